@@ -17,12 +17,19 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
 	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/cruntime"
@@ -36,38 +43,57 @@ var unpauseRequests = make(chan struct{})
 var done = make(chan struct{})
 var mu sync.Mutex
 
-// TODO: initialize with current state (handle the case that user enables auto-pause after it is already paused)
 var runtimePaused = false
-var version = "0.0.1"
-
-// TODO: #10597 make this configurable to support containerd/cri-o
-var runtime = "docker"
+var version = "0.0.2"
+
+var (
+	runtime       = flag.String("container-runtime", "docker", "The container runtime to pause: docker, containerd or cri-o")
+	interval      = flag.Duration("interval", time.Minute, "How long the API server must be idle before the cluster is paused")
+	namespaces    = flag.String("namespaces", "kube-system", "Comma separated list of namespaces to pause, or empty for all namespaces")
+	listenAddress = flag.String("listen-address", "0.0.0.0:8080", "The address to serve unpause requests and /status on")
+	configFile    = flag.String("config", "", "Path to a ConfigMap manifest whose data sets any of the above flags which are not passed on the command line")
+)
 
 func main() {
-	// TODO: #10595 make this configurable
-	const interval = time.Minute * 1
+	flag.Parse()
+	if *configFile != "" {
+		if err := applyConfig(*configFile); err != nil {
+			log.Printf("ignoring config %s: %v", *configFile, err)
+		}
+	}
+
+	if err := initPausedState(); err != nil {
+		log.Printf("unable to detect the paused state, assuming running: %v", err)
+	}
+
 	// channel for incoming messages
 	go func() {
+		timer := time.NewTimer(*interval)
 		for {
-			// On each iteration new timer is created
 			select {
-			// TODO: #10596 make it memory-leak proof
-			case <-time.After(interval):
+			case <-timer.C:
 				runPause()
+				timer.Reset(*interval)
 			case <-unpauseRequests:
 				fmt.Printf("Got request\n")
 				if runtimePaused {
 					runUnpause()
 				}
 
+				// any request to the API server restarts the idle timer
+				if !timer.Stop() {
+					<-timer.C
+				}
+				timer.Reset(*interval)
 				done <- struct{}{}
 			}
 		}
 	}()
 
+	http.HandleFunc("/status", statusHandler)
 	http.HandleFunc("/", handler) // each request calls handler
-	fmt.Printf("Starting auto-pause server %s at port 8080 \n", version)
-	log.Fatal(http.ListenAndServe("0.0.0.0:8080", nil))
+	fmt.Printf("Starting auto-pause server %s at %s for %s, pausing %q after %s\n", version, *listenAddress, *runtime, *namespaces, *interval)
+	log.Fatal(http.ListenAndServe(*listenAddress, nil))
 }
 
 // handler echoes the Path component of the requested URL.
@@ -77,21 +103,95 @@ func handler(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "allow")
 }
 
-func runPause() {
+// statusHandler reports the current state of the cluster, without unpausing it
+func statusHandler(w http.ResponseWriter, r *http.Request) {
 	mu.Lock()
-	defer mu.Unlock()
-	if runtimePaused {
-		return
+	st := cluster.AutoPauseStatus{
+		Paused:     runtimePaused,
+		Runtime:    *runtime,
+		Namespaces: namespaceList(),
+		Interval:   interval.String(),
+	}
+	mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(st); err != nil {
+		log.Printf("encode status: %v", err)
+	}
+}
+
+// applyConfig sets the flags which were not passed on the command line from the data of a ConfigMap manifest
+func applyConfig(path string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	cm := struct {
+		Data map[string]string `json:"data"`
+	}{}
+	if err := yaml.Unmarshal(b, &cm); err != nil {
+		return errors.Wrap(err, "unmarshal")
+	}
+
+	set := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) {
+		set[f.Name] = true
+	})
+	for k, v := range cm.Data {
+		if set[k] || k == "config" {
+			continue
+		}
+		if err := flag.Set(k, v); err != nil {
+			return errors.Wrapf(err, "invalid value %q for %s", v, k)
+		}
+	}
+	return nil
+}
+
+// namespaceList returns the namespaces to pause, or nil for all namespaces
+func namespaceList() []string {
+	var ns []string
+	for _, n := range strings.Split(*namespaces, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			ns = append(ns, n)
+		}
 	}
+	return ns
+}
 
+func newRuntime() (command.Runner, cruntime.Manager) {
 	r := command.NewExecRunner(true)
 
-	cr, err := cruntime.New(cruntime.Config{Type: runtime, Runner: r})
+	cr, err := cruntime.New(cruntime.Config{Type: *runtime, Runner: r})
 	if err != nil {
 		exit.Error(reason.InternalNewRuntime, "Failed runtime", err)
 	}
+	return r, cr
+}
 
-	uids, err := cluster.Pause(cr, r, []string{"kube-system"})
+// initPausedState handles auto-pause being enabled, or restarted, while the cluster is already paused
+func initPausedState() error {
+	_, cr := newRuntime()
+	ids, err := cr.ListContainers(cruntime.ListOptions{State: cruntime.Paused, Namespaces: namespaceList()})
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	runtimePaused = len(ids) > 0
+	return nil
+}
+
+func runPause() {
+	mu.Lock()
+	defer mu.Unlock()
+	if runtimePaused {
+		return
+	}
+
+	r, cr := newRuntime()
+	uids, err := cluster.Pause(cr, r, namespaceList())
 	if err != nil {
 		exit.Error(reason.GuestPause, "Pause", err)
 	}
@@ -106,14 +206,8 @@ func runUnpause() {
 	mu.Lock()
 	defer mu.Unlock()
 
-	r := command.NewExecRunner(true)
-
-	cr, err := cruntime.New(cruntime.Config{Type: runtime, Runner: r})
-	if err != nil {
-		exit.Error(reason.InternalNewRuntime, "Failed runtime", err)
-	}
-
-	uids, err := cluster.Unpause(cr, r, nil)
+	r, cr := newRuntime()
+	uids, err := cluster.Unpause(cr, r, namespaceList())
 	if err != nil {
 		exit.Error(reason.GuestUnpause, "Unpause", err)
 	}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestApplyConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "auto-pause")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "auto-pause-config.yaml")
+	cm := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: auto-pause
+  namespace: auto-pause
+data:
+  container-runtime: "cri-o"
+  interval: "5m0s"
+  namespaces: "kube-system, default"
+`
+	if err := ioutil.WriteFile(path, []byte("data:\n  interval: soon\n"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := applyConfig(path); err == nil {
+		t.Errorf("expected an error for an invalid interval")
+	}
+
+	if err := ioutil.WriteFile(path, []byte(cm), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	// flags passed on the command line take precedence over the config
+	if err := flag.Set("container-runtime", "containerd"); err != nil {
+		t.Fatalf("set: %v", err)
+	}
+	if err := applyConfig(path); err != nil {
+		t.Fatalf("applyConfig: %v", err)
+	}
+
+	if *runtime != "containerd" {
+		t.Errorf("runtime = %q, want %q", *runtime, "containerd")
+	}
+	if *interval != 5*time.Minute {
+		t.Errorf("interval = %s, want 5m0s", *interval)
+	}
+	ns := namespaceList()
+	if len(ns) != 2 || ns[0] != "kube-system" || ns[1] != "default" {
+		t.Errorf("namespaces = %v, want [kube-system default]", ns)
+	}
+}
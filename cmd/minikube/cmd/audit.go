@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"k8s.io/minikube/pkg/minikube/audit"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var (
+	auditCommand string
+	auditSince   string
+	auditUntil   string
+	auditStatus  string
+	auditOutput  string
+)
+
+// auditCmd represents the audit command
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Query the audit log of minikube commands",
+	Long: `Query the audit log of the minikube commands that were run on this host.
+Rows are only filtered by profile and user when --profile and --user are passed explicitly. The audit log is rotated once it grows beyond MaxAuditFileSizeInMB, which can be changed with "minikube config set MaxAuditFileSizeInMB SIZE".`,
+	Example: `minikube audit --command=start --status=failure
+minikube audit -p minikube --since=24h -o csv`,
+	Run: func(cmd *cobra.Command, args []string) {
+		f := audit.Filter{
+			Command: auditCommand,
+			Since:   parseAuditTime("since", auditSince),
+			Until:   parseAuditTime("until", auditUntil),
+			Status:  auditStatus,
+		}
+		if cmd.Flags().Changed(config.ProfileName) {
+			f.Profile = viper.GetString(config.ProfileName)
+		}
+		if cmd.Flags().Changed(config.UserFlag) {
+			f.User = viper.GetString(config.UserFlag)
+		}
+
+		r, err := audit.Query(f)
+		if err != nil {
+			exit.Error(reason.HostAuditLog, "Failed to query the audit log", err)
+		}
+
+		switch strings.ToLower(auditOutput) {
+		case "json":
+			b, err := r.JSON()
+			if err != nil {
+				exit.Error(reason.InternalJSONMarshal, "Failed to marshal audit log", err)
+			}
+			out.String(string(b))
+		case "csv":
+			c, err := r.CSV()
+			if err != nil {
+				exit.Error(reason.HostAuditLog, "Failed to format audit log", err)
+			}
+			out.String(c)
+		case "table":
+			if r.Len() == 0 {
+				out.Step(style.Empty, "No commands found in the audit log")
+				return
+			}
+			out.String(r.ASCIITable())
+		default:
+			exit.Message(reason.Usage, "invalid output format: {{.output}}. Valid values: 'table', 'json', 'csv'", out.V{"output": auditOutput})
+		}
+	},
+}
+
+// parseAuditTime parses the value of a time flag, which is either a duration before now or a timestamp.
+func parseAuditTime(flag string, value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	if d, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-d)
+	}
+	for _, layout := range []string{time.RFC3339, constants.TimeFormat, "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t
+		}
+	}
+	exit.Message(reason.Usage, `invalid --{{.flag}} value {{.value}}: must be a duration such as "24h", or a time such as "2006-01-02" or "2006-01-02T15:04:05Z07:00"`, out.V{"flag": flag, "value": value})
+	return time.Time{}
+}
+
+func init() {
+	auditCmd.Flags().StringVar(&auditCommand, "command", "", "Only show runs of this command, for example start")
+	auditCmd.Flags().StringVar(&auditSince, "since", "", `Only show commands started after this time, either a duration before now such as "24h", or a time such as "2021-02-03" or "2021-02-03T15:04:05Z"`)
+	auditCmd.Flags().StringVar(&auditUntil, "until", "", `Only show commands started before this time, in the same formats as --since`)
+	auditCmd.Flags().StringVar(&auditStatus, "status", "", "Only show commands that exited with this status. One of 'success', 'failure' or an exit code")
+	auditCmd.Flags().StringVarP(&auditOutput, "output", "o", "table", "The output format. One of 'table', 'json', 'csv'")
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"k8s.io/minikube/pkg/minikube/bundle"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var (
+	bundleKubernetesVersion string
+	bundleContainerRuntime  string
+	bundleDriver            string
+	bundleCNI               string
+	bundleAddons            []string
+)
+
+// bundleCmd represents the set of bundle subcommands
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Create bundles to start clusters without network access",
+	Long:  "A bundle is a single archive of everything minikube downloads to start a cluster, which can be used with 'minikube start --bundle' on hosts without network access",
+	Run: func(cmd *cobra.Command, args []string) {
+		exit.Message(reason.Usage, "Usage: minikube bundle create [FILE]")
+	},
+}
+
+// bundleCreateCmd represents the bundle create command
+var bundleCreateCmd = &cobra.Command{
+	Use:     "create [FILE]",
+	Short:   "Create a bundle of everything a cluster needs",
+	Long:    "Downloads the preload, base image or ISO, Kubernetes binaries, CNI manifest and addon images a cluster needs, and writes them into a single archive",
+	Example: "minikube bundle create --kubernetes-version=v1.20.2 --driver=docker --addons=storage-provisioner,metrics-server",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) > 1 {
+			exit.Message(reason.Usage, "Usage: minikube bundle create [FILE]")
+		}
+
+		opts := bundle.Options{
+			KubernetesVersion: normalizeKubernetesVersion(bundleKubernetesVersion),
+			ContainerRuntime:  bundleContainerRuntime,
+			Driver:            bundleDriver,
+			CNI:               bundleCNI,
+			Addons:            bundleAddons,
+		}
+		if opts.Driver == "" {
+			opts.Driver = viper.GetString("driver")
+		}
+		if opts.Driver == "" {
+			pick, _, _ := driver.Suggest(driver.Choices(false))
+			if pick.Name == "" {
+				exit.Message(reason.DrvNotDetected, "Unable to pick a driver, please specify one with --driver")
+			}
+			opts.Driver = pick.Name
+		}
+		if !driver.Supported(opts.Driver) {
+			exit.Message(reason.DrvUnsupportedOS, "The driver '{{.driver}}' is not supported on {{.os}}/{{.arch}}", out.V{"driver": opts.Driver, "os": runtime.GOOS, "arch": runtime.GOARCH})
+		}
+
+		path := fmt.Sprintf("minikube-bundle-%s-%s-%s.tar", opts.KubernetesVersion, opts.ContainerRuntime, opts.Driver)
+		if len(args) == 1 {
+			path = args[0]
+		}
+
+		out.Step(style.Caching, "Creating bundle for Kubernetes {{.version}} on {{.runtime}} with the {{.driver}} driver ...", out.V{"version": opts.KubernetesVersion, "runtime": opts.ContainerRuntime, "driver": opts.Driver})
+		m, err := bundle.Create(path, opts)
+		if err != nil {
+			exit.Error(reason.InetCacheBundle, "Failed to create bundle", err)
+		}
+		out.Step(style.Ready, "Created {{.path}} with {{.count}} files", out.V{"path": path, "count": len(m.Files)})
+		out.Step(style.Tip, "To start a cluster from it, run: minikube start --bundle={{.path}}", out.V{"path": path})
+	},
+}
+
+func init() {
+	bundleCreateCmd.Flags().StringVar(&bundleKubernetesVersion, "kubernetes-version", "", fmt.Sprintf("The Kubernetes version to bundle (ex: v1.2.3, 'stable' for %s, 'latest' for %s). Defaults to 'stable'.", constants.DefaultKubernetesVersion, constants.NewestKubernetesVersion))
+	bundleCreateCmd.Flags().StringVar(&bundleContainerRuntime, "container-runtime", "docker", "The container runtime to bundle (docker, cri-o, containerd)")
+	bundleCreateCmd.Flags().StringVar(&bundleDriver, "driver", "", "The driver to bundle the base image or ISO for. Defaults to the configured driver, or the one start would pick")
+	bundleCreateCmd.Flags().StringVar(&bundleCNI, "cni", "", "The CNI to bundle the manifest and images of, if it is downloaded (ex: calico, flannel, or a URL)")
+	bundleCreateCmd.Flags().StringSliceVar(&bundleAddons, "addons", []string{"storage-provisioner"}, "The addons to bundle the images of")
+	bundleCmd.AddCommand(bundleCreateCmd)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mirror"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var (
+	registryCacheOCIBin string
+	registryCachePort   int
+)
+
+// cacheRegistryCmd represents the cache registry command
+var cacheRegistryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Manage the pull-through registry cache shared by all profiles",
+	Long: `Manage a pull-through registry cache on the host, which is shared by all profiles.
+
+While it is running, the container runtime of every profile started is configured to pull docker.io, k8s.gcr.io, gcr.io and quay.io images through it, so that each image is only pulled once per host.`,
+}
+
+// cacheRegistryStartCmd represents the cache registry start command
+var cacheRegistryStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the pull-through registry cache",
+	Long:  "Start the pull-through registry cache, with --driver. Profiles use it from their next start.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if registryCacheOCIBin != oci.Docker && registryCacheOCIBin != oci.Podman {
+			exit.Message(reason.Usage, "The registry cache can only be run by the docker or podman driver, not {{.driver}}", out.V{"driver": registryCacheOCIBin})
+		}
+
+		out.Step(style.Caching, "Starting the registry cache in {{.dir}} ...", out.V{"dir": mirror.Dir()})
+		cfg, err := mirror.Start(registryCacheOCIBin, registryCachePort)
+		if err != nil {
+			exit.Error(reason.HostRegistryCache, "Failed to start the registry cache", err)
+		}
+		for _, u := range cfg.Upstreams {
+			out.Step(style.SubStep, "{{.registry}} is cached on port {{.port}}", out.V{"registry": u.Registry, "port": u.Port})
+		}
+		out.Step(style.Tip, "Profiles use the registry cache from their next start")
+	},
+}
+
+// cacheRegistryStopCmd represents the cache registry stop command
+var cacheRegistryStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the pull-through registry cache",
+	Long:  "Stop the pull-through registry cache. The cached images are kept for when it is started again.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := mirror.Stop(); err != nil {
+			exit.Error(reason.HostRegistryCache, "Failed to stop the registry cache", err)
+		}
+		out.Step(style.Stopped, "Stopped the registry cache")
+	},
+}
+
+func init() {
+	cacheRegistryStartCmd.Flags().StringVar(&registryCacheOCIBin, "driver", oci.Docker, "The driver to run the registry cache with, either docker or podman")
+	cacheRegistryStartCmd.Flags().IntVar(&registryCachePort, "port", mirror.DefaultPort, "The host port of the first registry cache, the others listening on the ports following it")
+	cacheRegistryCmd.AddCommand(cacheRegistryStartCmd)
+	cacheRegistryCmd.AddCommand(cacheRegistryStopCmd)
+	cacheCmd.AddCommand(cacheRegistryCmd)
+}
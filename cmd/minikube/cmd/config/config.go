@@ -134,6 +134,10 @@ var settings = []Setting{
 		name: config.WantNoneDriverWarning,
 		set:  SetBool,
 	},
+	{
+		name: config.MaxAuditFileSizeInMB,
+		set:  SetInt,
+	},
 	{
 		name: config.ProfileName,
 		set:  SetString,
@@ -80,3 +80,13 @@ func Set(name string, value string) error {
 	// Write the value
 	return config.WriteConfig(localpath.ConfigFile(), cc)
 }
+
+// Validate runs the validations of a property against a value, without writing it to the config file.
+// Properties which are not configurable via `minikube config` have no validations and are always valid.
+func Validate(name string, value string) error {
+	s, err := findSetting(name)
+	if err != nil {
+		return nil
+	}
+	return run(name, value, s.validations)
+}
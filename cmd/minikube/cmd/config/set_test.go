@@ -65,6 +65,18 @@ func TestSetOK(t *testing.T) {
 	}
 }
 
+func TestValidate(t *testing.T) {
+	if err := Validate("memory", "10a"); err == nil {
+		t.Errorf("Validate did not return error for invalid memory")
+	}
+	if err := Validate("cpus", "4"); err != nil {
+		t.Errorf("Validate returned error for valid cpus: %v", err)
+	}
+	if err := Validate("nonexistent", "10"); err != nil {
+		t.Errorf("Validate returned error for a property without validations: %v", err)
+	}
+}
+
 func createTestConfig(t *testing.T) {
 	t.Helper()
 	td, err := ioutil.TempDir("", "config")
@@ -0,0 +1,301 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/machine/libmachine/ssh"
+	"github.com/mitchellh/go-ps"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/shell"
+	"k8s.io/minikube/pkg/util/retry"
+)
+
+const (
+	// containerdGuestSocket is the containerd socket within the node
+	containerdGuestSocket = "/run/containerd/containerd.sock"
+	// containerdTunnelProcessFileName is the filename of the ssh process forwarding the containerd socket, within the profile
+	containerdTunnelProcessFileName = ".containerd-env-process"
+)
+
+var containerdEnvTmpl = fmt.Sprintf(
+	"{{ .Prefix }}%s{{ .Delimiter }}{{ .ContainerdAddress }}{{ .Suffix }}"+
+		"{{ .Prefix }}%s{{ .Delimiter }}{{ .ContainerdNamespace }}{{ .Suffix }}"+
+		"{{ .Prefix }}%s{{ .Delimiter }}{{ .ContainerRuntimeEndpoint }}{{ .Suffix }}"+
+		"{{ .Prefix }}%s{{ .Delimiter }}{{ .MinikubeContainerdProfile }}{{ .Suffix }}"+
+		"{{ .UsageHint }}",
+	constants.ContainerdAddressEnv,
+	constants.ContainerdNamespaceEnv,
+	constants.ContainerRuntimeEndpointEnv,
+	constants.MinikubeActiveContainerdEnv)
+
+// ContainerdShellConfig represents the shell config for containerd
+type ContainerdShellConfig struct {
+	shell.Config
+	ContainerdAddress         string
+	ContainerdNamespace       string
+	ContainerRuntimeEndpoint  string
+	MinikubeContainerdProfile string
+}
+
+var containerdUnset bool
+
+// containerdShellCfgSet generates context variables for "containerd-env"
+func containerdShellCfgSet(ec ContainerdEnvConfig, envMap map[string]string) *ContainerdShellConfig {
+	const usgPlz = "To point your shell to minikube's containerd service, run:"
+	usgCmd := fmt.Sprintf("minikube -p %s containerd-env", ec.profile)
+	return &ContainerdShellConfig{
+		Config:                    *shell.CfgSet(ec.EnvConfig, usgPlz, usgCmd),
+		ContainerdAddress:         envMap[constants.ContainerdAddressEnv],
+		ContainerdNamespace:       envMap[constants.ContainerdNamespaceEnv],
+		ContainerRuntimeEndpoint:  envMap[constants.ContainerRuntimeEndpointEnv],
+		MinikubeContainerdProfile: envMap[constants.MinikubeActiveContainerdEnv],
+	}
+}
+
+// containerdEnvCmd represents the containerd-env command
+var containerdEnvCmd = &cobra.Command{
+	Use:   "containerd-env",
+	Short: "Configure environment to use minikube's containerd service",
+	Long: `Sets up nerdctl and crictl env variables, pointing them at minikube's containerd service.
+
+The containerd socket is forwarded to the host over ssh, by a process which keeps running until 'minikube containerd-env --unset' is run.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var err error
+
+		shl := shell.ForceShell
+		if shl == "" {
+			shl, err = shell.Detect()
+			if err != nil {
+				exit.Error(reason.InternalShellDetect, "Error detecting shell", err)
+			}
+		}
+		sh := shell.EnvConfig{
+			Shell: shl,
+		}
+
+		cname := ClusterFlagValue()
+		if containerdUnset {
+			if err := killContainerdTunnel(cname); err != nil {
+				klog.Warningf("unable to stop the containerd socket forwarding: %v", err)
+			}
+			if err := containerdUnsetScript(ContainerdEnvConfig{EnvConfig: sh}, os.Stdout); err != nil {
+				exit.Error(reason.InternalEnvScript, "Error generating unset output", err)
+			}
+			return
+		}
+
+		if runtime.GOOS == "windows" {
+			exit.Message(reason.Usage, "The containerd-env command is not supported on Windows, as it forwards a unix socket")
+		}
+
+		co := mustload.Running(cname)
+		driverName := co.CP.Host.DriverName
+
+		if driverName == driver.None {
+			exit.Message(reason.EnvDriverConflict, `'none' driver does not support 'minikube containerd-env' command`)
+		}
+
+		if len(co.Config.Nodes) > 1 {
+			exit.Message(reason.EnvMultiConflict, `The containerd-env command is incompatible with multi-node clusters. Use the 'registry' add-on: https://minikube.sigs.k8s.io/docs/handbook/registry/`)
+		}
+
+		if co.Config.KubernetesConfig.ContainerRuntime != "containerd" {
+			exit.Message(reason.Usage, `The containerd-env command is only compatible with the "containerd" runtime, but this cluster was configured to use the "{{.runtime}}" runtime.`,
+				out.V{"runtime": co.Config.KubernetesConfig.ContainerRuntime})
+		}
+
+		client, err := createExternalSSHClient(co.CP.Host.Driver)
+		if err != nil {
+			exit.Error(reason.IfSSHClient, "Error getting ssh client", err)
+		}
+
+		socket, err := containerdTunnel(cname, co.CP.Runner, client)
+		if err != nil {
+			exit.Error(reason.EnvContainerdTunnel, "Error forwarding the containerd socket", err)
+		}
+
+		ec := ContainerdEnvConfig{
+			EnvConfig: sh,
+			profile:   cname,
+			socket:    socket,
+		}
+		if err := containerdSetScript(ec, os.Stdout); err != nil {
+			exit.Error(reason.InternalEnvScript, "Error generating set output", err)
+		}
+	},
+}
+
+// ContainerdEnvConfig encapsulates all external inputs into shell generation for containerd
+type ContainerdEnvConfig struct {
+	shell.EnvConfig
+	profile string
+	socket  string
+}
+
+// containerdSetScript writes out a shell-compatible 'containerd-env' script
+func containerdSetScript(ec ContainerdEnvConfig, w io.Writer) error {
+	envVars := containerdEnvVars(ec)
+	return shell.SetScript(ec.EnvConfig, w, containerdEnvTmpl, containerdShellCfgSet(ec, envVars))
+}
+
+// containerdUnsetScript writes out a shell-compatible 'containerd-env unset' script
+func containerdUnsetScript(ec ContainerdEnvConfig, w io.Writer) error {
+	return shell.UnsetScript(ec.EnvConfig, w, containerdEnvNames())
+}
+
+// containerdEnvVars gets the necessary env variables to allow the use of minikube's containerd service
+func containerdEnvVars(ec ContainerdEnvConfig) map[string]string {
+	return map[string]string{
+		constants.ContainerdAddressEnv: ec.socket,
+		// the namespace of the containers and images managed by Kubernetes
+		constants.ContainerdNamespaceEnv:      "k8s.io",
+		constants.ContainerRuntimeEndpointEnv: "unix://" + ec.socket,
+		constants.MinikubeActiveContainerdEnv: ec.profile,
+	}
+}
+
+// containerdEnvNames gets the necessary env variables to reset after using minikube's containerd service
+func containerdEnvNames() []string {
+	return []string{
+		constants.ContainerdAddressEnv,
+		constants.ContainerdNamespaceEnv,
+		constants.ContainerRuntimeEndpointEnv,
+		constants.MinikubeActiveContainerdEnv,
+	}
+}
+
+// containerdTunnel forwards the containerd socket of the node to a socket within the profile over ssh,
+// unless it is already, and returns the path of the socket
+func containerdTunnel(profile string, r command.Runner, client *ssh.ExternalClient) (string, error) {
+	socket := localpath.MakeMiniPath("profiles", profile, "containerd.sock")
+	if pid, err := containerdTunnelPid(profile); err != nil {
+		klog.Warningf("unable to find the containerd socket forwarding: %v", err)
+	} else if pid != 0 {
+		if _, err := os.Stat(socket); err == nil {
+			klog.Infof("containerd socket is already forwarded by pid %d", pid)
+			return socket, nil
+		}
+		if err := killContainerdTunnel(profile); err != nil {
+			return "", errors.Wrap(err, "stop stale forwarding")
+		}
+	}
+
+	// the socket is only accessible to root, while ssh forwards it as the ssh user
+	if _, err := r.RunCmd(exec.Command("/bin/bash", "-c", fmt.Sprintf("sudo chgrp $(id -g) %s && sudo chmod g+rw %s", containerdGuestSocket, containerdGuestSocket))); err != nil {
+		return "", errors.Wrap(err, "socket permissions")
+	}
+
+	if err := os.Remove(socket); err != nil && !os.IsNotExist(err) {
+		return "", errors.Wrap(err, "remove stale socket")
+	}
+	args := append([]string{}, client.BaseArgs...)
+	args = append(args, "-N", "-o", "ExitOnForwardFailure=yes", "-L", fmt.Sprintf("%s:%s", socket, containerdGuestSocket))
+	c := exec.Command(client.BinaryPath, args...)
+	klog.Infof("Starting %v", c.Args)
+	// not inheriting stdout, so that eval $(minikube containerd-env) does not wait for ssh to exit
+	if err := c.Start(); err != nil {
+		return "", errors.Wrap(err, "start ssh")
+	}
+	pidPath := localpath.MakeMiniPath("profiles", profile, containerdTunnelProcessFileName)
+	if err := ioutil.WriteFile(pidPath, []byte(strconv.Itoa(c.Process.Pid)), 0o644); err != nil {
+		return "", errors.Wrap(err, "write pid")
+	}
+
+	exists := func() error {
+		_, err := os.Stat(socket)
+		return err
+	}
+	if err := retry.Local(exists, 15*time.Second); err != nil {
+		if kerr := killContainerdTunnel(profile); kerr != nil {
+			klog.Warningf("unable to stop the containerd socket forwarding: %v", kerr)
+		}
+		return "", errors.Wrap(err, "wait for socket")
+	}
+	return socket, nil
+}
+
+// containerdTunnelPid returns the pid of the process forwarding the containerd socket of profile, or 0 if there is none
+func containerdTunnelPid(profile string) (int, error) {
+	pidPath := localpath.MakeMiniPath("profiles", profile, containerdTunnelProcessFileName)
+	data, err := ioutil.ReadFile(pidPath)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "ReadFile")
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, errors.Wrap(err, "error parsing pid")
+	}
+	// os.FindProcess does not check if pid is running :(
+	entry, err := ps.FindProcess(pid)
+	if err != nil {
+		return 0, errors.Wrap(err, "ps.FindProcess")
+	}
+	if entry == nil || !strings.HasPrefix(filepath.Base(entry.Executable()), "ssh") {
+		klog.Infof("Stale pid: %d", pid)
+		return 0, os.Remove(pidPath)
+	}
+	return pid, nil
+}
+
+// killContainerdTunnel stops forwarding the containerd socket of profile, if it is
+func killContainerdTunnel(profile string) error {
+	pid, err := containerdTunnelPid(profile)
+	if err != nil || pid == 0 {
+		return err
+	}
+
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return errors.Wrap(err, "os.FindProcess")
+	}
+	klog.Infof("Killing pid %d ...", pid)
+	if err := proc.Kill(); err != nil {
+		return errors.Wrapf(err, "Kill(%d)", pid)
+	}
+	if err := os.Remove(localpath.MakeMiniPath("profiles", profile, "containerd.sock")); err != nil && !os.IsNotExist(err) {
+		klog.Warningf("unable to remove socket: %v", err)
+	}
+	return os.Remove(localpath.MakeMiniPath("profiles", profile, containerdTunnelProcessFileName))
+}
+
+func init() {
+	containerdEnvCmd.Flags().StringVar(&shell.ForceShell, "shell", "", "Force environment to be configured for a specified shell: [fish, cmd, powershell, tcsh, bash, zsh], default is auto-detect")
+	containerdEnvCmd.Flags().BoolVarP(&containerdUnset, "unset", "u", false, "Unset variables instead of setting them")
+}
@@ -263,20 +263,55 @@ func deleteProfile(ctx context.Context, profile *config.Profile) error {
 	}
 
 	if err == nil && (driver.BareMetal(cc.Driver) || driver.IsSSH(cc.Driver)) {
-		if err := uninstallKubernetes(api, *cc, cc.Nodes[0], viper.GetString(cmdcfg.Bootstrapper)); err != nil {
-			deletionError, ok := err.(DeletionError)
-			if ok {
-				delErr := profileDeletionErr(profile.Name, fmt.Sprintf("%v", err))
-				deletionError.Err = delErr
-				return deletionError
+		// the machines of the ssh driver outlive the cluster, so each of them is reset
+		nodes := cc.Nodes[:1]
+		if driver.IsSSH(cc.Driver) {
+			nodes = cc.Nodes
+		}
+		for _, n := range nodes {
+			if err := uninstallKubernetes(api, *cc, n, viper.GetString(cmdcfg.Bootstrapper)); err != nil {
+				if driver.IsSSH(cc.Driver) {
+					// an unreachable machine should not keep the others from being reset, nor the profile from being deleted
+					out.FailureT("Failed to reset Kubernetes on {{.name}}: {{.error}}", out.V{"name": config.MachineName(*cc, n), "error": err})
+					continue
+				}
+				deletionError, ok := err.(DeletionError)
+				if ok {
+					delErr := profileDeletionErr(profile.Name, fmt.Sprintf("%v", err))
+					deletionError.Err = delErr
+					return deletionError
+				}
+				return err
 			}
-			return err
 		}
 	}
 
 	if err := killMountProcess(); err != nil {
 		out.FailureT("Failed to kill mount process: {{.error}}", out.V{"error": err})
 	}
+	killMountProcesses(cc)
+	if cc != nil {
+		for _, m := range cc.Mounts {
+			if m.Type != cluster.MountTypeNFS {
+				continue
+			}
+			if err := cluster.UnexportNFS(cc.Name, m.GuestPath); err != nil {
+				out.FailureT("Failed to remove the NFS export of {{.path}}: {{.error}}", out.V{"path": m.HostPath, "error": err})
+			}
+		}
+	}
+
+	if err := killContainerdTunnel(profile.Name); err != nil {
+		klog.Warningf("Failed to stop the containerd socket forwarding: %v", err)
+	}
+
+	// snapshots are removed before the hosts, while the profile config still tells which machines they hold
+	if cc != nil {
+		if err := machine.DeleteSnapshots(cc); err != nil {
+			out.FailureT("Failed to delete the snapshots of {{.name}}: {{.error}}", out.V{"name": profile.Name, "error": err})
+		}
+	}
+	deleteSnapshotsDirectory(profile.Name)
 
 	deleteHosts(api, cc)
 
@@ -450,6 +485,16 @@ func deleteProfileDirectory(profile string) {
 	}
 }
 
+func deleteSnapshotsDirectory(profile string) {
+	snapshotsDir := localpath.Snapshots(profile)
+	if _, err := os.Stat(snapshotsDir); err == nil {
+		out.Step(style.DeletingHost, `Removing {{.directory}} ...`, out.V{"directory": snapshotsDir})
+		if err := os.RemoveAll(snapshotsDir); err != nil {
+			exit.Error(reason.GuestProfileDeletion, "Unable to remove snapshots directory", err)
+		}
+	}
+}
+
 func deleteMachineDirectories(cc *config.ClusterConfig) {
 	if cc != nil {
 		for _, n := range cc.Nodes {
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/registry"
+)
+
+var driverOutput string
+
+// driverCmd represents the set of driver subcommands
+var driverCmd = &cobra.Command{
+	Use:   "driver",
+	Short: "Inspect and diagnose the drivers available on this host",
+	Long:  "Inspect the drivers minikube can use on this host, why it would choose one over another, and diagnose why one does not work",
+	Run: func(cmd *cobra.Command, args []string) {
+		exit.Message(reason.Usage, "Usage: minikube driver [list|status|doctor]")
+	},
+}
+
+// driverReport is what is known about a driver on this host, and why start would or would not choose it
+type driverReport struct {
+	Name             string
+	Type             string
+	Priority         string
+	Default          bool
+	Installed        bool
+	Healthy          bool
+	Running          bool
+	NeedsImprovement bool
+	Error            string `json:",omitempty"`
+	Reason           string `json:",omitempty"`
+	Fix              string `json:",omitempty"`
+	Doc              string `json:",omitempty"`
+	Chosen           bool
+	Rejection        string `json:",omitempty"`
+}
+
+// driverReports returns a report for every driver, in the order start considers them
+func driverReports() []driverReport {
+	choices := driver.Choices(false)
+	pick, alternates, rejects := driver.Suggest(choices)
+	rejections := map[string]string{}
+	for _, ds := range append(alternates, rejects...) {
+		rejections[ds.Name] = ds.Rejection
+	}
+
+	reports := []driverReport{}
+	for _, ds := range choices {
+		r := driverReport{
+			Name:             ds.Name,
+			Type:             driver.MachineType(ds.Name),
+			Priority:         registry.Driver(ds.Name).Priority.String(),
+			Default:          ds.Default,
+			Installed:        ds.State.Installed,
+			Healthy:          ds.State.Healthy,
+			Running:          ds.State.Running,
+			NeedsImprovement: ds.State.NeedsImprovement,
+			Reason:           ds.State.Reason,
+			Fix:              ds.State.Fix,
+			Doc:              ds.State.Doc,
+			Chosen:           ds.Name == pick.Name,
+			Rejection:        rejections[ds.Name],
+		}
+		if ds.State.Error != nil {
+			r.Error = ds.State.Error.Error()
+		}
+		reports = append(reports, r)
+	}
+	return reports
+}
+
+// targetDriver returns the driver named in args, or else the one start would use
+func targetDriver(args []string) string {
+	if len(args) > 0 {
+		name := args[0]
+		if registry.Driver(name).Empty() {
+			exit.Message(reason.DrvNotFound, "The driver '{{.driver}}' was not found", out.V{"driver": name})
+		}
+		return registry.Driver(name).Name
+	}
+
+	profile := viper.GetString(config.ProfileName)
+	if cc, err := config.Load(profile); err == nil {
+		klog.Infof("using the driver of profile %q", profile)
+		return cc.Driver
+	}
+	if d := viper.GetString("driver"); d != "" {
+		return d
+	}
+	pick, _, _ := driver.Suggest(driver.Choices(false))
+	if pick.Name == "" {
+		exit.Message(reason.DrvNotDetected, "No possible driver was detected, run 'minikube driver list' to see why")
+	}
+	return pick.Name
+}
+
+// yesNo formats a boolean for a table
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func renderTable(header []string, rows [][]string) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(header)
+	table.SetAutoFormatHeaders(false)
+	table.SetAutoWrapText(false)
+	table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+	table.SetCenterSeparator("|")
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+func init() {
+	driverCmd.PersistentFlags().StringVarP(&driverOutput, "output", "o", "table", "The output format. One of 'table', 'json'")
+	driverCmd.AddCommand(driverListCmd)
+	driverCmd.AddCommand(driverStatusCmd)
+	driverCmd.AddCommand(driverDoctorCmd)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/registry"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+// doctorCheck is the JSON form of a registry.Check
+type doctorCheck struct {
+	Name    string
+	Passed  bool
+	Warning bool
+	Error   string `json:",omitempty"`
+	Fix     string `json:",omitempty"`
+	Doc     string `json:",omitempty"`
+}
+
+var driverDoctorCmd = &cobra.Command{
+	Use:   "doctor [DRIVER]",
+	Short: "Runs deeper checks of a driver and its host",
+	Long:  "Runs deeper checks of a driver and its host than start does, such as resources and cgroups available to container runtimes, or the libvirt connection. Defaults to the driver of the profile, or the one start would choose.",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := targetDriver(args)
+		def := registry.Driver(name)
+
+		st := def.Status()
+		installed := registry.Check{Name: name + " is installed", Error: st.Error, Fix: st.Fix, Doc: st.Doc}
+		checks := []registry.Check{installed}
+		if st.Installed && st.Healthy && def.Doctor != nil {
+			checks = append(checks, def.Doctor()...)
+		}
+
+		failed := 0
+		results := []doctorCheck{}
+		for _, c := range checks {
+			r := doctorCheck{Name: c.Name, Passed: c.Error == nil, Warning: c.Warning, Fix: c.Fix, Doc: c.Doc}
+			if c.Error != nil {
+				r.Error = c.Error.Error()
+				if !c.Warning {
+					failed++
+				}
+			}
+			results = append(results, r)
+		}
+
+		switch strings.ToLower(driverOutput) {
+		case "json":
+			b, err := json.Marshal(struct {
+				Driver string
+				Checks []doctorCheck
+			}{name, results})
+			if err != nil {
+				exit.Error(reason.InternalJSONMarshal, "Failed to marshal driver checks", err)
+			}
+			out.String(string(b))
+		case "table":
+			out.Step(style.HealthCheck, "Checking the {{.driver}} driver ...", out.V{"driver": name})
+			for _, r := range results {
+				switch {
+				case r.Passed:
+					out.Step(style.Check, r.Name)
+				case r.Warning:
+					out.Step(style.Warning, "{{.check}}: {{.error}}", out.V{"check": r.Name, "error": r.Error})
+				default:
+					out.Step(style.Failure, "{{.check}}: {{.error}}", out.V{"check": r.Name, "error": r.Error})
+				}
+				if !r.Passed && r.Fix != "" {
+					out.Step(style.Tip, "    {{.fix}}", out.V{"fix": r.Fix})
+				}
+				if !r.Passed && r.Doc != "" {
+					out.Step(style.Documentation, "    {{.url}}", out.V{"url": r.Doc})
+				}
+			}
+			if def.Doctor == nil {
+				out.Step(style.Empty, "The {{.driver}} driver has no further checks", out.V{"driver": name})
+			}
+		default:
+			exit.Message(reason.Usage, "invalid output format: {{.output}}. Valid values: 'table', 'json'", out.V{"output": driverOutput})
+		}
+
+		if failed > 0 {
+			exit.Message(reason.DrvUnhealthy, "The {{.driver}} driver failed {{.count}} checks", out.V{"driver": name, "count": failed})
+		}
+	},
+}
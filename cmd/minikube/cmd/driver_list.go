@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+)
+
+var driverListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the drivers available on this host, in the order start considers them",
+	Long:  "Lists every driver minikube supports on this host with its state, in the order start considers them, and why each was or was not chosen.",
+	Run: func(cmd *cobra.Command, args []string) {
+		reports := driverReports()
+		switch strings.ToLower(driverOutput) {
+		case "json":
+			b, err := json.Marshal(reports)
+			if err != nil {
+				exit.Error(reason.InternalJSONMarshal, "Failed to marshal drivers", err)
+			}
+			out.String(string(b))
+		case "table":
+			rows := [][]string{}
+			for i, r := range reports {
+				chosen := r.Rejection
+				if r.Chosen {
+					chosen = "chosen"
+				}
+				rows = append(rows, []string{strconv.Itoa(i + 1), r.Name, r.Type, r.Priority, yesNo(r.Default), yesNo(r.Installed), yesNo(r.Healthy), yesNo(r.Running), yesNo(r.NeedsImprovement), chosen})
+			}
+			renderTable([]string{"Rank", "Driver", "Type", "Priority", "Default", "Installed", "Healthy", "Running", "Needs Improvement", "Selection"}, rows)
+		default:
+			exit.Message(reason.Usage, "invalid output format: {{.output}}. Valid values: 'table', 'json'", out.V{"output": driverOutput})
+		}
+	},
+}
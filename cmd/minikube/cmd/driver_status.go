@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+)
+
+var driverStatusCmd = &cobra.Command{
+	Use:   "status [DRIVER]",
+	Short: "Shows the state of a driver and why it was or was not chosen",
+	Long:  "Shows the state of a driver, how to fix it, and why start would or would not choose it. Defaults to the driver of the profile, or the one start would choose.",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := targetDriver(args)
+		var report *driverReport
+		for _, r := range driverReports() {
+			if r.Name == name {
+				r := r
+				report = &r
+			}
+		}
+		if report == nil {
+			exit.Message(reason.DrvUnsupportedOS, "The driver '{{.driver}}' is not supported on this host", out.V{"driver": name})
+		}
+
+		switch strings.ToLower(driverOutput) {
+		case "json":
+			b, err := json.Marshal(report)
+			if err != nil {
+				exit.Error(reason.InternalJSONMarshal, "Failed to marshal driver status", err)
+			}
+			out.String(string(b))
+		case "table":
+			selection := report.Rejection
+			if report.Chosen {
+				selection = "chosen"
+			}
+			rows := [][]string{
+				{"Driver", report.Name},
+				{"Type", report.Type},
+				{"Priority", report.Priority},
+				{"Default", yesNo(report.Default)},
+				{"Installed", yesNo(report.Installed)},
+				{"Healthy", yesNo(report.Healthy)},
+				{"Running", yesNo(report.Running)},
+				{"Needs Improvement", yesNo(report.NeedsImprovement)},
+				{"Selection", selection},
+			}
+			for _, f := range []struct{ name, value string }{{"Error", report.Error}, {"Reason", report.Reason}, {"Fix", report.Fix}, {"Documentation", report.Doc}} {
+				if f.value != "" {
+					rows = append(rows, []string{f.name, f.value})
+				}
+			}
+			renderTable([]string{"Field", "Value"}, rows)
+		default:
+			exit.Message(reason.Usage, "invalid output format: {{.output}}. Valid values: 'table', 'json'", out.V{"output": driverOutput})
+		}
+	},
+}
@@ -17,19 +17,33 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var (
+	imageFormat string
+	buildTag    string
+	buildFile   string
 )
 
 // imageCmd represents the image command
 var imageCmd = &cobra.Command{
 	Use:   "image",
-	Short: "Load a local image into minikube",
-	Long:  "Load a local image into minikube",
+	Short: "Manage images",
+	Long:  "Manage the images of the container runtime on every node of the cluster",
 }
 
 // loadImageCmd represents the image load command
@@ -42,10 +56,7 @@ var loadImageCmd = &cobra.Command{
 			exit.Message(reason.Usage, "Please provide an image in your local daemon to load into minikube via <minikube image load IMAGE_NAME>")
 		}
 		// Cache and load images into docker daemon
-		profile, err := config.LoadProfile(viper.GetString(config.ProfileName))
-		if err != nil {
-			exit.Error(reason.Usage, "loading profile", err)
-		}
+		profile := imageProfile()
 		img := args[0]
 		if err := machine.CacheAndLoadImages([]string{img}, []*config.Profile{profile}); err != nil {
 			exit.Error(reason.GuestImageLoad, "Failed to load image", err)
@@ -53,6 +64,183 @@ var loadImageCmd = &cobra.Command{
 	},
 }
 
+// listImageCmd represents the image ls command
+var listImageCmd = &cobra.Command{
+	Use:     "ls",
+	Aliases: []string{"list"},
+	Short:   "List images",
+	Long:    "List the images present on the nodes of the cluster",
+	Run: func(cmd *cobra.Command, args []string) {
+		validateImageFormat()
+		images, err := machine.ListImages([]*config.Profile{imageProfile()})
+		if err != nil {
+			exit.Error(reason.GuestImageList, "Failed to list images", err)
+		}
+		if imageFormat == "json" {
+			printImageJSON(images)
+			return
+		}
+		for _, tag := range imageTags(images) {
+			out.Ln(tag)
+		}
+	},
+}
+
+// removeImageCmd represents the image rm command
+var removeImageCmd = &cobra.Command{
+	Use:     "rm IMAGE [IMAGE...]",
+	Aliases: []string{"remove"},
+	Short:   "Remove one or more images",
+	Long:    "Remove one or more images from every node of the cluster",
+	Run: func(cmd *cobra.Command, args []string) {
+		validateImageFormat()
+		if len(args) == 0 {
+			exit.Message(reason.Usage, "Please provide an image to remove via <minikube image rm IMAGE_NAME>")
+		}
+		if err := machine.RemoveImages(args, []*config.Profile{imageProfile()}); err != nil {
+			exit.Error(reason.GuestImageRemove, "Failed to remove images", err)
+		}
+		printImageResult("removed", args, style.Deleted, "Removed image {{.image}}")
+	},
+}
+
+// pullImageCmd represents the image pull command
+var pullImageCmd = &cobra.Command{
+	Use:   "pull IMAGE [IMAGE...]",
+	Short: "Pull one or more images",
+	Long:  "Pull one or more images from a registry onto every node of the cluster",
+	Run: func(cmd *cobra.Command, args []string) {
+		validateImageFormat()
+		if len(args) == 0 {
+			exit.Message(reason.Usage, "Please provide an image to pull via <minikube image pull IMAGE_NAME>")
+		}
+		if err := machine.PullImages(args, []*config.Profile{imageProfile()}); err != nil {
+			exit.Error(reason.GuestImagePull, "Failed to pull images", err)
+		}
+		printImageResult("pulled", args, style.Pulling, "Pulled image {{.image}}")
+	},
+}
+
+// tagImageCmd represents the image tag command
+var tagImageCmd = &cobra.Command{
+	Use:   "tag SOURCE TARGET",
+	Short: "Tag an image",
+	Long:  "Create a tag TARGET that refers to the image SOURCE on every node of the cluster",
+	Run: func(cmd *cobra.Command, args []string) {
+		validateImageFormat()
+		if len(args) != 2 {
+			exit.Message(reason.Usage, "Please provide a source and target image via <minikube image tag SOURCE TARGET>")
+		}
+		if err := machine.TagImage(args[0], args[1], []*config.Profile{imageProfile()}); err != nil {
+			exit.Error(reason.GuestImageTag, "Failed to tag image", err)
+		}
+		printImageResult("tagged", args[1:], style.Success, "Tagged image {{.image}}")
+	},
+}
+
+// saveImageCmd represents the image save command
+var saveImageCmd = &cobra.Command{
+	Use:   "save IMAGE [FILE]",
+	Short: "Save an image to a tarball on the host",
+	Long:  "Save an image from the cluster into a tarball on the host. FILE defaults to the image name, with a .tar extension.",
+	Run: func(cmd *cobra.Command, args []string) {
+		validateImageFormat()
+		if len(args) == 0 || len(args) > 2 {
+			exit.Message(reason.Usage, "Please provide an image to save via <minikube image save IMAGE_NAME [FILE]>")
+		}
+		img := args[0]
+		dst := strings.NewReplacer("/", "_", ":", "_", "@", "_").Replace(img) + ".tar"
+		if len(args) == 2 {
+			dst = args[1]
+		}
+		if err := machine.SaveImage(img, dst, imageProfile()); err != nil {
+			exit.Error(reason.GuestImageSave, "Failed to save image", err)
+		}
+		if imageFormat == "json" {
+			printImageJSON(map[string]string{"image": img, "file": dst})
+			return
+		}
+		out.Step(style.Success, "Saved image {{.image}} to {{.file}}", out.V{"image": img, "file": dst})
+	},
+}
+
+// buildImageCmd represents the image build command
+var buildImageCmd = &cobra.Command{
+	Use:   "build PATH",
+	Short: "Build an image",
+	Long:  "Build an image from a local Dockerfile context directory, inside every node of the cluster",
+	Run: func(cmd *cobra.Command, args []string) {
+		validateImageFormat()
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "Please provide a build context directory via <minikube image build PATH>")
+		}
+		if err := machine.BuildImage(args[0], buildFile, buildTag, []*config.Profile{imageProfile()}); err != nil {
+			exit.Error(reason.GuestImageBuild, "Failed to build image", err)
+		}
+		if imageFormat == "json" {
+			printImageJSON(map[string]string{"context": args[0], "tag": buildTag})
+			return
+		}
+		out.Step(style.Success, "Built image from {{.context}}", out.V{"context": args[0]})
+	},
+}
+
+// imageProfile loads the profile the image commands operate on
+func imageProfile() *config.Profile {
+	profile, err := config.LoadProfile(viper.GetString(config.ProfileName))
+	if err != nil {
+		exit.Error(reason.Usage, "loading profile", err)
+	}
+	return profile
+}
+
+// validateImageFormat exits if --format has an unsupported value
+func validateImageFormat() {
+	if imageFormat != "" && imageFormat != "json" {
+		exit.Message(reason.InternalOutputUsage, "error: --format must be 'json'")
+	}
+}
+
+// printImageResult prints the images an action was applied to
+func printImageResult(action string, images []string, st style.Enum, format string) {
+	if imageFormat == "json" {
+		printImageJSON(map[string][]string{action: images})
+		return
+	}
+	for _, img := range images {
+		out.Step(st, format, out.V{"image": img})
+	}
+}
+
+// printImageJSON prints v as JSON
+func printImageJSON(v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		exit.Error(reason.InternalJSONMarshal, "image json failure", err)
+	}
+	out.Ln(string(b))
+}
+
+// imageTags returns the sorted tags of the images, using the ID for untagged images
+func imageTags(images []cruntime.ListImage) []string {
+	tags := []string{}
+	for _, img := range images {
+		if len(img.RepoTags) == 0 {
+			tags = append(tags, fmt.Sprintf("<none>@%s", img.ID))
+			continue
+		}
+		tags = append(tags, img.RepoTags...)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
 func init() {
+	for _, c := range []*cobra.Command{listImageCmd, removeImageCmd, pullImageCmd, tagImageCmd, saveImageCmd, buildImageCmd} {
+		c.Flags().StringVar(&imageFormat, "format", "", "Output format. Accepts: json")
+		imageCmd.AddCommand(c)
+	}
+	buildImageCmd.Flags().StringVarP(&buildTag, "tag", "t", "", "Tag to apply to the new image (optional)")
+	buildImageCmd.Flags().StringVarP(&buildFile, "file", "f", "", "Path to the Dockerfile, relative to PATH (default \"Dockerfile\")")
 	imageCmd.AddCommand(loadImageCmd)
 }
@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/reason"
+)
+
+// kubeadmCmd represents the kubeadm command
+var kubeadmCmd = &cobra.Command{
+	Use:   "kubeadm",
+	Short: "Inspect the kubeadm configuration of a cluster",
+	Long:  "Inspect the kubeadm configuration of a cluster, including the patches passed to 'minikube start --kubeadm-patch'.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cmd.Help(); err != nil {
+			klog.ErrorS(err, "help")
+		}
+	},
+}
+
+var kubeadmConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the kubeadm config",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cmd.Help(); err != nil {
+			klog.ErrorS(err, "help")
+		}
+	},
+}
+
+var kubeadmConfigViewCmd = &cobra.Command{
+	Use:     "view",
+	Short:   "Display the kubeadm config used by the primary control plane",
+	Long:    "Display the kubeadm config used by the primary control plane, with the kubeadm patches applied.",
+	Example: "minikube kubeadm config view",
+	Run: func(cmd *cobra.Command, args []string) {
+		co := mustload.Running(ClusterFlagValue())
+		rr, err := co.CP.Runner.RunCmd(exec.Command("sudo", "cat", bsutil.KubeadmYamlPath))
+		if err != nil {
+			exit.Error(reason.GuestKubeadmConfig, "Failed to read the kubeadm config", err)
+		}
+		fmt.Print(rr.Stdout.String())
+	},
+}
+
+func init() {
+	kubeadmConfigCmd.AddCommand(kubeadmConfigViewCmd)
+	kubeadmCmd.AddCommand(kubeadmConfigCmd)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/kubeconfig"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var (
+	addUserOIDC         bool
+	addUserClientSecret string
+)
+
+// kubeconfigCmd represents the kubeconfig command
+var kubeconfigCmd = &cobra.Command{
+	Use:   "kubeconfig",
+	Short: "Manage the kubeconfig entries of a cluster",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cmd.Help(); err != nil {
+			klog.ErrorS(err, "help")
+		}
+	},
+}
+
+var kubeconfigAddUserCmd = &cobra.Command{
+	Use:   "add-user USER",
+	Short: "Add a user and a context for it to the kubeconfig",
+	Long: `Add a user, and a context for it on the cluster, both named USER@<profile>, to the kubeconfig. An existing user or context of that name is not overwritten.
+With --oidc, the user gets its tokens from the OIDC issuer the cluster was started with, using the kubectl oidc-login plugin (https://github.com/int128/kubelogin).`,
+	Example: "minikube kubeconfig add-user jane --oidc",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "usage: minikube kubeconfig add-user USER --oidc")
+		}
+		user := args[0]
+		if !addUserOIDC {
+			exit.Message(reason.Usage, "Only OIDC users are supported, try: minikube kubeconfig add-user {{.user}} --oidc", out.V{"user": user})
+		}
+
+		cname := ClusterFlagValue()
+		api, cc := mustload.Partial(cname)
+		defer api.Close()
+
+		k8s := cc.KubernetesConfig
+		if k8s.OIDCIssuerURL == "" {
+			exit.Message(reason.Usage, "The cluster {{.cluster}} does not use OIDC, start it with --oidc-issuer-url and --oidc-client-id", out.V{"cluster": cname})
+		}
+
+		authInfo := kubeconfig.OIDCAuthInfo(k8s.OIDCIssuerURL, k8s.OIDCClientID, addUserClientSecret, k8s.OIDCCAFile)
+		name, err := kubeconfig.AddUser(cname, user, authInfo, kubeconfig.PathFromEnv())
+		if err != nil {
+			exit.Error(reason.HostKubeconfigUpdate, "Failed to add user to kubeconfig", err)
+		}
+		out.Step(style.Ready, `Added user and context "{{.context}}"`, out.V{"context": name})
+		out.Step(style.Tip, "To use it, run: kubectl config use-context {{.context}}", out.V{"context": name})
+	},
+}
+
+func init() {
+	kubeconfigAddUserCmd.Flags().BoolVar(&addUserOIDC, "oidc", false, "Authenticate the user with the OIDC issuer of the cluster")
+	kubeconfigAddUserCmd.Flags().StringVar(&addUserClientSecret, "oidc-client-secret", "", "The client secret of the OIDC client, if it has one")
+	kubeconfigCmd.AddCommand(kubeconfigAddUserCmd)
+}
@@ -19,16 +19,21 @@ package cmd
 import (
 	"os"
 
+	"github.com/docker/machine/libmachine/state"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/klog/v2"
 	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
 	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/cruntime"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/logs"
+	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/mustload"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
 )
 
 const (
@@ -44,6 +49,10 @@ var (
 	numberOfLines int
 	// showProblems only shows lines that match known issues
 	showProblems bool
+	// bundleFile is the path of the diagnostic archive to write, set via --bundle
+	bundleFile string
+	// showAudit shows the API server audit log instead, set via --audit
+	showAudit bool
 )
 
 // logsCmd represents the logs command
@@ -54,6 +63,31 @@ var logsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		co := mustload.Running(ClusterFlagValue())
 
+		if bundleFile != "" {
+			lines := logs.BundleLines
+			if cmd.Flags().Changed("length") {
+				lines = numberOfLines
+			}
+			err := logs.Bundle(*co.Config, bundleNodes(co), lines, bundleFile)
+			if err != nil {
+				out.WarningT("{{.error}}", out.V{"error": err})
+			}
+			out.Step(style.Documentation, "Wrote logs to {{.file}}, secrets and certificates have been redacted but please review it before sharing", out.V{"file": bundleFile})
+			if err != nil {
+				os.Exit(reason.ExSvcError)
+			}
+			return
+		}
+
+		if showAudit {
+			if err := logs.AuditLog(*co.Config, co.CP.Runner, numberOfLines, followLogs); err != nil {
+				// Avoid exit.Error, since it outputs the issue URL
+				out.WarningT("{{.error}}", out.V{"error": err})
+				os.Exit(reason.ExSvcError)
+			}
+			return
+		}
+
 		bs, err := cluster.Bootstrapper(co.API, viper.GetString(cmdcfg.Bootstrapper), *co.Config, co.CP.Runner)
 		if err != nil {
 			exit.Error(reason.InternalBootstrapper, "Error getting cluster bootstrapper", err)
@@ -85,9 +119,43 @@ var logsCmd = &cobra.Command{
 	},
 }
 
+// bundleNodes returns the running nodes of a cluster to bundle logs from
+func bundleNodes(co mustload.ClusterController) []logs.BundleNode {
+	nodes := []logs.BundleNode{}
+	for _, n := range co.Config.Nodes {
+		name := config.MachineName(*co.Config, n)
+		st, err := machine.Status(co.API, name)
+		if err != nil || st != state.Running.String() {
+			out.WarningT("Skipping node {{.name}}, which is not running", out.V{"name": name})
+			continue
+		}
+		h, err := machine.LoadHost(co.API, name)
+		if err != nil {
+			exit.Error(reason.GuestLoadHost, "Error getting host", err)
+		}
+		runner, err := machine.CommandRunner(h)
+		if err != nil {
+			exit.Error(reason.InternalCommandRunner, "Failed to get command runner", err)
+		}
+		cr, err := cruntime.New(cruntime.Config{Type: co.Config.KubernetesConfig.ContainerRuntime, Runner: runner})
+		if err != nil {
+			exit.Error(reason.InternalNewRuntime, "Unable to get runtime", err)
+		}
+		bs, err := cluster.Bootstrapper(co.API, viper.GetString(cmdcfg.Bootstrapper), *co.Config, runner)
+		if err != nil {
+			exit.Error(reason.InternalBootstrapper, "Error getting cluster bootstrapper", err)
+		}
+		klog.Infof("bundling logs from node %s", name)
+		nodes = append(nodes, logs.BundleNode{Name: name, ControlPlane: n.ControlPlane, Runtime: cr, Bootstrapper: bs, Runner: runner})
+	}
+	return nodes
+}
+
 func init() {
 	logsCmd.Flags().BoolVarP(&followLogs, "follow", "f", false, "Show only the most recent journal entries, and continuously print new entries as they are appended to the journal.")
 	logsCmd.Flags().BoolVar(&showProblems, "problems", false, "Show only log entries which point to known problems")
 	logsCmd.Flags().IntVarP(&numberOfLines, "length", "n", 60, "Number of lines back to go within the log")
+	logsCmd.Flags().StringVar(&bundleFile, "bundle", "", "Write the logs of every node, cluster state, audit log, profile config and driver info into this tar.gz archive, with secrets and certificates redacted")
+	logsCmd.Flags().BoolVar(&showAudit, "audit", false, "Show the API server audit log of a cluster started with --audit-policy, instead of the component logs")
 	logsCmd.Flags().StringVar(&nodeName, "node", "", "The node to get logs from. Defaults to the primary control plane.")
 }
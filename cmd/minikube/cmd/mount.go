@@ -17,23 +17,30 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
 	"sync"
 	"syscall"
 
+	"github.com/docker/machine/libmachine/state"
+	"github.com/olekukonko/tablewriter"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/node"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/reason"
 	"k8s.io/minikube/pkg/minikube/style"
@@ -42,32 +49,40 @@ import (
 
 const (
 	// nineP is the value of --type used for the 9p filesystem.
-	nineP               = "9p"
+	nineP               = cluster.MountType9p
 	defaultMountVersion = "9p2000.L"
 	defaultMsize        = 262144
 )
 
 // placeholders for flag values
 var (
-	mountIP      string
-	mountVersion string
-	mountType    string
-	isKill       bool
-	uid          string
-	gid          string
-	mSize        int
-	options      []string
-	mode         uint
+	mountIP         string
+	mountVersion    string
+	mountType       string
+	isKill          bool
+	isPersistent    bool
+	uid             string
+	gid             string
+	mSize           int
+	options         []string
+	mode            uint
+	mountListOutput string
 )
 
 // supportedFilesystems is a map of filesystem types to not warn against.
-var supportedFilesystems = map[string]bool{nineP: true}
+var supportedFilesystems = map[string]bool{nineP: true, cluster.MountTypeSSHFS: true, cluster.MountTypeNFS: true}
 
 // mountCmd represents the mount command
 var mountCmd = &cobra.Command{
 	Use:   "mount [flags] <source directory>:<target directory>",
 	Short: "Mounts the specified directory into minikube",
-	Long:  `Mounts the specified directory into minikube.`,
+	Long: `Mounts the specified directory into minikube.
+
+The 9p and sshfs mounts are served by this process, and last until it exits. NFS mounts are served by the NFS server of the host, which requires sudo to export the directory.
+With --persistent, the mount is saved in the profile and restored by every "minikube start", and served in the background until "minikube mount remove" or "minikube stop".`,
+	Example: `minikube mount $HOME/src:/src --type=sshfs --persistent
+minikube mount list
+minikube mount remove /src`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if isKill {
 			if err := killMountProcess(); err != nil {
@@ -107,6 +122,26 @@ var mountCmd = &cobra.Command{
 			exit.Message(reason.Usage, `'none' driver does not support 'minikube mount' command`)
 		}
 
+		// An escape valve to allow future hackers to try VirtFS, or other FS types.
+		if !supportedFilesystems[mountType] {
+			out.WarningT("{{.type}} is not yet a supported filesystem. We will try anyways!", out.V{"type": mountType})
+		}
+
+		if isPersistent {
+			abs, err := filepath.Abs(hostPath)
+			if err != nil {
+				exit.Error(reason.HostPathStat, "Unable to resolve the host path", err)
+			}
+			m := config.Mount{HostPath: abs, GuestPath: vmPath, Type: mountType, UID: uid, GID: gid, Mode: uint32(mode), Options: options}
+			persistMount(co.Config, m)
+			out.Step(style.Mounting, "Mounting host path {{.sourcePath}} into VM as {{.destinationPath}} ...", out.V{"sourcePath": abs, "destinationPath": vmPath})
+			if err := node.StartMount(*co.Config, co.CP.Host, co.CP.Runner, m); err != nil {
+				exit.Error(reason.GuestMount, "mount failed", err)
+			}
+			out.Step(style.Success, "Saved the mount of {{.sourcePath}} to {{.destinationPath}}, which is restored by every minikube start", out.V{"sourcePath": abs, "destinationPath": vmPath})
+			return
+		}
+
 		var ip net.IP
 		var err error
 		if mountIP == "" {
@@ -133,21 +168,7 @@ var mountCmd = &cobra.Command{
 			MSize:   mSize,
 			Port:    port,
 			Mode:    os.FileMode(mode),
-			Options: map[string]string{},
-		}
-
-		for _, o := range options {
-			if !strings.Contains(o, "=") {
-				cfg.Options[o] = ""
-				continue
-			}
-			parts := strings.Split(o, "=")
-			cfg.Options[parts[0]] = parts[1]
-		}
-
-		// An escape valve to allow future hackers to try NFS, VirtFS, or other FS types.
-		if !supportedFilesystems[cfg.Type] {
-			out.WarningT("{{.type}} is not yet a supported filesystem. We will try anyways!", out.V{"type": cfg.Type})
+			Options: cluster.MountOptions(options),
 		}
 
 		bindIP := ip.String() // the ip to listen on the user's host machine
@@ -158,11 +179,15 @@ var mountCmd = &cobra.Command{
 		out.Infof("Mount type:   {{.name}}", out.V{"type": cfg.Type})
 		out.Infof("User ID:      {{.userID}}", out.V{"userID": cfg.UID})
 		out.Infof("Group ID:     {{.groupID}}", out.V{"groupID": cfg.GID})
-		out.Infof("Version:      {{.version}}", out.V{"version": cfg.Version})
-		out.Infof("Message Size: {{.size}}", out.V{"size": cfg.MSize})
+		if cfg.Type == nineP {
+			out.Infof("Version:      {{.version}}", out.V{"version": cfg.Version})
+			out.Infof("Message Size: {{.size}}", out.V{"size": cfg.MSize})
+		}
 		out.Infof("Permissions:  {{.octalMode}} ({{.writtenMode}})", out.V{"octalMode": fmt.Sprintf("%o", cfg.Mode), "writtenMode": cfg.Mode})
 		out.Infof("Options:      {{.options}}", out.V{"options": cfg.Options})
-		out.Infof("Bind Address: {{.Address}}", out.V{"Address": net.JoinHostPort(bindIP, fmt.Sprint(port))})
+		if cfg.Type == nineP {
+			out.Infof("Bind Address: {{.Address}}", out.V{"Address": net.JoinHostPort(bindIP, fmt.Sprint(port))})
+		}
 
 		var wg sync.WaitGroup
 		if cfg.Type == nineP {
@@ -175,23 +200,65 @@ var mountCmd = &cobra.Command{
 			}()
 		}
 
+		var sshfs *cluster.SSHFS
+
 		// Unmount if Ctrl-C or kill request is received.
 		c := make(chan os.Signal, 1)
 		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		go func() {
 			for sig := range c {
 				out.Step(style.Unmount, "Unmounting {{.path}} ...", out.V{"path": vmPath})
+				if sshfs != nil {
+					sshfs.Stop()
+				}
 				err := cluster.Unmount(co.CP.Runner, vmPath)
 				if err != nil {
 					out.FailureT("Failed unmount: {{.error}}", out.V{"error": err})
 				}
+				if cfg.Type == cluster.MountTypeNFS {
+					if err := cluster.UnexportNFS(co.Config.Name, vmPath); err != nil {
+						out.FailureT("Failed to remove the NFS export: {{.error}}", out.V{"error": err})
+					}
+				}
 				exit.Message(reason.Interrupted, "Received {{.name}} signal", out.V{"name": sig})
 			}
 		}()
 
-		err = cluster.Mount(co.CP.Runner, ip.String(), vmPath, cfg)
-		if err != nil {
-			exit.Error(reason.GuestMount, "mount failed", err)
+		switch cfg.Type {
+		case cluster.MountTypeSSHFS:
+			client, err := createExternalSSHClient(co.CP.Host.Driver)
+			if err != nil {
+				exit.Error(reason.IfSSHClient, "Error getting ssh client", err)
+			}
+			sshfs, err = cluster.StartSSHFS(co.CP.Runner, client.BinaryPath, client.BaseArgs, hostPath, vmPath, cfg)
+			if errors.Is(err, cluster.ErrSSHFSNotInstalled) {
+				exit.Message(reason.GuestMount, "sshfs is not installed in the node of the {{.name}} cluster, which was created by an older version of minikube. Recreate the cluster with 'minikube delete' and 'minikube start', or mount with --type=9p", out.V{"name": co.Config.Name})
+			}
+			if err != nil {
+				exit.Error(reason.GuestMount, "mount failed", err)
+			}
+			wg.Add(1)
+			go func() {
+				if err := sshfs.Wait(); err != nil {
+					klog.Warningf("sshfs exited: %v", err)
+				}
+				out.Step(style.Stopped, "sshfs connection is closed")
+				wg.Done()
+			}()
+		case cluster.MountTypeNFS:
+			nodeIP, err := co.CP.Host.Driver.GetIP()
+			if err != nil {
+				exit.Error(reason.IfHostIP, "Error getting the IP address of the VM", err)
+			}
+			if err := cluster.MountNFS(co.CP.Runner, co.Config.Name, ip.String(), nodeIP, hostPath, vmPath, cfg); err != nil {
+				exit.Error(reason.GuestMount, "mount failed", err)
+			}
+			// the mount is served by the host, so there is nothing to wait for but a signal
+			wg.Add(1)
+		default:
+			if err := cluster.Mount(co.CP.Runner, ip.String(), vmPath, cfg); err != nil {
+				exit.Error(reason.GuestMount, "mount failed", err)
+			}
 		}
 		out.Step(style.Success, "Successfully mounted {{.sourcePath}} to {{.destinationPath}}", out.V{"sourcePath": hostPath, "destinationPath": vmPath})
 		out.Ln("")
@@ -200,16 +267,160 @@ var mountCmd = &cobra.Command{
 	},
 }
 
+// mountListCmd lists the mounts saved in the profile
+var mountListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the mounts saved in the profile",
+	Example: "minikube mount list",
+	Run: func(cmd *cobra.Command, args []string) {
+		_, cc := mustload.Partial(ClusterFlagValue())
+
+		switch strings.ToLower(mountListOutput) {
+		case "json":
+			mounts := cc.Mounts
+			if mounts == nil {
+				mounts = []config.Mount{}
+			}
+			b, err := json.Marshal(mounts)
+			if err != nil {
+				exit.Error(reason.InternalJSONMarshal, "Failed to marshal mounts", err)
+			}
+			out.String(string(b))
+		case "table":
+			if len(cc.Mounts) == 0 {
+				out.Step(style.Empty, "No mounts found for cluster {{.cluster}}. To create one, run: \"minikube mount SOURCE:TARGET --persistent\"", out.V{"cluster": cc.Name})
+				return
+			}
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Source", "Target", "Type", "Process"})
+			table.SetAutoFormatHeaders(false)
+			table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+			table.SetCenterSeparator("|")
+			for _, m := range cc.Mounts {
+				table.Append([]string{m.HostPath, m.GuestPath, m.Type, mountProcess(cc.Name, m)})
+			}
+			table.Render()
+		default:
+			exit.Message(reason.Usage, "invalid output format: {{.output}}. Valid values: 'table', 'json'", out.V{"output": mountListOutput})
+		}
+	},
+}
+
+// mountRemoveCmd unmounts a mount saved in the profile, and removes it from the profile
+var mountRemoveCmd = &cobra.Command{
+	Use:     "remove <target directory>",
+	Aliases: []string{"rm"},
+	Short:   "Unmount a mount saved in the profile, and remove it from the profile",
+	Example: "minikube mount remove /src",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "usage: minikube mount remove <target directory>")
+		}
+		api, cc := mustload.Partial(ClusterFlagValue())
+		defer api.Close()
+
+		target := args[0]
+		var removed *config.Mount
+		mounts := []config.Mount{}
+		for i, m := range cc.Mounts {
+			if m.GuestPath == target {
+				removed = &cc.Mounts[i]
+				continue
+			}
+			mounts = append(mounts, m)
+		}
+		if removed == nil {
+			exit.Message(reason.Usage, `Mount "{{.path}}" not found. Run "minikube mount list" to view all mounts.`, out.V{"path": target})
+		}
+
+		if err := cluster.KillMountProcess(cc.Name, target); err != nil {
+			out.WarningT("Unable to kill mount process: {{.error}}", out.V{"error": err})
+		}
+		cp, err := config.PrimaryControlPlane(cc)
+		if err != nil {
+			exit.Error(reason.GuestCpConfig, "Unable to find control plane", err)
+		}
+		if st, err := machine.Status(api, config.MachineName(*cc, cp)); err == nil && st == state.Running.String() {
+			co := mustload.Running(cc.Name)
+			out.Step(style.Unmount, "Unmounting {{.path}} ...", out.V{"path": target})
+			if err := cluster.Unmount(co.CP.Runner, target); err != nil {
+				out.FailureT("Failed unmount: {{.error}}", out.V{"error": err})
+			}
+		}
+		if removed.Type == cluster.MountTypeNFS {
+			if err := cluster.UnexportNFS(cc.Name, target); err != nil {
+				out.FailureT("Failed to remove the NFS export: {{.error}}", out.V{"error": err})
+			}
+		}
+
+		cc.Mounts = mounts
+		if err := config.SaveProfile(cc.Name, cc); err != nil {
+			exit.Error(reason.HostSaveProfile, "Failed to save config", err)
+		}
+		out.Step(style.Deleted, "Removed mount {{.path}}", out.V{"path": target})
+	},
+}
+
+// persistMount saves m in the profile, replacing any mount into the same target directory
+func persistMount(cc *config.ClusterConfig, m config.Mount) {
+	mounts := []config.Mount{}
+	for _, existing := range cc.Mounts {
+		if existing.GuestPath == m.GuestPath {
+			if err := cluster.KillMountProcess(cc.Name, existing.GuestPath); err != nil {
+				out.WarningT("Unable to kill mount process: {{.error}}", out.V{"error": err})
+			}
+			continue
+		}
+		mounts = append(mounts, existing)
+	}
+	cc.Mounts = append(mounts, m)
+	if err := config.SaveProfile(cc.Name, cc); err != nil {
+		exit.Error(reason.HostSaveProfile, "Failed to save config", err)
+	}
+}
+
+// mountProcess describes what serves a mount saved in the profile, for display
+func mountProcess(profile string, m config.Mount) string {
+	if m.Type == cluster.MountTypeNFS {
+		return "NFS server"
+	}
+	pid, err := cluster.MountPid(profile, m.GuestPath)
+	if err != nil {
+		klog.Warningf("unable to find the mount process of %s: %v", m.GuestPath, err)
+	}
+	if pid == 0 {
+		return "Stopped"
+	}
+	return fmt.Sprintf("pid %d", pid)
+}
+
+// killMountProcesses kills the processes serving the mounts saved in the profile, which are restarted by minikube start
+func killMountProcesses(cc *config.ClusterConfig) {
+	if cc == nil {
+		return
+	}
+	for _, m := range cc.Mounts {
+		if err := cluster.KillMountProcess(cc.Name, m.GuestPath); err != nil {
+			out.WarningT("Unable to kill mount process: {{.error}}", out.V{"error": err})
+		}
+	}
+}
+
 func init() {
 	mountCmd.Flags().StringVar(&mountIP, "ip", "", "Specify the ip that the mount should be setup on")
-	mountCmd.Flags().StringVar(&mountType, "type", nineP, "Specify the mount filesystem type (supported types: 9p)")
+	mountCmd.Flags().StringVar(&mountType, "type", nineP, "Specify the mount filesystem type (supported types: 9p, sshfs, nfs)")
 	mountCmd.Flags().StringVar(&mountVersion, "9p-version", defaultMountVersion, "Specify the 9p version that the mount should use")
 	mountCmd.Flags().BoolVar(&isKill, "kill", false, "Kill the mount process spawned by minikube start")
+	mountCmd.Flags().BoolVar(&isPersistent, "persistent", false, "Save the mount in the profile, so that it is restored by every minikube start, and serve it in the background")
 	mountCmd.Flags().StringVar(&uid, "uid", "docker", "Default user id used for the mount")
 	mountCmd.Flags().StringVar(&gid, "gid", "docker", "Default group id used for the mount")
 	mountCmd.Flags().UintVar(&mode, "mode", 0o755, "File permissions used for the mount")
 	mountCmd.Flags().StringSliceVar(&options, "options", []string{}, "Additional mount options, such as cache=fscache")
 	mountCmd.Flags().IntVar(&mSize, "msize", defaultMsize, "The number of bytes to use for 9p packet payload")
+	mountListCmd.Flags().StringVarP(&mountListOutput, "output", "o", "table", "The output format. One of 'table', 'json'")
+	mountCmd.AddCommand(mountListCmd)
+	mountCmd.AddCommand(mountRemoveCmd)
 }
 
 // getPort asks the kernel for a free open port that is ready to use
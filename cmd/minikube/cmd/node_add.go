@@ -17,6 +17,9 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"net"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"k8s.io/minikube/pkg/minikube/cni"
@@ -31,14 +34,22 @@ import (
 )
 
 var (
-	cp     bool
-	worker bool
+	cp          bool
+	worker      bool
+	nodeSSHIP   string
+	nodeSSHUser string
+	nodeSSHKey  string
+	nodeSSHPort int
 )
 
 var nodeAddCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Adds a node to the given cluster.",
-	Long:  "Adds a node to the given cluster config, and starts it.",
+	Long: `Adds a node to the given cluster config, and starts it.
+
+With the ssh driver, the node runs on the existing machine given by --ssh-ip-address, which must differ from the machines of the other nodes.`,
+	Example: `minikube node add
+minikube node add --ssh-ip-address=192.168.0.11 --ssh-user=ubuntu --ssh-key=$HOME/.ssh/id_rsa`,
 	Run: func(cmd *cobra.Command, args []string) {
 		co := mustload.Healthy(ClusterFlagValue())
 		cc := co.Config
@@ -47,6 +58,10 @@ var nodeAddCmd = &cobra.Command{
 			out.FailureT("none driver does not support multi-node clusters")
 		}
 
+		if cp && !config.IsHA(*cc) {
+			exit.Message(reason.Usage, `Adding a control plane node requires a cluster created with "minikube start --ha"`)
+		}
+
 		name := node.Name(len(cc.Nodes) + 1)
 
 		out.Step(style.Happy, "Adding node {{.name}} to cluster {{.cluster}}", out.V{"name": name, "cluster": cc.Name})
@@ -58,6 +73,14 @@ var nodeAddCmd = &cobra.Command{
 			ControlPlane:      cp,
 			KubernetesVersion: cc.KubernetesConfig.KubernetesVersion,
 		}
+		if driver.IsSSH(cc.Driver) {
+			sshNode(cmd, cc, &n)
+		} else if cmd.Flags().Changed(sshIPAddress) {
+			exit.Message(reason.Usage, "--ssh-ip-address is only supported by the ssh driver")
+		}
+		if cp {
+			n.Port = cc.KubernetesConfig.NodePort
+		}
 
 		// Make sure to decrease the default amount of memory we use per VM if this is the first worker node
 		if len(cc.Nodes) == 1 {
@@ -70,8 +93,9 @@ var nodeAddCmd = &cobra.Command{
 			}
 		}
 
-		if err := node.Add(cc, n, false); err != nil {
-			_, err := maybeDeleteAndRetry(cmd, *cc, n, nil, err)
+		ctx := context.Background()
+		if err := node.Add(ctx, cc, n, false); err != nil {
+			_, err := maybeDeleteAndRetry(ctx, cmd, *cc, n, nil, err)
 			if err != nil {
 				exit.Error(reason.GuestNodeAdd, "failed to add node", err)
 			}
@@ -85,11 +109,50 @@ var nodeAddCmd = &cobra.Command{
 	},
 }
 
+// sshNode sets the machine of a node added to a cluster of the ssh driver, which inherits the ssh user, key and port of the cluster unless they are given
+func sshNode(cmd *cobra.Command, cc *config.ClusterConfig, n *config.Node) {
+	if nodeSSHIP == "" {
+		exit.Message(reason.Usage, "The ssh driver runs each node on a machine of its own. Please specify the machine of the new node with --ssh-ip-address")
+	}
+	if net.ParseIP(nodeSSHIP) == nil {
+		if _, err := net.LookupIP(nodeSSHIP); err != nil {
+			exit.Error(reason.Usage, "Could not resolve IP address", err)
+		}
+	}
+	for _, existing := range cc.Nodes {
+		ip := existing.SSHIPAddress
+		if ip == "" {
+			ip = cc.SSHIPAddress
+		}
+		if ip == nodeSSHIP {
+			exit.Message(reason.Usage, "Node {{.name}} already runs on {{.ip}}", out.V{"name": existing.Name, "ip": nodeSSHIP})
+		}
+	}
+
+	n.SSHIPAddress = nodeSSHIP
+	n.SSHUser = cc.SSHUser
+	if cmd.Flags().Changed(sshSSHUser) {
+		n.SSHUser = nodeSSHUser
+	}
+	n.SSHKey = cc.SSHKey
+	if cmd.Flags().Changed(sshSSHKey) {
+		n.SSHKey = nodeSSHKey
+	}
+	n.SSHPort = cc.SSHPort
+	if cmd.Flags().Changed(sshSSHPort) {
+		n.SSHPort = nodeSSHPort
+	}
+}
+
 func init() {
 	// TODO(https://github.com/kubernetes/minikube/issues/7366): We should figure out which minikube start flags to actually import
-	nodeAddCmd.Flags().BoolVar(&cp, "control-plane", false, "If true, the node added will also be a control plane in addition to a worker.")
+	nodeAddCmd.Flags().BoolVar(&cp, "control-plane", false, "If true, the node added will also be a control plane in addition to a worker. Requires a cluster started with --ha.")
 	nodeAddCmd.Flags().BoolVar(&worker, "worker", true, "If true, the added node will be marked for work. Defaults to true.")
 	nodeAddCmd.Flags().Bool(deleteOnFailure, false, "If set, delete the current cluster if start fails and try again. Defaults to false.")
+	nodeAddCmd.Flags().StringVar(&nodeSSHIP, sshIPAddress, "", "IP address of the machine to run the node on (ssh driver only)")
+	nodeAddCmd.Flags().StringVar(&nodeSSHUser, sshSSHUser, defaultSSHUser, "SSH user (ssh driver only). Defaults to the one of the cluster.")
+	nodeAddCmd.Flags().StringVar(&nodeSSHKey, sshSSHKey, "", "SSH key (ssh driver only). Defaults to the one of the cluster.")
+	nodeAddCmd.Flags().IntVar(&nodeSSHPort, sshSSHPort, defaultSSHPort, "SSH port (ssh driver only). Defaults to the one of the cluster.")
 
 	nodeCmd.AddCommand(nodeAddCmd)
 }
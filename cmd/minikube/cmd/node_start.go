@@ -17,6 +17,7 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -56,7 +57,8 @@ var nodeStartCmd = &cobra.Command{
 		}
 
 		register.Reg.SetStep(register.InitialSetup)
-		r, p, m, h, err := node.Provision(cc, n, n.ControlPlane, viper.GetBool(deleteOnFailure))
+		ctx := context.Background()
+		r, p, m, h, err := node.Provision(ctx, cc, n, n.ControlPlane, viper.GetBool(deleteOnFailure))
 		if err != nil {
 			exit.Error(reason.GuestNodeProvision, "provisioning host for node", err)
 		}
@@ -71,9 +73,9 @@ var nodeStartCmd = &cobra.Command{
 			ExistingAddons: nil,
 		}
 
-		_, err = node.Start(s, n.ControlPlane)
+		_, err = node.Start(ctx, s, config.IsPrimaryControlPlane(*cc, *n))
 		if err != nil {
-			_, err := maybeDeleteAndRetry(cmd, *cc, *n, nil, err)
+			_, err := maybeDeleteAndRetry(ctx, cmd, *cc, *n, nil, err)
 			if err != nil {
 				node.ExitIfFatal(err)
 				exit.Error(reason.GuestNodeStart, "failed to start node", err)
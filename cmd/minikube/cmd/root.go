@@ -40,6 +40,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/registry/drvs/plugin"
 	"k8s.io/minikube/pkg/minikube/translate"
 )
 
@@ -135,6 +136,8 @@ func Execute() {
 		targetDir := localpath.MakeMiniPath("bin")
 		addToPath(targetDir)
 	}
+	// driver plugins installed for minikube are run by name, like those on the PATH
+	addToPath(plugin.Dir())
 
 	// Universally ensure that we never speak to the wrong DOCKER_HOST
 	if err := oci.PointToHostDockerDaemon(); err != nil {
@@ -147,6 +150,7 @@ func Execute() {
 
 	if err := RootCmd.Execute(); err != nil {
 		// Cobra already outputs the error, typically because the user provided an unknown command.
+		audit.LogExit(reason.ExProgramUsage)
 		os.Exit(reason.ExProgramUsage)
 	}
 }
@@ -216,6 +220,7 @@ func init() {
 			Commands: []*cobra.Command{
 				dockerEnvCmd,
 				podmanEnvCmd,
+				containerdEnvCmd,
 				cacheCmd,
 				imageCmd,
 			},
@@ -227,6 +232,7 @@ func init() {
 				configCmd.ConfigCmd,
 				configCmd.ProfileCmd,
 				updateContextCmd,
+				kubeconfigCmd,
 			},
 		},
 		{
@@ -243,6 +249,10 @@ func init() {
 				sshCmd,
 				kubectlCmd,
 				nodeCmd,
+				kubeadmCmd,
+				snapshotCmd,
+				bundleCmd,
+				scheduleCmd,
 			},
 		},
 		{
@@ -252,6 +262,8 @@ func init() {
 				sshHostCmd,
 				ipCmd,
 				logsCmd,
+				auditCmd,
+				driverCmd,
 				updateCheckCmd,
 				versionCmd,
 				optionsCmd,
@@ -299,12 +311,13 @@ func setupViper() {
 	viper.SetDefault(config.WantReportErrorPrompt, true)
 	viper.SetDefault(config.WantKubectlDownloadMsg, true)
 	viper.SetDefault(config.WantNoneDriverWarning, true)
+	viper.SetDefault(config.MaxAuditFileSizeInMB, 10)
 	viper.SetDefault(config.ShowDriverDeprecationNotification, true)
 	viper.SetDefault(config.ShowBootstrapperDeprecationNotification, true)
 }
 
 func addToPath(dir string) {
-	new := fmt.Sprintf("%s:%s", dir, os.Getenv("PATH"))
+	new := fmt.Sprintf("%s%c%s", dir, os.PathListSeparator, os.Getenv("PATH"))
 	klog.Infof("Updating PATH: %s", dir)
 	os.Setenv("PATH", new)
 }
@@ -0,0 +1,239 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/schedule"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var (
+	scheduleAction string
+	scheduleCron   string
+	scheduleIdle   time.Duration
+	scheduleKeep   int
+	scheduleOutput string
+)
+
+// scheduleCmd represents the schedule command
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Manage recurring and idle-triggered cluster actions",
+	Long: `Run stop, pause, unpause and snapshot actions on a cron schedule, such as "0 19 * * mon-fri", or once the cluster has received no API requests from the host for a while.
+Cron expressions use the UTC offset of the host. Stop, pause and unpause are run by the scheduled-stop service inside the cluster, so they keep running after minikube exits. Snapshots are taken by a background minikube process on the host.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cmd.Help(); err != nil {
+			klog.ErrorS(err, "help")
+		}
+	},
+}
+
+var scheduleAddCmd = &cobra.Command{
+	Use:   "add SCHEDULE_NAME",
+	Short: "Add a recurring or idle-triggered action",
+	Example: `minikube schedule add nightly-stop --action=stop --cron="0 19 * * mon-fri"
+minikube schedule add idle-pause --action=pause --idle=30m
+minikube schedule add backup --action=snapshot --cron="@daily" --keep=7`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "usage: minikube schedule add SCHEDULE_NAME --action=ACTION [--cron=EXPRESSION | --idle=DURATION]")
+		}
+		api, cc := mustload.Partial(ClusterFlagValue())
+		defer api.Close()
+
+		s := config.ScheduledAction{
+			Name:   args[0],
+			Action: strings.ToLower(scheduleAction),
+			Cron:   scheduleCron,
+			Idle:   scheduleIdle,
+			Keep:   scheduleKeep,
+		}
+		if s.Cron == "" && s.Idle == 0 {
+			exit.Message(reason.Usage, "One of --cron or --idle is required")
+		}
+		if err := schedule.Validate(cc, s); err != nil {
+			exit.Message(reason.Usage, "{{.error}}", out.V{"error": err})
+		}
+
+		cc.Schedules = append(cc.Schedules, s)
+		if err := config.SaveProfile(cc.Name, cc); err != nil {
+			exit.Error(reason.HostSaveProfile, "Failed to save config", err)
+		}
+		applySchedules(api, cc)
+
+		if s.Cron != "" {
+			out.Step(style.Ready, "Added schedule {{.name}}: {{.action}} at {{.cron}}, next at {{.next}}", out.V{"name": s.Name, "action": s.Action, "cron": s.Cron, "next": formatNext(s)})
+		} else {
+			out.Step(style.Ready, "Added schedule {{.name}}: {{.action}} after {{.idle}} without API requests", out.V{"name": s.Name, "action": s.Action, "idle": s.Idle})
+		}
+	},
+}
+
+var scheduleRemoveCmd = &cobra.Command{
+	Use:     "remove SCHEDULE_NAME",
+	Aliases: []string{"rm"},
+	Short:   "Remove a scheduled action",
+	Example: "minikube schedule remove nightly-stop",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "usage: minikube schedule remove SCHEDULE_NAME")
+		}
+		api, cc := mustload.Partial(ClusterFlagValue())
+		defer api.Close()
+
+		name := args[0]
+		schedules := []config.ScheduledAction{}
+		for _, s := range cc.Schedules {
+			if s.Name != name {
+				schedules = append(schedules, s)
+			}
+		}
+		if len(schedules) == len(cc.Schedules) {
+			exit.Message(reason.Usage, `Schedule "{{.name}}" not found. Run "minikube schedule list" to view all schedules.`, out.V{"name": name})
+		}
+
+		cc.Schedules = schedules
+		if err := config.SaveProfile(cc.Name, cc); err != nil {
+			exit.Error(reason.HostSaveProfile, "Failed to save config", err)
+		}
+		applySchedules(api, cc)
+		out.Step(style.Deleted, "Removed schedule {{.name}}", out.V{"name": name})
+	},
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the scheduled actions of a cluster",
+	Example: "minikube schedule list",
+	Run: func(cmd *cobra.Command, args []string) {
+		_, cc := mustload.Partial(ClusterFlagValue())
+
+		switch strings.ToLower(scheduleOutput) {
+		case "json":
+			schedules := cc.Schedules
+			if schedules == nil {
+				schedules = []config.ScheduledAction{}
+			}
+			b, err := json.Marshal(schedules)
+			if err != nil {
+				exit.Error(reason.InternalJSONMarshal, "Failed to marshal schedules", err)
+			}
+			out.String(string(b))
+		case "table":
+			if len(cc.Schedules) == 0 {
+				out.Step(style.Empty, "No schedules found for cluster {{.cluster}}. To create one, run: \"minikube schedule add SCHEDULE_NAME --action=stop --cron=\\\"0 19 * * mon-fri\\\"\"", out.V{"cluster": cc.Name})
+				return
+			}
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Name", "Action", "Trigger", "Next"})
+			table.SetAutoFormatHeaders(false)
+			table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+			table.SetCenterSeparator("|")
+			for _, s := range cc.Schedules {
+				trigger := s.Cron
+				if trigger == "" {
+					trigger = "idle " + s.Idle.String()
+				}
+				action := s.Action
+				if s.Keep > 0 {
+					action = fmt.Sprintf("%s (keep %d)", action, s.Keep)
+				}
+				table.Append([]string{s.Name, action, trigger, formatNext(s)})
+			}
+			table.Render()
+		default:
+			exit.Message(reason.Usage, "invalid output format: {{.output}}. Valid values: 'table', 'json'", out.V{"output": scheduleOutput})
+		}
+	},
+}
+
+// scheduleDaemonCmd takes scheduled snapshots in the background, and is started by applySchedules
+var scheduleDaemonCmd = &cobra.Command{
+	Use:    "daemon",
+	Short:  "Take the scheduled snapshots of a cluster",
+	Hidden: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := schedule.DaemonizeSnapshots(ClusterFlagValue()); err != nil {
+			exit.Error(reason.DaemonizeError, "Failed to take scheduled snapshots", err)
+		}
+	},
+}
+
+// applySchedules installs the schedules of a cluster into its running nodes, and restarts the process taking scheduled snapshots
+func applySchedules(api libmachine.API, cc *config.ClusterConfig) {
+	if err := schedule.Sync(api, cc); err != nil {
+		exit.Error(reason.GuestScheduleSync, "Failed to install schedules", err)
+	}
+	if err := schedule.StartSnapshots(cc.Name); err != nil {
+		out.WarningT("Unable to start scheduled snapshots: {{.error}}", out.V{"error": err})
+	}
+}
+
+// restoreSchedules reinstalls the schedules of a cluster after it has been started
+func restoreSchedules(cc *config.ClusterConfig) {
+	api, err := machine.NewAPIClient()
+	if err != nil {
+		klog.Warningf("api client: %v", err)
+		return
+	}
+	defer api.Close()
+	if err := schedule.Sync(api, cc); err != nil {
+		out.WarningT("Unable to install schedules: {{.error}}", out.V{"error": err})
+	}
+	if err := schedule.StartSnapshots(cc.Name); err != nil {
+		out.WarningT("Unable to start scheduled snapshots: {{.error}}", out.V{"error": err})
+	}
+}
+
+// formatNext returns when a schedule runs next, for display
+func formatNext(s config.ScheduledAction) string {
+	next := schedule.Next(s, time.Now())
+	if next.IsZero() {
+		return "-"
+	}
+	return next.Format("2006-01-02 15:04")
+}
+
+func init() {
+	scheduleAddCmd.Flags().StringVar(&scheduleAction, "action", schedule.Stop, "The action to run. One of: "+strings.Join(schedule.Actions, ", "))
+	scheduleAddCmd.Flags().StringVar(&scheduleCron, "cron", "", `Run the action whenever this cron expression matches, for example "0 19 * * mon-fri"`)
+	scheduleAddCmd.Flags().DurationVar(&scheduleIdle, "idle", 0, "Run the action once the cluster has received no API requests from the host for this long, for example 30m")
+	scheduleAddCmd.Flags().IntVar(&scheduleKeep, "keep", 0, "For snapshot schedules, how many of the snapshots taken by the schedule to keep, deleting the oldest ones. Keeps them all by default.")
+	scheduleListCmd.Flags().StringVarP(&scheduleOutput, "output", "o", "table", "The output format. One of 'table', 'json'")
+	scheduleCmd.AddCommand(scheduleAddCmd)
+	scheduleCmd.AddCommand(scheduleRemoveCmd)
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleDaemonCmd)
+}
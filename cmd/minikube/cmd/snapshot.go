@@ -0,0 +1,174 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mustload"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+)
+
+var snapshotOutput string
+
+// snapshotCmd represents the snapshot command
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save and restore snapshots of a cluster",
+	Long:  "Save a stopped or paused cluster as a named snapshot, and restore it later in place of running 'minikube delete' and 'minikube start' again. Supported by the docker and podman drivers.",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cmd.Help(); err != nil {
+			klog.ErrorS(err, "help")
+		}
+	},
+}
+
+var snapshotSaveCmd = &cobra.Command{
+	Use:     "save SNAPSHOT_NAME",
+	Short:   "Save a snapshot of a stopped or paused cluster",
+	Example: "minikube snapshot save known-good",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "usage: minikube snapshot save SNAPSHOT_NAME")
+		}
+		name := args[0]
+
+		api, cc := mustload.Partial(ClusterFlagValue())
+		defer api.Close()
+		validateSnapshotDriver(cc)
+
+		for _, n := range cc.Nodes {
+			if !nodeQuiesced(api, *cc, n) {
+				exit.Message(reason.Usage, `The cluster {{.cluster}} must be stopped or paused before saving a snapshot. Try: "minikube pause -p {{.cluster}}" or "minikube stop -p {{.cluster}}"`, out.V{"cluster": cc.Name})
+			}
+		}
+
+		out.Step(style.Caching, "Saving snapshot {{.name}} of cluster {{.cluster}} ...", out.V{"name": name, "cluster": cc.Name})
+		if _, err := machine.SaveSnapshot(api, cc, name); err != nil {
+			exit.Error(reason.GuestSnapshotSave, "Failed to save snapshot", err)
+		}
+		out.Step(style.Ready, "Saved snapshot {{.name}}", out.V{"name": name})
+	},
+}
+
+var snapshotRestoreCmd = &cobra.Command{
+	Use:     "restore SNAPSHOT_NAME",
+	Short:   "Restore a cluster from a snapshot",
+	Long:    "Stops the cluster and restores its nodes and configuration from a snapshot. Run 'minikube start' afterwards to start the restored cluster.",
+	Example: "minikube snapshot restore known-good",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			exit.Message(reason.Usage, "usage: minikube snapshot restore SNAPSHOT_NAME")
+		}
+		name := args[0]
+
+		api, cc := mustload.Partial(ClusterFlagValue())
+		defer api.Close()
+		validateSnapshotDriver(cc)
+
+		out.Step(style.Resetting, "Restoring cluster {{.cluster}} from snapshot {{.name}} ...", out.V{"name": name, "cluster": cc.Name})
+		if _, err := machine.RestoreSnapshot(api, cc, name); err != nil {
+			exit.Error(reason.GuestSnapshotRestore, "Failed to restore snapshot", err)
+		}
+		out.Step(style.Ready, `Restored snapshot {{.name}}. To start the cluster, run: "minikube start -p {{.cluster}}"`, out.V{"name": name, "cluster": cc.Name})
+	},
+}
+
+var snapshotListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the snapshots of a cluster",
+	Example: "minikube snapshot list",
+	Run: func(cmd *cobra.Command, args []string) {
+		profile := ClusterFlagValue()
+		snapshots, err := machine.ListSnapshots(profile)
+		if err != nil {
+			exit.Error(reason.GuestSnapshotList, "Failed to list snapshots", err)
+		}
+
+		switch strings.ToLower(snapshotOutput) {
+		case "json":
+			if snapshots == nil {
+				snapshots = []machine.Snapshot{}
+			}
+			b, err := json.Marshal(snapshots)
+			if err != nil {
+				exit.Error(reason.InternalJSONMarshal, "Failed to marshal snapshots", err)
+			}
+			out.String(string(b))
+		case "table":
+			if len(snapshots) == 0 {
+				out.Step(style.Empty, "No snapshots found for cluster {{.cluster}}. To create one, run: \"minikube snapshot save SNAPSHOT_NAME\"", out.V{"cluster": profile})
+				return
+			}
+			table := tablewriter.NewWriter(os.Stdout)
+			table.SetHeader([]string{"Name", "Driver", "Nodes", "Created"})
+			table.SetAutoFormatHeaders(false)
+			table.SetBorders(tablewriter.Border{Left: true, Top: true, Right: true, Bottom: true})
+			table.SetCenterSeparator("|")
+			for _, s := range snapshots {
+				table.Append([]string{s.Name, s.Driver, strings.Join(s.Machines, ", "), s.Created.Format("2006-01-02 15:04:05")})
+			}
+			table.Render()
+		default:
+			exit.Message(reason.Usage, "invalid output format: {{.output}}. Valid values: 'table', 'json'", out.V{"output": snapshotOutput})
+		}
+	},
+}
+
+// validateSnapshotDriver exits if the driver of the cluster does not support snapshots
+func validateSnapshotDriver(cc *config.ClusterConfig) {
+	if !machine.SnapshotSupported(cc.Driver) {
+		exit.Message(reason.Usage, "The {{.driver}} driver does not support snapshots. Snapshots are supported by the docker and podman drivers.", out.V{"driver": cc.Driver})
+	}
+}
+
+// nodeQuiesced returns whether a node is stopped or paused, so that its disk is not changing
+func nodeQuiesced(api libmachine.API, cc config.ClusterConfig, n config.Node) bool {
+	st, err := nodeStatus(api, cc, n)
+	if err != nil {
+		klog.Warningf("status of %s: %v", n.Name, err)
+		return false
+	}
+	if st.Host != state.Running.String() {
+		return true
+	}
+	if n.ControlPlane {
+		return st.APIServer == state.Paused.String()
+	}
+	return st.Kubelet == state.Stopped.String()
+}
+
+func init() {
+	snapshotListCmd.Flags().StringVarP(&snapshotOutput, "output", "o", "table", "The output format. One of 'table', 'json'")
+	snapshotCmd.AddCommand(snapshotSaveCmd)
+	snapshotCmd.AddCommand(snapshotRestoreCmd)
+	snapshotCmd.AddCommand(snapshotListCmd)
+}
@@ -25,11 +25,14 @@ import (
 	"net/url"
 	"os"
 	"os/exec"
+	"os/signal"
 	"os/user"
 	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/docker/machine/libmachine/ssh"
@@ -63,6 +66,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/minikube/reason"
 	"k8s.io/minikube/pkg/minikube/style"
+	pkgnetwork "k8s.io/minikube/pkg/network"
 	pkgtrace "k8s.io/minikube/pkg/trace"
 
 	"k8s.io/minikube/pkg/minikube/registry"
@@ -77,6 +81,9 @@ var (
 	apiServerNames   []string
 	apiServerIPs     []net.IP
 	hostRe           = regexp.MustCompile(`^[^-][\w\.-]+$`)
+
+	// kubeletInUserNamespaceVersion is the first release with the KubeletInUserNamespace feature gate
+	kubeletInUserNamespaceVersion = semver.MustParse("1.22.0-alpha.0")
 )
 
 func init() {
@@ -131,8 +138,17 @@ func platform() string {
 
 // runStart handles the executes the flow of "minikube start"
 func runStart(cmd *cobra.Command, args []string) {
+	// The spec file may set the profile name, so it must be applied before anything else
+	if path := viper.GetString(clusterSpecFile); path != "" {
+		applyClusterSpec(cmd, path)
+	}
+	if path := viper.GetString(startBundle); path != "" {
+		applyBundle(cmd, path)
+	}
+
 	register.SetEventLogPath(localpath.EventLog(ClusterFlagValue()))
-	ctx := context.Background()
+	ctx, cancel := startContext()
+	defer cancel()
 	out.SetJSON(outputFormat == "json")
 	if err := pkgtrace.Initialize(viper.GetString(trace)); err != nil {
 		exit.Message(reason.Usage, "error initializing tracing: {{.Error}}", out.V{"Error": err.Error()})
@@ -141,7 +157,7 @@ func runStart(cmd *cobra.Command, args []string) {
 	displayVersion(version.GetVersion())
 
 	// No need to do the update check if no one is going to see it
-	if !viper.GetBool(interactive) || !viper.GetBool(dryRun) {
+	if (!viper.GetBool(interactive) || !viper.GetBool(dryRun)) && !download.Offline() {
 		// Avoid blocking execution on optional HTTP fetches
 		go notify.MaybePrintUpdateTextFromGithub()
 	}
@@ -202,8 +218,9 @@ func runStart(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	starter, err := provisionWithDriver(cmd, ds, existing)
+	starter, err := provisionWithDriver(ctx, cmd, ds, existing)
 	if err != nil {
+		exitIfCancelled(ctx, err, existing)
 		node.ExitIfFatal(err)
 		machine.MaybeDisplayAdvice(err, ds.Name)
 		if specified {
@@ -229,8 +246,9 @@ func runStart(cmd *cobra.Command, args []string) {
 				if err != nil {
 					out.WarningT("Failed to delete cluster {{.name}}, proceeding with retry anyway.", out.V{"name": ClusterFlagValue()})
 				}
-				starter, err = provisionWithDriver(cmd, ds, existing)
+				starter, err = provisionWithDriver(ctx, cmd, ds, existing)
 				if err != nil {
+					exitIfCancelled(ctx, err, existing)
 					continue
 				} else {
 					// Success!
@@ -260,18 +278,23 @@ func runStart(cmd *cobra.Command, args []string) {
 		}
 	}
 
-	kubeconfig, err := startWithDriver(cmd, starter, existing)
+	kubeconfig, err := startWithDriver(ctx, cmd, starter, existing)
 	if err != nil {
+		exitIfCancelled(ctx, err, existing)
 		node.ExitIfFatal(err)
 		exit.Error(reason.GuestStart, "failed to start node", err)
 	}
 
+	if len(starter.Cfg.Schedules) > 0 {
+		restoreSchedules(starter.Cfg)
+	}
+
 	if err := showKubectlInfo(kubeconfig, starter.Node.KubernetesVersion, starter.Cfg.Name); err != nil {
 		klog.Errorf("kubectl info: %v", err)
 	}
 }
 
-func provisionWithDriver(cmd *cobra.Command, ds registry.DriverState, existing *config.ClusterConfig) (node.Starter, error) {
+func provisionWithDriver(ctx context.Context, cmd *cobra.Command, ds registry.DriverState, existing *config.ClusterConfig) (node.Starter, error) {
 	driverName := ds.Name
 	klog.Infof("selected driver: %s", driverName)
 	validateDriver(ds, existing)
@@ -285,6 +308,7 @@ func provisionWithDriver(cmd *cobra.Command, ds registry.DriverState, existing *
 	if driverName == oci.Docker {
 		validateDockerStorageDriver(driverName)
 	}
+	validateRootless(driverName, existing)
 
 	// Download & update the driver, even in --download-only mode
 	if !viper.GetBool(dryRun) {
@@ -296,6 +320,12 @@ func provisionWithDriver(cmd *cobra.Command, ds registry.DriverState, existing *
 	if err != nil {
 		return node.Starter{}, errors.Wrap(err, "Failed to generate config")
 	}
+	pkgtrace.SetAttributes(map[string]string{
+		"minikube.profile":            cc.Name,
+		"minikube.driver":             cc.Driver,
+		"minikube.container_runtime":  cc.KubernetesConfig.ContainerRuntime,
+		"minikube.kubernetes_version": cc.KubernetesConfig.KubernetesVersion,
+	})
 
 	// This is about as far as we can go without overwriting config files
 	if viper.GetBool(dryRun) {
@@ -325,7 +355,7 @@ func provisionWithDriver(cmd *cobra.Command, ds registry.DriverState, existing *
 		ssh.SetDefaultClient(ssh.External)
 	}
 
-	mRunner, preExists, mAPI, host, err := node.Provision(&cc, &n, true, viper.GetBool(deleteOnFailure))
+	mRunner, preExists, mAPI, host, err := node.Provision(ctx, &cc, &n, true, viper.GetBool(deleteOnFailure))
 	if err != nil {
 		return node.Starter{}, err
 	}
@@ -341,10 +371,29 @@ func provisionWithDriver(cmd *cobra.Command, ds registry.DriverState, existing *
 	}, nil
 }
 
-func startWithDriver(cmd *cobra.Command, starter node.Starter, existing *config.ClusterConfig) (*kubeconfig.Settings, error) {
-	kubeconfig, err := node.Start(starter, true)
+func startWithDriver(ctx context.Context, cmd *cobra.Command, starter node.Starter, existing *config.ClusterConfig) (*kubeconfig.Settings, error) {
+	if existing == nil && viper.GetBool(highAvailability) {
+		vip, err := haVirtualIP(starter.Cfg.Driver, starter.Node.IP)
+		if err != nil {
+			return nil, errors.Wrap(err, "selecting HA virtual IP")
+		}
+		klog.Infof("using %s as the HA virtual IP", vip)
+		starter.Cfg.KubernetesConfig.APIServerHAVIP = vip
+		if err := config.SaveProfile(viper.GetString(config.ProfileName), starter.Cfg); err != nil {
+			return nil, errors.Wrap(err, "saving config")
+		}
+	}
+
+	if existing != nil && config.IsHA(*existing) {
+		// etcd needs a quorum of its members before the API server on the primary control plane can come up
+		if err := provisionControlPlanes(ctx, starter.Cfg); err != nil {
+			return nil, errors.Wrap(err, "provisioning control planes")
+		}
+	}
+
+	kubeconfig, err := node.Start(ctx, starter, true)
 	if err != nil {
-		kubeconfig, err = maybeDeleteAndRetry(cmd, *starter.Cfg, *starter.Node, starter.ExistingAddons, err)
+		kubeconfig, err = maybeDeleteAndRetry(ctx, cmd, *starter.Cfg, *starter.Node, starter.ExistingAddons, err)
 		if err != nil {
 			return nil, err
 		}
@@ -352,7 +401,11 @@ func startWithDriver(cmd *cobra.Command, starter node.Starter, existing *config.
 
 	numNodes := viper.GetInt(nodes)
 	if existing != nil {
-		if numNodes > 1 {
+		if viper.GetBool(highAvailability) && !config.IsHA(*existing) {
+			out.WarningT("The cluster {{.cluster}} already exists without a virtual IP, so the --ha parameter will be ignored.", out.V{"cluster": existing.Name})
+		}
+		// --ha implies --nodes, so only warn if --nodes was passed explicitly
+		if numNodes > 1 && (cmd.Flags().Changed(nodes) || !viper.GetBool(highAvailability)) {
 			// We ignore the --nodes parameter if we're restarting an existing cluster
 			out.WarningT(`The cluster {{.cluster}} already exists which means the --nodes parameter will be ignored. Use "minikube node add" to add nodes to an existing cluster.`, out.V{"cluster": existing.Name})
 		}
@@ -361,6 +414,8 @@ func startWithDriver(cmd *cobra.Command, starter node.Starter, existing *config.
 	if numNodes > 1 {
 		if driver.BareMetal(starter.Cfg.Driver) {
 			exit.Message(reason.DrvUnsupportedMulti, "The none driver is not compatible with multi-node clusters.")
+		} else if driver.IsSSH(starter.Cfg.Driver) && existing == nil {
+			exit.Message(reason.DrvUnsupportedMulti, `The ssh driver runs each node on a machine of its own. Use "minikube node add --ssh-ip-address" to add the other machines.`)
 		} else {
 			if existing == nil {
 				for i := 1; i < numNodes; i++ {
@@ -371,16 +426,20 @@ func startWithDriver(cmd *cobra.Command, starter node.Starter, existing *config.
 						ControlPlane:      false,
 						KubernetesVersion: starter.Cfg.KubernetesConfig.KubernetesVersion,
 					}
+					if config.IsHA(*starter.Cfg) && i < haControlPlanes {
+						n.ControlPlane = true
+						n.Port = starter.Cfg.KubernetesConfig.NodePort
+					}
 					out.Ln("") // extra newline for clarity on the command line
-					err := node.Add(starter.Cfg, n, viper.GetBool(deleteOnFailure))
+					err := node.Add(ctx, starter.Cfg, n, viper.GetBool(deleteOnFailure))
 					if err != nil {
 						return nil, errors.Wrap(err, "adding node")
 					}
 				}
 			} else {
 				for _, n := range existing.Nodes {
-					if !n.ControlPlane {
-						err := node.Add(starter.Cfg, n, viper.GetBool(deleteOnFailure))
+					if !config.IsPrimaryControlPlane(*existing, n) {
+						err := node.Add(ctx, starter.Cfg, n, viper.GetBool(deleteOnFailure))
 						if err != nil {
 							return nil, errors.Wrap(err, "adding node")
 						}
@@ -393,6 +452,43 @@ func startWithDriver(cmd *cobra.Command, starter node.Starter, existing *config.
 	return kubeconfig, nil
 }
 
+// haVirtualIP selects the virtual IP of a new HA cluster: the address minikube reserves in the network it creates
+// for the primary control plane, which is kept out of the range the network hands out to machines
+func haVirtualIP(driverName string, cpIP string) (string, error) {
+	if !haSupported(driverName) {
+		return "", fmt.Errorf("the %s driver does not reserve an address for a virtual IP", driverName)
+	}
+	n, err := pkgnetwork.Inspect(cpIP)
+	if err != nil {
+		return "", err
+	}
+	if n.Reserved == "" || n.Reserved == cpIP {
+		return "", fmt.Errorf("no reserved address in the network of %s", cpIP)
+	}
+	return n.Reserved, nil
+}
+
+// haSupported returns whether the network minikube creates for the driver's machines reserves an address for their virtual IP
+func haSupported(driverName string) bool {
+	return driverName == driver.Docker || driverName == driver.KVM2
+}
+
+// provisionControlPlanes starts the machines of the secondary control planes of an existing HA cluster
+func provisionControlPlanes(ctx context.Context, cc *config.ClusterConfig) error {
+	for _, n := range config.ControlPlanes(*cc) {
+		if config.IsPrimaryControlPlane(*cc, n) {
+			continue
+		}
+		if _, _, _, _, err := node.Provision(ctx, cc, &n, false, false); err != nil {
+			return errors.Wrapf(err, "provisioning %s", n.Name)
+		}
+		if err := node.Save(cc, &n); err != nil {
+			return errors.Wrapf(err, "saving %s", n.Name)
+		}
+	}
+	return nil
+}
+
 func warnAboutMultiNodeCNI() {
 	out.WarningT("Cluster was created without any CNI, adding node to it might cause broken network.")
 }
@@ -468,8 +564,8 @@ func showKubectlInfo(kcs *kubeconfig.Settings, k8sVersion string, machineName st
 	return nil
 }
 
-func maybeDeleteAndRetry(cmd *cobra.Command, existing config.ClusterConfig, n config.Node, existingAddons map[string]bool, originalErr error) (*kubeconfig.Settings, error) {
-	if viper.GetBool(deleteOnFailure) {
+func maybeDeleteAndRetry(ctx context.Context, cmd *cobra.Command, existing config.ClusterConfig, n config.Node, existingAddons map[string]bool, originalErr error) (*kubeconfig.Settings, error) {
+	if viper.GetBool(deleteOnFailure) && ctx.Err() == nil {
 		out.WarningT("Node {{.name}} failed to start, deleting and trying again.", out.V{"name": n.Name})
 		// Start failed, delete the cluster and try again
 		profile, err := config.LoadProfile(existing.Name)
@@ -486,7 +582,7 @@ func maybeDeleteAndRetry(cmd *cobra.Command, existing config.ClusterConfig, n co
 		cc := updateExistingConfigFromFlags(cmd, &existing)
 		var kubeconfig *kubeconfig.Settings
 		for _, n := range cc.Nodes {
-			r, p, m, h, err := node.Provision(&cc, &n, n.ControlPlane, false)
+			r, p, m, h, err := node.Provision(ctx, &cc, &n, n.ControlPlane, false)
 			s := node.Starter{
 				Runner:         r,
 				PreExists:      p,
@@ -501,8 +597,8 @@ func maybeDeleteAndRetry(cmd *cobra.Command, existing config.ClusterConfig, n co
 				return nil, err
 			}
 
-			k, err := node.Start(s, n.ControlPlane)
-			if n.ControlPlane {
+			k, err := node.Start(ctx, s, config.IsPrimaryControlPlane(cc, n))
+			if config.IsPrimaryControlPlane(cc, n) {
 				kubeconfig = k
 			}
 			if err != nil {
@@ -1157,15 +1253,67 @@ func validateFlags(cmd *cobra.Command, drvName string) {
 		}
 	}
 
+	if policy := viper.GetString(auditPolicy); policy != "" {
+		if _, err := os.Stat(policy); err != nil {
+			exit.Message(reason.Usage, "Unable to read audit policy {{.path}}: {{.error}}", out.V{"path": policy, "error": err})
+		}
+	}
+
+	validateOIDC()
+
+	for _, f := range []string{auditLogMaxAge, auditLogMaxBackup, auditLogMaxSize} {
+		if viper.GetInt(f) < 0 {
+			exit.Message(reason.Usage, "--{{.flag}} must not be negative, got {{.value}}", out.V{"flag": f, "value": viper.GetInt(f)})
+		}
+	}
+
 	if outputFormat != "text" && outputFormat != "json" {
 		exit.Message(reason.Usage, "Sorry, please set the --output flag to one of the following valid options: [text,json]")
 	}
 
+	if cmd.Flags().Changed(autoPauseInterval) && viper.GetDuration(autoPauseInterval) <= 0 {
+		exit.Message(reason.Usage, "--auto-pause-interval must be greater than 0, got {{.interval}}", out.V{"interval": viper.GetDuration(autoPauseInterval)})
+	}
+
+	if viper.GetBool(highAvailability) {
+		if !haSupported(drvName) {
+			exit.Message(reason.DrvUnsupportedMulti, "The '{{.name}}' driver does not support multi-control-plane clusters", out.V{"name": drvName})
+		}
+		if viper.GetInt(nodes) < haControlPlanes {
+			if cmd.Flags().Changed(nodes) {
+				out.WarningT("--ha requires {{.count}} control plane nodes, ignoring --nodes={{.nodes}}", out.V{"count": haControlPlanes, "nodes": viper.GetInt(nodes)})
+			}
+			viper.Set(nodes, haControlPlanes)
+		}
+	}
+
 	validateRegistryMirror()
 	validateInsecureRegistry()
 
 }
 
+// validateOIDC validates the OpenID Connect flags
+func validateOIDC() {
+	issuer := viper.GetString(oidcIssuerURL)
+	if issuer == "" {
+		return
+	}
+
+	// the API server does not start with an issuer URL that is not https
+	u, err := url.Parse(issuer)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		exit.Message(reason.Usage, "--oidc-issuer-url must be an https URL, got {{.url}}", out.V{"url": issuer})
+	}
+	if viper.GetString(oidcClientID) == "" {
+		exit.Message(reason.Usage, "--oidc-client-id is required with --oidc-issuer-url")
+	}
+	if ca := viper.GetString(oidcCAFile); ca != "" {
+		if _, err := os.Stat(ca); err != nil {
+			exit.Message(reason.Usage, "Unable to read OIDC CA {{.path}}: {{.error}}", out.V{"path": ca, "error": err})
+		}
+	}
+}
+
 // validateChangedMemoryFlags validates memory related flags.
 func validateChangedMemoryFlags(drvName string) {
 	if driver.IsKIC(drvName) && !oci.HasMemoryCgroup() {
@@ -1362,6 +1510,11 @@ func getKubernetesVersion(old *config.ClusterConfig) string {
 		paramVersion = old.KubernetesConfig.KubernetesVersion
 	}
 
+	return normalizeKubernetesVersion(paramVersion)
+}
+
+// normalizeKubernetesVersion resolves 'stable' and 'latest', and returns a version with its prefix
+func normalizeKubernetesVersion(paramVersion string) string {
 	if paramVersion == "" || strings.EqualFold(paramVersion, "stable") {
 		paramVersion = constants.DefaultKubernetesVersion
 	} else if strings.EqualFold(paramVersion, "latest") {
@@ -1398,6 +1551,28 @@ func validateDockerStorageDriver(drvName string) {
 	viper.Set(preload, false)
 }
 
+// validateRootless checks that a rootless docker/podman daemon is able to run the cluster
+func validateRootless(drvName string, existing *config.ClusterConfig) {
+	if !driver.IsKIC(drvName) {
+		return
+	}
+	si, err := oci.CachedDaemonInfo(drvName)
+	if err != nil || !si.Rootless {
+		return
+	}
+	out.Step(style.Notice, "Using rootless {{.driver_name}} driver", out.V{"driver_name": drvName})
+
+	if si.CgroupVersion != "2" {
+		exitIfNotForced(reason.DrvUnsupportedRootless, "Rootless {{.driver_name}} requires cgroup v2, the host is using cgroup v{{.version}}", out.V{"driver_name": drvName, "version": si.CgroupVersion})
+	}
+
+	// without the feature gate, the kubelet fails to set sysctls and oom scores in the user namespace
+	nvs, err := semver.Make(strings.TrimPrefix(getKubernetesVersion(existing), version.VersionPrefix))
+	if err == nil && nvs.LT(kubeletInUserNamespaceVersion) {
+		exit.Message(reason.KubernetesTooOld, "Rootless {{.driver_name}} requires Kubernetes v1.22 or later for the KubeletInUserNamespace feature gate, but Kubernetes {{.version}} was requested. Pass --kubernetes-version=v1.22.0 or later, or use a rootful {{.driver_name}}", out.V{"driver_name": drvName, "version": version.VersionPrefix + nvs.String()})
+	}
+}
+
 func exitIfNotForced(r reason.Kind, message string, v ...out.V) {
 	if !viper.GetBool(force) {
 		exit.Message(r, message, v...)
@@ -1411,3 +1586,70 @@ func exitGuestProvision(err error) {
 	}
 	exit.Error(reason.GuestProvision, "error provisioning host", err)
 }
+
+// startContext returns the context that the start path runs under. It is cancelled by the
+// first interrupt and expires after --timeout, if set. A second interrupt exits immediately.
+func startContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tctx, tcancel := ctx, cancel
+	if t := viper.GetDuration(startTimeout); t > 0 {
+		tctx, tcancel = context.WithTimeout(ctx, t)
+	}
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig, ok := <-c
+		if !ok {
+			return
+		}
+		out.WarningT("Received {{.name}} signal, cleaning up. Press Ctrl-C again to exit immediately.", out.V{"name": sig})
+		cancel()
+		if sig, ok = <-c; ok {
+			exit.Message(reason.Interrupted, "Received {{.name}} signal", out.V{"name": sig})
+		}
+	}()
+
+	return tctx, func() {
+		signal.Stop(c)
+		close(c)
+		tcancel()
+		cancel()
+	}
+}
+
+// exitIfCancelled exits if err was caused by the start being interrupted or timing out.
+// A cluster created by this start is deleted first, while an existing one is left as is.
+func exitIfCancelled(ctx context.Context, err error, existing *config.ClusterConfig) {
+	if ctx.Err() == nil {
+		return
+	}
+	klog.Warningf("start aborted: %v", err)
+
+	step := register.Reg.Step()
+	if existing == nil {
+		rollbackStart()
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		exit.Message(reason.GuestStartTimeout, `minikube start timed out after {{.timeout}} during step "{{.step}}"`, out.V{"timeout": viper.GetDuration(startTimeout), "step": step})
+	}
+	exit.Message(reason.Interrupted, `minikube start was interrupted during step "{{.step}}"`, out.V{"step": step})
+}
+
+// rollbackStart deletes the cluster created by an aborted start
+func rollbackStart() {
+	profile, err := config.LoadProfile(ClusterFlagValue())
+	if err != nil {
+		klog.Infof("nothing to roll back: %v", err)
+		return
+	}
+
+	out.Step(style.DeletingHost, `Deleting "{{.name}}", which was created by this start ...`, out.V{"name": profile.Name})
+	// the start context is done, so deletion gets its own
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	if err := deleteProfile(ctx, profile); err != nil {
+		out.WarningT("Failed to delete cluster {{.name}}: {{.error}}", out.V{"name": profile.Name, "error": err})
+	}
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"runtime"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/minikube/bundle"
+	"k8s.io/minikube/pkg/minikube/download"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/version"
+)
+
+// bundleImages are the images of the bundle passed to start, which have to be loaded into the cluster
+var bundleImages []string
+
+// applyBundle seeds the caches from a bundle, sets the flags it was built for unless they were set on the command line,
+// and disables all downloads
+func applyBundle(cmd *cobra.Command, path string) {
+	out.Step(style.Caching, "Extracting bundle {{.path}} ...", out.V{"path": path})
+	m, err := bundle.Extract(path)
+	if err != nil {
+		exit.Error(reason.HostBundle, "Failed to extract bundle", err)
+	}
+
+	if m.OS != runtime.GOOS || m.Arch != runtime.GOARCH {
+		exit.Message(reason.Usage, "The bundle {{.path}} was created for {{.bundle_os}}/{{.bundle_arch}}, and can not be used on {{.os}}/{{.arch}}", out.V{"path": path, "bundle_os": m.OS, "bundle_arch": m.Arch, "os": runtime.GOOS, "arch": runtime.GOARCH})
+	}
+	if m.MinikubeVersion != version.GetVersion() {
+		out.WarningT("The bundle {{.path}} was created by minikube {{.bundle_version}}, which may need different downloads than minikube {{.version}}", out.V{"path": path, "bundle_version": m.MinikubeVersion, "version": version.GetVersion()})
+	}
+
+	for _, f := range []struct {
+		name  string
+		value string
+	}{
+		{"driver", m.Driver},
+		{kubernetesVersion, m.KubernetesVersion},
+		{containerRuntime, m.ContainerRuntime},
+		{cniFlag, m.CNI},
+	} {
+		if f.value == "" {
+			continue
+		}
+		if cmd.Flags().Changed(f.name) {
+			v := viper.GetString(f.name)
+			if f.name == kubernetesVersion {
+				v = normalizeKubernetesVersion(v)
+			}
+			if v != f.value {
+				exit.Message(reason.Usage, "--{{.name}}={{.value}} does not match the bundle {{.path}}, which was created for {{.bundle_value}}", out.V{"name": f.name, "value": v, "path": path, "bundle_value": f.value})
+			}
+			continue
+		}
+		klog.Infof("bundle: setting --%s=%s", f.name, f.value)
+		if err := cmd.Flags().Set(f.name, f.value); err != nil {
+			exit.Message(reason.Usage, "Invalid value for {{.name}} in bundle {{.path}}: {{.error}}", out.V{"name": f.name, "path": path, "error": err})
+		}
+	}
+
+	bundleImages = m.Images
+	download.SetOffline(true)
+}
@@ -18,6 +18,8 @@ package cmd
 
 import (
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -27,6 +29,7 @@ import (
 	"github.com/spf13/viper"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/drivers/kic"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kverify"
 	"k8s.io/minikube/pkg/minikube/cni"
@@ -58,6 +61,7 @@ const (
 	networkPlugin           = "network-plugin"
 	enableDefaultCNI        = "enable-default-cni"
 	cniFlag                 = "cni"
+	cniMTU                  = "cni-mtu"
 	hypervVirtualSwitch     = "hyperv-virtual-switch"
 	hypervUseExternalSwitch = "hyperv-use-external-switch"
 	hypervExternalAdapter   = "hyperv-external-adapter"
@@ -71,6 +75,16 @@ const (
 	keepContext             = "keep-context"
 	createMount             = "mount"
 	featureGates            = "feature-gates"
+	kubeadmPatch            = "kubeadm-patch"
+	auditPolicy             = "audit-policy"
+	auditLogMaxAge          = "audit-log-maxage"
+	auditLogMaxBackup       = "audit-log-maxbackup"
+	auditLogMaxSize         = "audit-log-maxsize"
+	oidcIssuerURL           = "oidc-issuer-url"
+	oidcClientID            = "oidc-client-id"
+	oidcCAFile              = "oidc-ca-file"
+	oidcUsernameClaim       = "oidc-username-claim"
+	oidcGroupsClaim         = "oidc-groups-claim"
 	apiServerName           = "apiserver-name"
 	apiServerPort           = "apiserver-port"
 	dnsDomain               = "dns-domain"
@@ -93,6 +107,7 @@ const (
 	dryRun                  = "dry-run"
 	interactive             = "interactive"
 	waitTimeout             = "wait-timeout"
+	startTimeout            = "timeout"
 	nativeSSH               = "native-ssh"
 	minUsableMem            = 1800 // Kubernetes (kubeadm) will not start with less
 	minRecommendedMem       = 1900 // Warn at no lower than existing configurations
@@ -102,6 +117,8 @@ const (
 	hostOnlyNicType         = "host-only-nic-type"
 	natNicType              = "nat-nic-type"
 	nodes                   = "nodes"
+	highAvailability        = "ha"
+	haControlPlanes         = 3
 	preload                 = "preload"
 	deleteOnFailure         = "delete-on-failure"
 	forceSystemd            = "force-systemd"
@@ -116,6 +133,10 @@ const (
 	sshSSHPort              = "ssh-port"
 	defaultSSHUser          = "root"
 	defaultSSHPort          = 22
+	clusterSpecFile         = "file"
+	startBundle             = "bundle"
+	autoPauseInterval       = "auto-pause-interval"
+	autoPauseNamespaces     = "auto-pause-namespaces"
 )
 
 var (
@@ -149,19 +170,26 @@ func initMinikubeFlags() {
 	startCmd.Flags().String(criSocket, "", "The cri socket path to be used.")
 	startCmd.Flags().String(networkPlugin, "", "Kubelet network plug-in to use (default: auto)")
 	startCmd.Flags().Bool(enableDefaultCNI, false, "DEPRECATED: Replaced by --cni=bridge")
-	startCmd.Flags().String(cniFlag, "", "CNI plug-in to use. Valid options: auto, bridge, calico, cilium, flannel, kindnet, or path to a CNI manifest (default: auto)")
-	startCmd.Flags().StringSlice(waitComponents, kverify.DefaultWaitList, fmt.Sprintf("comma separated list of Kubernetes components to verify and wait for after starting a cluster. defaults to %q, available options: %q . other acceptable values are 'all' or 'none', 'true' and 'false'", strings.Join(kverify.DefaultWaitList, ","), strings.Join(kverify.AllComponentsList, ",")))
+	startCmd.Flags().String(cniFlag, "", fmt.Sprintf("CNI plug-in to use. Valid options: auto, bridge, kindnet, %s, or a path or URL to a CNI manifest (default: auto). The version of %s may be pinned, for example antrea@0.13", strings.Join(cni.Registered(), ", "), strings.Join(cni.Registered(), "/")))
+	startCmd.Flags().Int(cniMTU, 0, "MTU of the pod network, only supported by --cni=calico, cilium and weave (default: chosen by the CNI)")
+	startCmd.Flags().StringSlice(waitComponents, kverify.DefaultWaitList, fmt.Sprintf("comma separated list of Kubernetes components to verify and wait for after starting a cluster. defaults to %q, plus cni_ready when --cni is set, available options: %q . other acceptable values are 'all' or 'none', 'true' and 'false'", strings.Join(kverify.DefaultWaitList, ","), strings.Join(kverify.AllComponentsList, ",")))
 	startCmd.Flags().Duration(waitTimeout, 6*time.Minute, "max time to wait per Kubernetes or host to be healthy.")
+	startCmd.Flags().Duration(startTimeout, 0, "max time for the whole start to complete, after which a cluster created by this start is deleted. 0 means no limit.")
 	startCmd.Flags().Bool(nativeSSH, true, "Use native Golang SSH client (default true). Set to 'false' to use the command line 'ssh' command when accessing the docker machine. Useful for the machine drivers when they will not start with 'Waiting for SSH'.")
 	startCmd.Flags().Bool(autoUpdate, true, "If set, automatically updates drivers to the latest version. Defaults to true.")
 	startCmd.Flags().Bool(installAddons, true, "If set, install addons. Defaults to true.")
 	startCmd.Flags().IntP(nodes, "n", 1, "The number of nodes to spin up. Defaults to 1.")
+	startCmd.Flags().Duration(autoPauseInterval, time.Minute, "How long the API server must be idle before the auto-pause addon pauses the cluster")
+	startCmd.Flags().StringSlice(autoPauseNamespaces, []string{"kube-system"}, "The namespaces whose containers the auto-pause addon pauses. An empty value pauses all namespaces")
+	startCmd.Flags().Bool(highAvailability, false, "Create a cluster with 3 control plane nodes behind a virtual IP. Any additional --nodes are added as workers. Supported by the docker and kvm2 drivers.")
 	startCmd.Flags().Bool(preload, true, "If set, download tarball of preloaded images if available to improve start time. Defaults to true.")
 	startCmd.Flags().Bool(deleteOnFailure, false, "If set, delete the current cluster if start fails and try again. Defaults to false.")
 	startCmd.Flags().Bool(forceSystemd, false, "If set, force the container runtime to use sytemd as cgroup manager. Defaults to false.")
 	startCmd.Flags().StringP(network, "", "", "network to run minikube with. Only available with the docker/podman drivers. If left empty, minikube will create a new network.")
 	startCmd.Flags().StringVarP(&outputFormat, "output", "o", "text", "Format to print stdout in. Options include: [text,json]")
-	startCmd.Flags().StringP(trace, "", "", "Send trace events. Options include: [gcp]")
+	startCmd.Flags().StringP(trace, "", "", "Send trace events. Options include: [gcp, otel, file]")
+	startCmd.Flags().String(startBundle, "", "Path to a bundle created by 'minikube bundle create'. The caches are seeded from it, and minikube will not download anything.")
+	startCmd.Flags().StringP(clusterSpecFile, "f", "", "Path to a YAML or JSON cluster spec file (see 'minikube profile export'). Flags passed on the command line take precedence over values from the file, which take precedence over environment variables and 'minikube config' values.")
 }
 
 // initKubernetesFlags inits the commandline flags for Kubernetes related options
@@ -174,6 +202,16 @@ func initKubernetesFlags() {
 		Valid components are: kubelet, kubeadm, apiserver, controller-manager, etcd, proxy, scheduler
 		Valid kubeadm parameters: `+fmt.Sprintf("%s, %s", strings.Join(bsutil.KubeadmExtraArgsAllowed[bsutil.KubeadmCmdParam], ", "), strings.Join(bsutil.KubeadmExtraArgsAllowed[bsutil.KubeadmConfigParam], ",")))
 	startCmd.Flags().String(featureGates, "", "A set of key=value pairs that describe feature gates for alpha/experimental features.")
+	startCmd.Flags().StringSlice(kubeadmPatch, nil, fmt.Sprintf("Path to a YAML file of patches applied on top of the generated kubeadm config. Documents with a kind are strategic merge patches, documents with a target and a patch are JSON patches. Valid kinds are: %s. Can be repeated.", strings.Join(bsutil.KubeadmPatchTargets, ", ")))
+	startCmd.Flags().String(auditPolicy, "", "Path to an API server audit policy file. Enables auditing, with the audit log shown by 'minikube logs --audit'.")
+	startCmd.Flags().Int(auditLogMaxAge, 0, "Number of days to retain old audit log files (default: API server default)")
+	startCmd.Flags().Int(auditLogMaxBackup, 0, "Number of old audit log files to retain (default: API server default)")
+	startCmd.Flags().Int(auditLogMaxSize, 0, "Size in megabytes of the audit log before it gets rotated (default: API server default)")
+	startCmd.Flags().String(oidcIssuerURL, "", "URL of the OpenID Connect issuer, which must use https. Enables OIDC authentication in the API server.")
+	startCmd.Flags().String(oidcClientID, "", "The client ID that OIDC tokens must be issued for, required with --oidc-issuer-url")
+	startCmd.Flags().String(oidcCAFile, "", "Path to the CA that signed the certificate of the OIDC issuer, if it is not signed by a public CA")
+	startCmd.Flags().String(oidcUsernameClaim, "", "The JWT claim used as the user name (default: API server default)")
+	startCmd.Flags().String(oidcGroupsClaim, "", "The JWT claim used as the user's groups")
 	startCmd.Flags().String(dnsDomain, constants.ClusterDNSDomain, "The cluster dns domain name used in the Kubernetes cluster")
 	startCmd.Flags().Int(apiServerPort, constants.APIServerPort, "The apiserver listening port")
 	startCmd.Flags().String(apiServerName, constants.APIServerName, "The authoritative apiserver hostname for apiserver certificates and connectivity. This can be used if you want to make the apiserver available from outside the machine")
@@ -351,6 +389,8 @@ func generateClusterConfig(cmd *cobra.Command, existing *config.ClusterConfig, k
 			SSHUser:                 viper.GetString(sshSSHUser),
 			SSHKey:                  viper.GetString(sshSSHKey),
 			SSHPort:                 viper.GetInt(sshSSHPort),
+			AutoPauseInterval:       viper.GetDuration(autoPauseInterval),
+			AutoPauseNamespaces:     viper.GetStringSlice(autoPauseNamespaces),
 			KubernetesConfig: config.KubernetesConfig{
 				KubernetesVersion:      k8sVersion,
 				ClusterName:            ClusterFlagValue(),
@@ -366,13 +406,31 @@ func generateClusterConfig(cmd *cobra.Command, existing *config.ClusterConfig, k
 				ServiceCIDR:            viper.GetString(serviceCIDR),
 				ImageRepository:        repository,
 				ExtraOptions:           config.ExtraOptions,
+				KubeadmPatches:         kubeadmPatches(),
+				AuditPolicy:            absFlagPath(auditPolicy),
+				AuditLogMaxAge:         viper.GetInt(auditLogMaxAge),
+				AuditLogMaxBackup:      viper.GetInt(auditLogMaxBackup),
+				AuditLogMaxSize:        viper.GetInt(auditLogMaxSize),
+				OIDCIssuerURL:          viper.GetString(oidcIssuerURL),
+				OIDCClientID:           viper.GetString(oidcClientID),
+				OIDCCAFile:             absFlagPath(oidcCAFile),
+				OIDCUsernameClaim:      viper.GetString(oidcUsernameClaim),
+				OIDCGroupsClaim:        viper.GetString(oidcGroupsClaim),
 				ShouldLoadCachedImages: viper.GetBool(cacheImages),
 				CNI:                    chosenCNI,
+				CNIMTU:                 viper.GetInt(cniMTU),
 				NodePort:               viper.GetInt(apiServerPort),
 			},
 			MultiNodeRequested: viper.GetInt(nodes) > 1,
 		}
 		cc.VerifyComponents = interpretWaitFlag(*cmd)
+		if !cmd.Flags().Changed(waitComponents) && cniChosen(chosenCNI) {
+			cc.VerifyComponents = withCNIReady(cc.VerifyComponents)
+		}
+		if driver.IsKIC(drvName) {
+			si, err := oci.CachedDaemonInfo(drvName)
+			cc.Rootless = err == nil && si.Rootless
+		}
 		if viper.GetBool(createMount) && driver.IsKIC(drvName) {
 			cc.ContainerVolumeMounts = []string{viper.GetString(mountString)}
 		}
@@ -387,6 +445,10 @@ func generateClusterConfig(cmd *cobra.Command, existing *config.ClusterConfig, k
 		}
 	}
 
+	if len(bundleImages) > 0 {
+		cc.OfflineImages = bundleImages
+	}
+
 	klog.Infof("config:\n%+v", cc)
 
 	r, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime})
@@ -573,6 +635,14 @@ func updateExistingConfigFromFlags(cmd *cobra.Command, existing *config.ClusterC
 		cc.NatNicType = viper.GetString(natNicType)
 	}
 
+	if cmd.Flags().Changed(autoPauseInterval) {
+		cc.AutoPauseInterval = viper.GetDuration(autoPauseInterval)
+	}
+
+	if cmd.Flags().Changed(autoPauseNamespaces) {
+		cc.AutoPauseNamespaces = viper.GetStringSlice(autoPauseNamespaces)
+	}
+
 	if cmd.Flags().Changed(kubernetesVersion) {
 		cc.KubernetesConfig.KubernetesVersion = getKubernetesVersion(existing)
 	}
@@ -641,6 +711,46 @@ func updateExistingConfigFromFlags(cmd *cobra.Command, existing *config.ClusterC
 		cc.KubernetesConfig.ExtraOptions = config.ExtraOptions
 	}
 
+	if cmd.Flags().Changed(kubeadmPatch) {
+		cc.KubernetesConfig.KubeadmPatches = kubeadmPatches()
+	}
+
+	if cmd.Flags().Changed(auditPolicy) {
+		cc.KubernetesConfig.AuditPolicy = absFlagPath(auditPolicy)
+	}
+
+	if cmd.Flags().Changed(auditLogMaxAge) {
+		cc.KubernetesConfig.AuditLogMaxAge = viper.GetInt(auditLogMaxAge)
+	}
+
+	if cmd.Flags().Changed(auditLogMaxBackup) {
+		cc.KubernetesConfig.AuditLogMaxBackup = viper.GetInt(auditLogMaxBackup)
+	}
+
+	if cmd.Flags().Changed(auditLogMaxSize) {
+		cc.KubernetesConfig.AuditLogMaxSize = viper.GetInt(auditLogMaxSize)
+	}
+
+	if cmd.Flags().Changed(oidcIssuerURL) {
+		cc.KubernetesConfig.OIDCIssuerURL = viper.GetString(oidcIssuerURL)
+	}
+
+	if cmd.Flags().Changed(oidcClientID) {
+		cc.KubernetesConfig.OIDCClientID = viper.GetString(oidcClientID)
+	}
+
+	if cmd.Flags().Changed(oidcCAFile) {
+		cc.KubernetesConfig.OIDCCAFile = absFlagPath(oidcCAFile)
+	}
+
+	if cmd.Flags().Changed(oidcUsernameClaim) {
+		cc.KubernetesConfig.OIDCUsernameClaim = viper.GetString(oidcUsernameClaim)
+	}
+
+	if cmd.Flags().Changed(oidcGroupsClaim) {
+		cc.KubernetesConfig.OIDCGroupsClaim = viper.GetString(oidcGroupsClaim)
+	}
+
 	if cmd.Flags().Changed(enableDefaultCNI) && !cmd.Flags().Changed(cniFlag) {
 		if viper.GetBool(enableDefaultCNI) {
 			klog.Errorf("Found deprecated --enable-default-cni flag, setting --cni=bridge")
@@ -652,8 +762,14 @@ func updateExistingConfigFromFlags(cmd *cobra.Command, existing *config.ClusterC
 		cc.KubernetesConfig.CNI = viper.GetString(cniFlag)
 	}
 
+	if cmd.Flags().Changed(cniMTU) {
+		cc.KubernetesConfig.CNIMTU = viper.GetInt(cniMTU)
+	}
+
 	if cmd.Flags().Changed(waitComponents) {
 		cc.VerifyComponents = interpretWaitFlag(*cmd)
+	} else if cmd.Flags().Changed(cniFlag) && cniChosen(cc.KubernetesConfig.CNI) {
+		cc.VerifyComponents = withCNIReady(cc.VerifyComponents)
 	}
 
 	// Handle flags and legacy configuration upgrades that do not contain KicBaseImage
@@ -664,6 +780,57 @@ func updateExistingConfigFromFlags(cmd *cobra.Command, existing *config.ClusterC
 	return cc
 }
 
+// kubeadmPatches reads the patch files passed with --kubeadm-patch
+func kubeadmPatches() []config.KubeadmPatch {
+	var patches []config.KubeadmPatch
+	for _, path := range viper.GetStringSlice(kubeadmPatch) {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			exit.Message(reason.Usage, "Unable to read kubeadm patch file {{.path}}: {{.error}}", out.V{"path": path, "error": err})
+		}
+		ps, err := bsutil.ParseKubeadmPatches(data)
+		if err != nil {
+			exit.Message(reason.Usage, "Invalid kubeadm patch file {{.path}}: {{.error}}", out.V{"path": path, "error": err})
+		}
+		patches = append(patches, ps...)
+	}
+	return patches
+}
+
+// absFlagPath returns the absolute path of the file passed with a flag,
+// since the file is copied to the cluster again on every start
+func absFlagPath(name string) string {
+	p := viper.GetString(name)
+	if p == "" {
+		return ""
+	}
+	abs, err := filepath.Abs(p)
+	if err != nil {
+		exit.Message(reason.Usage, "Unable to resolve --{{.flag}} {{.path}}: {{.error}}", out.V{"flag": name, "path": p, "error": err})
+	}
+	return abs
+}
+
+// cniChosen returns whether a CNI was picked, rather than left to minikube to choose or disabled
+func cniChosen(name string) bool {
+	switch name {
+	case "", "auto", "false":
+		return false
+	}
+	return true
+}
+
+// withCNIReady returns a copy of the wait components, also waiting for the CNI to be running
+func withCNIReady(wcs map[string]bool) map[string]bool {
+	w := map[string]bool{}
+	for k, v := range wcs {
+		w[k] = v
+	}
+	w[kverify.CNIReadyKey] = true
+	klog.Infof("Waiting for the chosen CNI, wait components: %+v", w)
+	return w
+}
+
 // interpretWaitFlag interprets the wait flag and respects the legacy minikube users
 // returns map of components to wait for
 func interpretWaitFlag(cmd cobra.Command) map[string]bool {
@@ -0,0 +1,277 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/exit"
+	"k8s.io/minikube/pkg/minikube/node"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/minikube/reason"
+)
+
+// specFlag is a start flag, and the values a cluster spec sets it to
+type specFlag struct {
+	name   string
+	values []string
+}
+
+// applyClusterSpec applies the values of a cluster spec file as if they were passed as flags.
+// The precedence order, from highest to lowest, is:
+//  1. flags passed on the command line
+//  2. the cluster spec file
+//  3. MINIKUBE_* environment variables
+//  4. values set by `minikube config set`
+//  5. flag defaults
+func applyClusterSpec(cmd *cobra.Command, path string) {
+	s, err := config.ReadClusterSpec(path)
+	if err != nil {
+		exit.Message(reason.Usage, "Unable to load cluster spec file {{.path}}: {{.error}}", out.V{"path": path, "error": err})
+	}
+
+	flags, err := clusterSpecFlags(s)
+	if err != nil {
+		exit.Message(reason.Usage, "Unsupported setting in cluster spec file {{.path}}: {{.error}}", out.V{"path": path, "error": err})
+	}
+	for _, f := range flags {
+		if cmd.Flags().Changed(f.name) {
+			klog.Infof("ignoring %s from cluster spec file: --%s was set on the command line", f.name, f.name)
+			continue
+		}
+		for _, v := range f.values {
+			if err := cmdcfg.Validate(f.name, v); err != nil {
+				exit.Message(reason.Usage, "Invalid value for {{.name}} in cluster spec file {{.path}}: {{.error}}", out.V{"name": f.name, "path": path, "error": err})
+			}
+			klog.Infof("cluster spec: setting --%s=%s", f.name, v)
+			if err := cmd.Flags().Set(f.name, v); err != nil {
+				exit.Message(reason.Usage, "Invalid value for {{.name}} in cluster spec file {{.path}}: {{.error}}", out.V{"name": f.name, "path": path, "error": err})
+			}
+		}
+	}
+}
+
+// clusterSpecFlags converts the fields present in a cluster spec to their equivalent start flags,
+// returning an error for settings which no start flag can reproduce
+func clusterSpecFlags(s *config.ClusterSpec) ([]specFlag, error) {
+	cc := s.Spec
+	k := cc.KubernetesConfig
+	flags := []specFlag{}
+
+	if len(cc.ContainerVolumeMounts) > 1 {
+		return nil, fmt.Errorf("only one of the %d containerVolumeMounts can be passed as --%s", len(cc.ContainerVolumeMounts), mountString)
+	}
+	if len(cc.Mounts) > 0 {
+		return nil, errors.New(`mounts can not be set on start, add them with "minikube mount --persistent" once the cluster is running`)
+	}
+	if err := checkSpecNodes(s); err != nil {
+		return nil, err
+	}
+
+	add := func(field string, name string, values ...string) {
+		if s.IsSet(field) {
+			flags = append(flags, specFlag{name: name, values: values})
+		}
+	}
+	join := func(vs []string) string {
+		return strings.Join(vs, ",")
+	}
+
+	add("Name", config.ProfileName, cc.Name)
+	add("KeepContext", keepContext, strconv.FormatBool(cc.KeepContext))
+	add("EmbedCerts", embedCerts, strconv.FormatBool(cc.EmbedCerts))
+	add("MinikubeISO", isoURL, cc.MinikubeISO)
+	add("KicBaseImage", kicBaseImage, cc.KicBaseImage)
+	add("Memory", memory, fmt.Sprintf("%dmb", cc.Memory))
+	add("CPUs", cpus, strconv.Itoa(cc.CPUs))
+	add("DiskSize", humanReadableDiskSize, fmt.Sprintf("%dmb", cc.DiskSize))
+	add("Driver", "driver", cc.Driver)
+	add("HyperkitVpnKitSock", vpnkitSock, cc.HyperkitVpnKitSock)
+	add("HyperkitVSockPorts", vsockPorts, join(cc.HyperkitVSockPorts))
+	add("DockerEnv", "docker-env", cc.DockerEnv...)
+	add("DockerOpt", "docker-opt", cc.DockerOpt...)
+	add("InsecureRegistry", "insecure-registry", join(cc.InsecureRegistry))
+	add("RegistryMirror", "registry-mirror", join(cc.RegistryMirror))
+	add("HostOnlyCIDR", hostOnlyCIDR, cc.HostOnlyCIDR)
+	add("HypervVirtualSwitch", hypervVirtualSwitch, cc.HypervVirtualSwitch)
+	add("HypervUseExternalSwitch", hypervUseExternalSwitch, strconv.FormatBool(cc.HypervUseExternalSwitch))
+	add("HypervExternalAdapter", hypervExternalAdapter, cc.HypervExternalAdapter)
+	add("KVMNetwork", kvmNetwork, cc.KVMNetwork)
+	add("KVMQemuURI", kvmQemuURI, cc.KVMQemuURI)
+	add("KVMGPU", kvmGPU, strconv.FormatBool(cc.KVMGPU))
+	add("KVMHidden", kvmHidden, strconv.FormatBool(cc.KVMHidden))
+	add("DisableDriverMounts", disableDriverMounts, strconv.FormatBool(cc.DisableDriverMounts))
+	add("NFSShare", nfsShare, join(cc.NFSShare))
+	add("NFSSharesRoot", nfsSharesRoot, cc.NFSSharesRoot)
+	add("UUID", uuid, cc.UUID)
+	add("NoVTXCheck", noVTXCheck, strconv.FormatBool(cc.NoVTXCheck))
+	add("DNSProxy", dnsProxy, strconv.FormatBool(cc.DNSProxy))
+	add("HostDNSResolver", hostDNSResolver, strconv.FormatBool(cc.HostDNSResolver))
+	add("HostOnlyNicType", hostOnlyNicType, cc.HostOnlyNicType)
+	add("NatNicType", natNicType, cc.NatNicType)
+	add("SSHIPAddress", sshIPAddress, cc.SSHIPAddress)
+	add("SSHUser", sshSSHUser, cc.SSHUser)
+	add("SSHKey", sshSSHKey, cc.SSHKey)
+	add("SSHPort", sshSSHPort, strconv.Itoa(cc.SSHPort))
+	add("StartHostTimeout", waitTimeout, cc.StartHostTimeout.String())
+	add("ExposedPorts", ports, join(cc.ExposedPorts))
+	add("Network", network, cc.Network)
+	add("AutoPauseInterval", autoPauseInterval, cc.AutoPauseInterval.String())
+	add("AutoPauseNamespaces", autoPauseNamespaces, join(cc.AutoPauseNamespaces))
+
+	if len(cc.ContainerVolumeMounts) > 0 {
+		add("ContainerVolumeMounts", createMount, "true")
+		add("ContainerVolumeMounts", mountString, cc.ContainerVolumeMounts[0])
+	}
+	if len(cc.Nodes) > 0 {
+		add("Nodes", nodes, strconv.Itoa(len(cc.Nodes)))
+	}
+	if len(config.ControlPlanes(cc)) > 1 {
+		add("Nodes", highAvailability, "true")
+	}
+	add("Addons", "addons", join(enabledKeys(cc.Addons)))
+	if s.IsSet("VerifyComponents") {
+		wait := enabledKeys(cc.VerifyComponents)
+		if len(wait) == 0 {
+			wait = []string{"none"}
+		}
+		add("VerifyComponents", waitComponents, join(wait))
+	}
+
+	add("KubernetesConfig.KubernetesVersion", kubernetesVersion, k.KubernetesVersion)
+	add("KubernetesConfig.Namespace", startNamespace, k.Namespace)
+	add("KubernetesConfig.APIServerName", apiServerName, k.APIServerName)
+	add("KubernetesConfig.APIServerNames", "apiserver-names", join(k.APIServerNames))
+	ips := []string{}
+	for _, ip := range k.APIServerIPs {
+		ips = append(ips, ip.String())
+	}
+	add("KubernetesConfig.APIServerIPs", "apiserver-ips", join(ips))
+	add("KubernetesConfig.DNSDomain", dnsDomain, k.DNSDomain)
+	add("KubernetesConfig.ContainerRuntime", containerRuntime, k.ContainerRuntime)
+	add("KubernetesConfig.CRISocket", criSocket, k.CRISocket)
+	add("KubernetesConfig.NetworkPlugin", networkPlugin, k.NetworkPlugin)
+	add("KubernetesConfig.FeatureGates", featureGates, k.FeatureGates)
+	add("KubernetesConfig.ServiceCIDR", serviceCIDR, k.ServiceCIDR)
+	add("KubernetesConfig.ImageRepository", imageRepository, k.ImageRepository)
+	add("KubernetesConfig.ShouldLoadCachedImages", cacheImages, strconv.FormatBool(k.ShouldLoadCachedImages))
+	add("KubernetesConfig.CNI", cniFlag, k.CNI)
+	add("KubernetesConfig.CNIMTU", cniMTU, strconv.Itoa(k.CNIMTU))
+	add("KubernetesConfig.NodePort", apiServerPort, strconv.Itoa(k.NodePort))
+	if s.IsSet("KubernetesConfig.KubeadmPatches") && len(k.KubeadmPatches) > 0 {
+		path, err := writeSpecKubeadmPatches(k.KubeadmPatches)
+		if err != nil {
+			return nil, errors.Wrap(err, "kubeadmPatches")
+		}
+		add("KubernetesConfig.KubeadmPatches", kubeadmPatch, path)
+	}
+	add("KubernetesConfig.AuditPolicy", auditPolicy, k.AuditPolicy)
+	add("KubernetesConfig.AuditLogMaxAge", auditLogMaxAge, strconv.Itoa(k.AuditLogMaxAge))
+	add("KubernetesConfig.AuditLogMaxBackup", auditLogMaxBackup, strconv.Itoa(k.AuditLogMaxBackup))
+	add("KubernetesConfig.AuditLogMaxSize", auditLogMaxSize, strconv.Itoa(k.AuditLogMaxSize))
+	add("KubernetesConfig.OIDCIssuerURL", oidcIssuerURL, k.OIDCIssuerURL)
+	add("KubernetesConfig.OIDCClientID", oidcClientID, k.OIDCClientID)
+	add("KubernetesConfig.OIDCCAFile", oidcCAFile, k.OIDCCAFile)
+	add("KubernetesConfig.OIDCUsernameClaim", oidcUsernameClaim, k.OIDCUsernameClaim)
+	add("KubernetesConfig.OIDCGroupsClaim", oidcGroupsClaim, k.OIDCGroupsClaim)
+	opts := []string{}
+	for _, eo := range k.ExtraOptions {
+		opts = append(opts, eo.String())
+	}
+	add("KubernetesConfig.ExtraOptions", "extra-config", opts...)
+
+	return flags, nil
+}
+
+// checkSpecNodes checks that the nodes of a cluster spec are the ones --nodes and --ha create, as the settings
+// of individual nodes can not be passed to start. The IP of each node is assigned on start, so it is not checked.
+func checkSpecNodes(s *config.ClusterSpec) error {
+	cc := s.Spec
+	ha := len(config.ControlPlanes(cc)) > 1
+	port := cc.KubernetesConfig.NodePort
+	if port == 0 {
+		port = constants.APIServerPort
+	}
+	for i, n := range cc.Nodes {
+		set := func(field string) bool {
+			return s.IsSet(fmt.Sprintf("Nodes.%d.%s", i, field))
+		}
+
+		name := node.Name(i + 1)
+		if set("Name") && n.Name != name && (i > 0 || n.Name != "") {
+			return fmt.Errorf("node %d is named %q, but would be created as %q", i+1, n.Name, name)
+		}
+		controlPlane := i == 0 || (ha && i < haControlPlanes)
+		if set("ControlPlane") && n.ControlPlane != controlPlane {
+			return fmt.Errorf("node %d can not be set to controlPlane=%t: the first node, or the first %d nodes with more than one control plane, are the control planes", i+1, n.ControlPlane, haControlPlanes)
+		}
+		if set("Worker") && !n.Worker {
+			return fmt.Errorf("node %d can not be set to worker=false: every node is created as a worker", i+1)
+		}
+		if set("KubernetesVersion") && n.KubernetesVersion != "" && n.KubernetesVersion != cc.KubernetesConfig.KubernetesVersion {
+			return fmt.Errorf("node %d can not be set to kubernetesVersion %s: every node is created with the kubernetesVersion of the cluster", i+1, n.KubernetesVersion)
+		}
+		if set("Port") && n.Port != 0 && (!controlPlane || n.Port != port) {
+			return fmt.Errorf("node %d can not be set to port %d: control planes are created with the nodePort of the cluster", i+1, n.Port)
+		}
+		if n.SSHIPAddress != "" || n.SSHUser != "" || n.SSHKey != "" || n.SSHPort != 0 {
+			return fmt.Errorf(`node %d can not have ssh settings: add the machines of the ssh driver with "minikube node add" once the cluster is running`, i+1)
+		}
+	}
+	return nil
+}
+
+// enabledKeys returns the sorted keys of m which are set to true
+func enabledKeys(m map[string]bool) []string {
+	keys := []string{}
+	for k, enabled := range m {
+		if enabled {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// writeSpecKubeadmPatches writes the kubeadm patches of a cluster spec to a temporary file for --kubeadm-patch,
+// which is read once on start, as the patches are saved in the profile
+func writeSpecKubeadmPatches(patches []config.KubeadmPatch) (string, error) {
+	data, err := bsutil.MarshalKubeadmPatches(patches)
+	if err != nil {
+		return "", err
+	}
+	f, err := ioutil.TempFile("", "kubeadm-patches-*.yaml")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
@@ -17,13 +17,17 @@ limitations under the License.
 package cmd
 
 import (
+	"io/ioutil"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kverify"
 	cfg "k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/driver"
@@ -310,3 +314,144 @@ func TestBaseImageFlagDriverCombo(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterSpecFlags(t *testing.T) {
+	s, err := cfg.ParseClusterSpec([]byte(`apiVersion: minikube.sigs.k8s.io/v1alpha1
+kind: ClusterConfig
+spec:
+  memory: 4096
+  nodes: [{controlPlane: true}, {}, {}]
+  addons: {metrics-server: true, ingress: true, dashboard: false}
+  kubernetesConfig:
+    cni: calico
+    extraOptions:
+    - {component: kubelet, key: max-pods, value: "100"}
+    - {component: apiserver, key: v, value: "5"}
+    auditPolicy: /etc/minikube/audit-policy.yaml
+    auditLogMaxAge: 7
+    auditLogMaxSize: 100
+    oidcIssuerURL: https://dex.example.com
+    oidcClientID: minikube
+    oidcCAFile: /etc/minikube/dex-ca.pem
+    oidcUsernameClaim: email
+    oidcGroupsClaim: groups
+    kubeadmPatches:
+    - {target: ClusterConfiguration, type: strategic, patch: "etcd:\n  local:\n    dataDir: /data/etcd\n"}
+    - {target: KubeletConfiguration, type: json, patch: "- op: add\n  path: /maxPods\n  value: 50\n"}
+`))
+	if err != nil {
+		t.Fatalf("ParseClusterSpec: %v", err)
+	}
+
+	flags, err := clusterSpecFlags(s)
+	if err != nil {
+		t.Fatalf("clusterSpecFlags: %v", err)
+	}
+	got := map[string][]string{}
+	for _, f := range flags {
+		got[f.name] = f.values
+	}
+
+	// the patches are passed through a file, which has to parse back to the same patches
+	if len(got[kubeadmPatch]) != 1 {
+		t.Fatalf("expected one --%s, got %v", kubeadmPatch, got[kubeadmPatch])
+	}
+	defer os.Remove(got[kubeadmPatch][0])
+	data, err := ioutil.ReadFile(got[kubeadmPatch][0])
+	if err != nil {
+		t.Fatalf("reading kubeadm patches: %v", err)
+	}
+	patches, err := bsutil.ParseKubeadmPatches(data)
+	if err != nil {
+		t.Fatalf("ParseKubeadmPatches: %v\n%s", err, data)
+	}
+	if diff := cmp.Diff(s.Spec.KubernetesConfig.KubeadmPatches, patches); diff != "" {
+		t.Errorf("kubeadm patches mismatch (-want +got):\n%s", diff)
+	}
+	delete(got, kubeadmPatch)
+
+	want := map[string][]string{
+		memory:            {"4096mb"},
+		nodes:             {"3"},
+		"addons":          {"ingress,metrics-server"},
+		cniFlag:           {"calico"},
+		"extra-config":    {"kubelet.max-pods=100", "apiserver.v=5"},
+		auditPolicy:       {"/etc/minikube/audit-policy.yaml"},
+		auditLogMaxAge:    {"7"},
+		auditLogMaxSize:   {"100"},
+		oidcIssuerURL:     {"https://dex.example.com"},
+		oidcClientID:      {"minikube"},
+		oidcCAFile:        {"/etc/minikube/dex-ca.pem"},
+		oidcUsernameClaim: {"email"},
+		oidcGroupsClaim:   {"groups"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("clusterSpecFlags() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestClusterSpecFlagsUnsupported(t *testing.T) {
+	tests := []struct {
+		description string
+		spec        string
+		valid       bool
+	}{
+		{"exported ha nodes", `{nodes: [{name: "", controlPlane: true, worker: true, port: 8443, ip: 192.168.49.2}, {name: m02, controlPlane: true, worker: true, port: 8443}, {name: m03, controlPlane: true, worker: true, port: 8443}, {name: m04, worker: true}]}`, true},
+		{"single mount", `{containerVolumeMounts: ["/tmp:/data"]}`, true},
+		{"second mount", `{containerVolumeMounts: ["/tmp:/data", "/srv:/srv"]}`, false},
+		{"persistent mount", `{mounts: [{hostPath: /tmp, guestPath: /data}]}`, false},
+		{"renamed node", `{nodes: [{}, {name: worker}]}`, false},
+		{"second control plane out of order", `{nodes: [{controlPlane: true}, {controlPlane: false}, {controlPlane: true}]}`, false},
+		{"control plane only", `{nodes: [{controlPlane: true, worker: false}]}`, false},
+		{"node version", `{kubernetesConfig: {kubernetesVersion: v1.20.2}, nodes: [{kubernetesVersion: v1.20.2}, {kubernetesVersion: v1.19.0}]}`, false},
+		{"worker port", `{nodes: [{}, {port: 8443}]}`, false},
+		{"ssh node", `{nodes: [{}, {sshIPAddress: 192.168.0.2}]}`, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			s, err := cfg.ParseClusterSpec([]byte("apiVersion: minikube.sigs.k8s.io/v1alpha1\nkind: ClusterConfig\nspec: " + tc.spec))
+			if err != nil {
+				t.Fatalf("ParseClusterSpec: %v", err)
+			}
+			if _, err := clusterSpecFlags(s); (err == nil) != tc.valid {
+				t.Errorf("clusterSpecFlags() = %v, want valid=%t", err, tc.valid)
+			}
+		})
+	}
+}
+
+func TestHAVirtualIP(t *testing.T) {
+	vip, err := haVirtualIP(driver.Docker, "192.168.239.2")
+	if err != nil {
+		t.Fatalf("haVirtualIP: %v", err)
+	}
+	if vip != "192.168.239.254" {
+		t.Errorf("haVirtualIP = %s, want 192.168.239.254", vip)
+	}
+	if _, err := haVirtualIP(driver.VirtualBox, "192.168.239.2"); err == nil {
+		t.Errorf("expected an error for a driver whose network does not reserve the virtual IP")
+	}
+}
+
+func TestWithCNIReady(t *testing.T) {
+	for _, name := range []string{"", "auto", "false"} {
+		if cniChosen(name) {
+			t.Errorf("cniChosen(%q) = true, want false", name)
+		}
+	}
+	for _, name := range []string{"bridge", "antrea@0.13", "/tmp/cni.yaml"} {
+		if !cniChosen(name) {
+			t.Errorf("cniChosen(%q) = false, want true", name)
+		}
+	}
+
+	wcs := map[string]bool{kverify.APIServerWaitKey: true, kverify.CNIReadyKey: false}
+	got := withCNIReady(wcs)
+	want := map[string]bool{kverify.APIServerWaitKey: true, kverify.CNIReadyKey: true}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("withCNIReady() mismatch (-want +got):\n%s", diff)
+	}
+	if wcs[kverify.CNIReadyKey] {
+		t.Errorf("withCNIReady() modified its argument")
+	}
+}
@@ -136,6 +136,7 @@ type Status struct {
 	Kubeconfig string
 	Worker     bool
 	TimeToStop string
+	AutoPause  string `json:",omitempty"`
 }
 
 // ClusterState holds a cluster state representation
@@ -183,6 +184,9 @@ kubelet: {{.Kubelet}}
 apiserver: {{.APIServer}}
 kubeconfig: {{.Kubeconfig}}
 timeToStop: {{.TimeToStop}}
+{{- if .AutoPause}}
+autoPause: {{.AutoPause}}
+{{- end}}
 
 `
 	workerStatusFormat = `{{.Name}}
@@ -398,6 +402,14 @@ func nodeStatus(api libmachine.API, cc config.ClusterConfig, n config.Node) (*St
 		}
 	}
 
+	// the kubeconfig of a multi-control-plane cluster points at the virtual IP, so check this node's API server directly
+	if config.IsHA(cc) && !cc.Addons["auto-pause"] {
+		hostname, _, port, err = driver.NodeEndpoint(&cc, &n, host.DriverName)
+		if err != nil {
+			klog.Errorf("node endpoint: %v", err)
+		}
+	}
+
 	sta, err := kverify.APIServerStatus(cr, hostname, port)
 	klog.Infof("%s apiserver status = %s (err=%v)", name, stk, err)
 
@@ -408,6 +420,23 @@ func nodeStatus(api libmachine.API, cc config.ClusterConfig, n config.Node) (*St
 		st.APIServer = sta.String()
 	}
 
+	if cc.Addons["auto-pause"] && config.IsPrimaryControlPlane(cc, n) {
+		aps, err := cluster.GetAutoPauseStatus(cr)
+		switch {
+		case errors.Is(err, cluster.ErrAutoPauseStatusUnsupported):
+			// older auto-pause services pause the API server, so report its state instead
+			klog.Infof("auto-pause status: %v", err)
+			st.AutoPause = st.APIServer
+		case err != nil:
+			klog.Errorf("auto-pause status: %v", err)
+			st.AutoPause = state.Error.String()
+		case aps.Paused:
+			st.AutoPause = state.Paused.String()
+		default:
+			st.AutoPause = state.Running.String()
+		}
+	}
+
 	return st, nil
 }
 
@@ -144,6 +144,7 @@ func stopProfile(profile string) int {
 	if err := killMountProcess(); err != nil {
 		out.WarningT("Unable to kill mount process: {{.error}}", out.V{"error": err})
 	}
+	killMountProcesses(cc)
 
 	if !keepActive {
 		if err := kubeconfig.DeleteContext(profile, kubeconfig.PathFromEnv()); err != nil {
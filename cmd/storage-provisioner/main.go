@@ -27,6 +27,9 @@ import (
 
 var pvDir = "/tmp/hostpath-provisioner"
 
+// nodeName is set by the DaemonSet running one provisioner per node
+var nodeName = os.Getenv("NODE_NAME")
+
 func main() {
 	// Glog requires that /tmp exists.
 	if err := os.MkdirAll("/tmp", 0755); err != nil {
@@ -35,7 +38,7 @@ func main() {
 	}
 	flag.Parse()
 
-	if err := storage.StartStorageProvisioner(pvDir); err != nil {
+	if err := storage.StartStorageProvisioner(pvDir, nodeName); err != nil {
 		klog.Exit(err)
 	}
 
@@ -22,6 +22,18 @@ define BUILDKIT_BIN_INSTALL_TARGET_CMDS
 	$(INSTALL) -D -m 0755 \
 		$(@D)/buildkitd \
 		$(TARGET_DIR)/usr/sbin
+	$(INSTALL) -Dm644 \
+		$(BUILDKIT_BIN_PKGDIR)/buildkitd.toml \
+		$(TARGET_DIR)/etc/buildkit/buildkitd.toml
+endef
+
+define BUILDKIT_BIN_INSTALL_INIT_SYSTEMD
+	$(INSTALL) -Dm644 \
+		$(BUILDKIT_BIN_PKGDIR)/buildkit.service \
+		$(TARGET_DIR)/usr/lib/systemd/system/buildkit.service
+	$(INSTALL) -Dm644 \
+		$(BUILDKIT_BIN_PKGDIR)/buildkit.socket \
+		$(TARGET_DIR)/usr/lib/systemd/system/buildkit.socket
 endef
 
 $(eval $(generic-package))
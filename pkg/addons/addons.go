@@ -29,10 +29,12 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 
+	storage "k8s.io/api/storage/v1"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/drivers/kic/oci"
 	"k8s.io/minikube/pkg/kapi"
 	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
@@ -215,8 +217,18 @@ https://github.com/kubernetes/minikube/issues/7332`, out.V{"driver_name": cc.Dri
 		}
 	}
 
-	data := assets.GenerateTemplateData(addon, cc.KubernetesConfig)
-	return enableOrDisableAddonInternal(cc, addon, runner, data, enable)
+	data := assets.GenerateTemplateData(addon, *cc)
+	if err := enableOrDisableAddonInternal(cc, addon, runner, data, enable); err != nil {
+		return err
+	}
+
+	if name == "storage-provisioner" && enable {
+		// older versions ran a single provisioner Pod, which would keep provisioning next to the DaemonSet
+		if _, err := runner.RunCmd(kubectlDeletePodCommand(cc, "kube-system", "storage-provisioner")); err != nil {
+			return errors.Wrap(err, "deleting legacy storage-provisioner pod")
+		}
+	}
+	return nil
 }
 
 func isAddonAlreadySet(cc *config.ClusterConfig, addon *assets.Addon, enable bool) bool {
@@ -312,6 +324,17 @@ func enableOrDisableStorageClasses(cc *config.ClusterConfig, name string, val st
 		return errors.Wrapf(err, "Error getting storagev1 interface %v ", err)
 	}
 
+	if enable && class == defaultStorageClassProvisioner {
+		// the standard class binds volumes once a pod is scheduled, which older versions did not
+		deleted, err := storageclass.DeleteIfBindingModeChanged(storagev1, class, storage.VolumeBindingWaitForFirstConsumer)
+		if err != nil {
+			return errors.Wrapf(err, "Error updating the binding mode of storage class %s", class)
+		}
+		if deleted {
+			klog.Infof("deleted storage class %s to change its volume binding mode", class)
+		}
+	}
+
 	if enable {
 		// Only StorageClass for 'name' should be marked as default
 		err = storageclass.SetDefaultStorageClass(storagev1, class)
@@ -445,6 +468,36 @@ func Start(wg *sync.WaitGroup, cc *config.ClusterConfig, toEnable map[string]boo
 	}
 }
 
+// reportAutoPauseStatus shows the settings and state reported by the auto-pause service
+func reportAutoPauseStatus(r command.Runner) {
+	var st *cluster.AutoPauseStatus
+	get := func() (err error) {
+		st, err = cluster.GetAutoPauseStatus(r)
+		if errors.Is(err, cluster.ErrAutoPauseStatusUnsupported) {
+			return nil
+		}
+		return err
+	}
+	if err := retry.Expo(get, 250*time.Millisecond, 5*time.Second); err != nil {
+		klog.Warningf("unable to get auto-pause status: %v", err)
+		return
+	}
+	if st == nil {
+		klog.Infof("auto-pause status: %v", cluster.ErrAutoPauseStatusUnsupported)
+		return
+	}
+
+	ns := "all namespaces"
+	if len(st.Namespaces) > 0 {
+		ns = strings.Join(st.Namespaces, ", ")
+	}
+	state := "running"
+	if st.Paused {
+		state = "paused"
+	}
+	out.Infof("auto-pause will pause {{.namespaces}} after {{.interval}} of inactivity. The cluster is currently {{.state}}.", out.V{"namespaces": ns, "interval": st.Interval, "state": state})
+}
+
 // enableOrDisableAutoPause enables the service after the config was copied by generic enble
 func enableOrDisableAutoPause(cc *config.ClusterConfig, name string, val string) error {
 	enable, err := strconv.ParseBool(val)
@@ -455,13 +508,19 @@ func enableOrDisableAutoPause(cc *config.ClusterConfig, name string, val string)
 	out.Infof("https://github.com/kubernetes/minikube/labels/co%2Fauto-pause")
 
 	if !driver.IsKIC(cc.Driver) || runtime.GOARCH != "amd64" {
-		exit.Message(reason.Usage, `auto-pause currently is only supported on docker driver/docker runtime/amd64. Track progress of others here: https://github.com/kubernetes/minikube/issues/10601`)
+		exit.Message(reason.Usage, `auto-pause currently is only supported on the docker and podman drivers on amd64. Track progress of others here: https://github.com/kubernetes/minikube/issues/10601`)
 	}
 	co := mustload.Running(cc.Name)
 	if enable {
-		if err := sysinit.New(co.CP.Runner).EnableNow("auto-pause"); err != nil {
+		sm := sysinit.New(co.CP.Runner)
+		if err := sm.EnableNow("auto-pause"); err != nil {
 			klog.ErrorS(err, "failed to enable", "service", "auto-pause")
 		}
+		// pick up any change to the auto-pause config
+		if err := sm.Restart("auto-pause"); err != nil {
+			klog.ErrorS(err, "failed to restart", "service", "auto-pause")
+		}
+		reportAutoPauseStatus(co.CP.Runner)
 	}
 
 	port := co.CP.Port // api server port
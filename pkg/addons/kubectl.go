@@ -28,22 +28,32 @@ import (
 )
 
 func kubectlCommand(cc *config.ClusterConfig, files []string, enable bool) *exec.Cmd {
-	v := constants.DefaultKubernetesVersion
-	if cc != nil {
-		v = cc.KubernetesConfig.KubernetesVersion
-	}
-
-	kubectlBinary := kapi.KubectlBinaryPath(v)
-
 	kubectlAction := "apply"
 	if !enable {
 		kubectlAction = "delete"
 	}
 
-	args := []string{fmt.Sprintf("KUBECONFIG=%s", path.Join(vmpath.GuestPersistentDir, "kubeconfig")), kubectlBinary, kubectlAction}
+	args := []string{kubectlAction}
 	for _, f := range files {
 		args = append(args, []string{"-f", f}...)
 	}
 
+	return kubectl(cc, args...)
+}
+
+// kubectlDeletePodCommand deletes a pod, if it exists
+func kubectlDeletePodCommand(cc *config.ClusterConfig, namespace string, name string) *exec.Cmd {
+	return kubectl(cc, "delete", "pod", name, "-n", namespace, "--ignore-not-found")
+}
+
+func kubectl(cc *config.ClusterConfig, args ...string) *exec.Cmd {
+	v := constants.DefaultKubernetesVersion
+	if cc != nil {
+		v = cc.KubernetesConfig.KubernetesVersion
+	}
+
+	kubectlBinary := kapi.KubectlBinaryPath(v)
+
+	args = append([]string{fmt.Sprintf("KUBECONFIG=%s", path.Join(vmpath.GuestPersistentDir, "kubeconfig")), kubectlBinary}, args...)
 	return exec.Command("sudo", args...)
 }
@@ -60,3 +60,16 @@ func TestKubectlCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestKubectlDeletePodCommand(t *testing.T) {
+	cc := &config.ClusterConfig{
+		KubernetesConfig: config.KubernetesConfig{
+			KubernetesVersion: "v1.17.0",
+		},
+	}
+	expected := "sudo KUBECONFIG=/var/lib/minikube/kubeconfig /var/lib/minikube/binaries/v1.17.0/kubectl delete pod storage-provisioner -n kube-system --ignore-not-found"
+	actual := strings.Join(kubectlDeletePodCommand(cc, "kube-system", "storage-provisioner").Args, " ")
+	if actual != expected {
+		t.Fatalf("expected does not match actual\nExpected: %s\nActual: %s", expected, actual)
+	}
+}
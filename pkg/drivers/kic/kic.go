@@ -72,6 +72,16 @@ func NewDriver(c Config) *Driver {
 
 // Create a host using the driver's config
 func (d *Driver) Create() error {
+	return d.create(true)
+}
+
+// CreateWithoutPreload creates the node container like Create, but leaves its volume without the preloaded images,
+// for volumes whose contents are restored separately
+func (d *Driver) CreateWithoutPreload() error {
+	return d.create(false)
+}
+
+func (d *Driver) create(preload bool) error {
 	ctx := context.Background()
 	params := oci.CreateParams{
 		Mounts:        d.NodeConfig.Mounts,
@@ -161,7 +171,7 @@ func (d *Driver) Create() error {
 	go func() {
 		defer waitForPreload.Done()
 		// If preload doesn't exist, don't bother extracting tarball to volume
-		if !download.PreloadExists(d.NodeConfig.KubernetesVersion, d.NodeConfig.ContainerRuntime) {
+		if !preload || !download.PreloadExists(d.NodeConfig.KubernetesVersion, d.NodeConfig.ContainerRuntime) {
 			return
 		}
 		t := time.Now()
@@ -19,6 +19,10 @@ limitations under the License.
 package oci
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
 	"syscall"
 
 	"golang.org/x/sys/unix"
@@ -32,3 +36,18 @@ func IsCgroup2UnifiedMode() (bool, error) {
 	}
 	return st.Type == unix.CGROUP2_SUPER_MAGIC, nil
 }
+
+// delegatedControllers returns the cgroup v2 controllers systemd delegates to the current user.
+// A rootless container runtime can only apply the limits of these controllers.
+func delegatedControllers() (map[string]bool, error) {
+	uid := os.Getuid()
+	b, err := ioutil.ReadFile(fmt.Sprintf("/sys/fs/cgroup/user.slice/user-%d.slice/user@%d.service/cgroup.controllers", uid, uid))
+	if err != nil {
+		return nil, err
+	}
+	controllers := map[string]bool{}
+	for _, c := range strings.Fields(string(b)) {
+		controllers[c] = true
+	}
+	return controllers, nil
+}
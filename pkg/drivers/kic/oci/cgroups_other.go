@@ -28,3 +28,8 @@ import (
 func IsCgroup2UnifiedMode() (bool, error) {
 	return false, errors.Errorf("Not supported on %s", runtime.GOOS)
 }
+
+// delegatedControllers returns the cgroup v2 controllers systemd delegates to the current user.
+func delegatedControllers() (map[string]bool, error) {
+	return nil, errors.Errorf("Not supported on %s", runtime.GOOS)
+}
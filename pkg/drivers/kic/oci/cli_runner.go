@@ -21,14 +21,17 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"k8s.io/klog/v2"
 
+	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/style"
 )
@@ -70,9 +73,26 @@ func (rr RunResult) Output() string {
 	return sb.String()
 }
 
+// IsRootlessPodman returns whether podman runs as the current user rather than through sudo.
+// Rootless podman is opted into with MINIKUBE_ROOTLESS=true, as an unprivileged podman is available
+// next to the root one on most installs, and the containers of existing profiles belong to root.
+func IsRootlessPodman() bool {
+	s := os.Getenv(constants.MinikubeRootlessEnv)
+	if s == "" {
+		return false
+	}
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		klog.Warningf("failed to parse %s=%q: %v", constants.MinikubeRootlessEnv, s, err)
+		return false
+	}
+	return v
+}
+
 // PrefixCmd adds any needed prefix (such as sudo) to the command
 func PrefixCmd(cmd *exec.Cmd) *exec.Cmd {
-	if cmd.Args[0] == Podman && runtime.GOOS == "linux" { // want sudo when not running podman-remote
+	// want sudo when not running podman-remote, or rootless podman
+	if cmd.Args[0] == Podman && runtime.GOOS == "linux" && !IsRootlessPodman() {
 		cmdWithSudo := exec.Command("sudo", append([]string{"-n"}, cmd.Args...)...)
 		cmdWithSudo.Env = cmd.Env
 		cmdWithSudo.Dir = cmd.Dir
@@ -17,11 +17,13 @@ limitations under the License.
 package oci
 
 import (
+	"os"
 	"os/exec"
 	"runtime"
 	"strings"
 	"testing"
 
+	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/tests"
 )
@@ -58,3 +60,26 @@ func TestRunCmdWarnSlowOnce(t *testing.T) {
 		t.Errorf("runCmd does not print the correct log, instead print :%v", f2.String())
 	}
 }
+
+func TestIsRootlessPodman(t *testing.T) {
+	defer os.Setenv(constants.MinikubeRootlessEnv, os.Getenv(constants.MinikubeRootlessEnv))
+
+	tests := []struct {
+		description string
+		env         string
+		want        bool
+	}{
+		{description: "unset", env: "", want: false},
+		{description: "opted in", env: "true", want: true},
+		{description: "disabled", env: "false", want: false},
+		{description: "invalid", env: "maybe", want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			os.Setenv(constants.MinikubeRootlessEnv, tc.env)
+			if got := IsRootlessPodman(); got != tc.want {
+				t.Errorf("IsRootlessPodman() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -34,6 +34,8 @@ type SysInfo struct {
 	Swarm         bool     // Weather or not the docker swarm is active
 	StorageDriver string   // the storage driver for the daemon  (for example overlay2)
 	Errors        []string // any server issues
+	Rootless      bool     // the daemon runs as an unprivileged user, inside a user namespace
+	CgroupVersion string   // the cgroup version of the daemon host, "1" or "2"
 }
 
 var (
@@ -58,14 +60,24 @@ func CachedDaemonInfo(ociBin string) (SysInfo, error) {
 func DaemonInfo(ociBin string) (SysInfo, error) {
 	if ociBin == Podman {
 		p, err := podmanSystemInfo()
-		cachedSysInfo = &SysInfo{CPUs: p.Host.Cpus, TotalMemory: p.Host.MemTotal, OSType: p.Host.Os, Swarm: false, StorageDriver: p.Store.GraphDriverName}
+		cachedSysInfo = &SysInfo{CPUs: p.Host.Cpus, TotalMemory: p.Host.MemTotal, OSType: p.Host.Os, Swarm: false, StorageDriver: p.Store.GraphDriverName, Rootless: p.rootless(), CgroupVersion: strings.TrimPrefix(p.Host.CgroupVersion, "v")}
 		return *cachedSysInfo, err
 	}
 	d, err := dockerSystemInfo()
-	cachedSysInfo = &SysInfo{CPUs: d.NCPU, TotalMemory: d.MemTotal, OSType: d.OSType, Swarm: d.Swarm.LocalNodeState == "active", StorageDriver: d.Driver, Errors: d.ServerErrors}
+	cachedSysInfo = &SysInfo{CPUs: d.NCPU, TotalMemory: d.MemTotal, OSType: d.OSType, Swarm: d.Swarm.LocalNodeState == "active", StorageDriver: d.Driver, Errors: d.ServerErrors, Rootless: dockerRootless(d.SecurityOptions), CgroupVersion: d.CgroupVersion}
 	return *cachedSysInfo, err
 }
 
+// dockerRootless returns whether the docker security options show a rootless daemon, for example "name=rootless"
+func dockerRootless(securityOptions []string) bool {
+	for _, o := range securityOptions {
+		if o == "name=rootless" {
+			return true
+		}
+	}
+	return false
+}
+
 // dockerSysInfo represents the output of docker system info --format '{{json .}}'
 type dockerSysInfo struct {
 	ID                string      `json:"ID"`
@@ -102,6 +114,7 @@ type dockerSysInfo struct {
 	SystemTime         time.Time `json:"SystemTime"`
 	LoggingDriver      string    `json:"LoggingDriver"`
 	CgroupDriver       string    `json:"CgroupDriver"`
+	CgroupVersion      string    `json:"CgroupVersion"`
 	NEventsListener    int       `json:"NEventsListener"`
 	KernelVersion      string    `json:"KernelVersion"`
 	OperatingSystem    string    `json:"OperatingSystem"`
@@ -206,7 +219,10 @@ type podmanSysInfo struct {
 		Kernel      string `json:"kernel"`
 		Os          string `json:"os"`
 		Rootless    bool   `json:"rootless"`
-		Uptime      string `json:"uptime"`
+		Security    struct {
+			Rootless bool `json:"rootless"`
+		} `json:"security"`
+		Uptime string `json:"uptime"`
 	} `json:"host"`
 	Registries struct {
 		Search []string `json:"search"`
@@ -259,6 +275,11 @@ var podmanInfoGetter = func() (string, error) {
 	return rr.Stdout.String(), err
 }
 
+// rootless returns whether podman runs in a user namespace, reported under host.security from podman 3
+func (p podmanSysInfo) rootless() bool {
+	return p.Host.Rootless || p.Host.Security.Rootless
+}
+
 // podmanSysInfo returns podman system info --format '{{json .}}'
 func podmanSystemInfo() (podmanSysInfo, error) {
 	var ps podmanSysInfo
@@ -36,6 +36,8 @@ func TestDockerSystemInfo(t *testing.T) {
 		OS            string
 		Swarm         bool
 		StorageDriver string
+		Rootless      bool
+		CgroupVersion string
 	}{
 		{
 			Name:          "linux_docker",
@@ -138,6 +140,7 @@ func TestDockerSystemInfo(t *testing.T) {
 			OS:            "linux",
 			Swarm:         false,
 			StorageDriver: "overlay",
+			CgroupVersion: "1",
 		},
 		{
 			Name:          "mac_swarm_enabled",
@@ -150,6 +153,28 @@ func TestDockerSystemInfo(t *testing.T) {
 			Swarm:         true,
 			StorageDriver: "overlay2",
 		},
+		{
+			Name:          "linux_docker_rootless",
+			OciBin:        "docker",
+			RawJSON:       `{"ID":"ZQ5B:3HPY:PQ4M:W5NK:ZL4C:ZAVS:KVUB:RQ4E:QCRC:2K3M:BYXR:HDC6","Driver":"overlay2","CgroupDriver":"systemd","CgroupVersion":"2","KernelVersion":"5.11.0-7612-generic","OperatingSystem":"Ubuntu 21.04","OSType":"linux","NCPU":8,"MemTotal":16651620352,"ServerVersion":"20.10.6","Swarm":{"LocalNodeState":"inactive"},"SecurityOptions":["name=seccomp,profile=default","name=rootless","name=cgroupns"],"Warnings":["WARNING: No cpu shares support","WARNING: No cpuset support"]}`,
+			CPUs:          8,
+			Memory:        16651620352,
+			OS:            "linux",
+			StorageDriver: "overlay2",
+			Rootless:      true,
+			CgroupVersion: "2",
+		},
+		{
+			Name:          "linux_podman_rootless",
+			OciBin:        "podman",
+			RawJSON:       `{"host":{"cgroupVersion":"v2","cpus":4,"memTotal":8232542208,"os":"linux","rootless":true},"store":{"graphDriverName":"overlay"}}`,
+			CPUs:          4,
+			Memory:        8232542208,
+			OS:            "linux",
+			StorageDriver: "overlay",
+			Rootless:      true,
+			CgroupVersion: "2",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -178,6 +203,12 @@ func TestDockerSystemInfo(t *testing.T) {
 			if s.Swarm != tc.Swarm {
 				t.Errorf("Expected Swarm to be %t but got %t", tc.Swarm, s.Swarm)
 			}
+			if s.Rootless != tc.Rootless {
+				t.Errorf("Expected Rootless to be %t but got %t", tc.Rootless, s.Rootless)
+			}
+			if s.CgroupVersion != tc.CgroupVersion {
+				t.Errorf("Expected CgroupVersion to be %q but got %q", tc.CgroupVersion, s.CgroupVersion)
+			}
 
 		})
 
@@ -110,6 +110,11 @@ func tryCreateDockerNetwork(ociBin string, subnetAddr string, subnetMask int, mt
 		}
 
 		args = append(args, fmt.Sprintf("--label=%s=%s", CreatedByLabelKey, "true"))
+
+		// keep the reserved address, used as the virtual IP of multi-control-plane clusters, from being allocated to containers
+		if p, err := network.Inspect(fmt.Sprintf("%s/%d", subnetAddr, subnetMask)); err == nil {
+			args = append(args, fmt.Sprintf("--aux-address=reserved=%s", p.Reserved))
+		}
 	}
 	args = append(args, name)
 
@@ -153,6 +153,13 @@ func CreateContainerNode(p CreateParams) error {
 		}
 	}
 
+	// a rootless daemon runs the node inside a user namespace of an unprivileged user
+	si, err := CachedDaemonInfo(p.OCIBinary)
+	if err != nil {
+		klog.Warningf("error getting daemon info, assuming a rootful %s: %v", p.OCIBinary, err)
+	}
+	rootless := si.Rootless
+
 	runArgs := []string{
 		"-d", // run the container detached
 		"-t", // allocate a tty for entrypoint logs
@@ -187,6 +194,22 @@ func CreateContainerNode(p CreateParams) error {
 	memcgSwap := hasMemorySwapCgroup()
 	memcg := HasMemoryCgroup()
 
+	var controllers map[string]bool
+	if rootless {
+		// systemd only delegates some cgroup v2 controllers to users, the rest can not be limited
+		controllers, err = delegatedControllers()
+		if err != nil {
+			klog.Warningf("unable to read the cgroup controllers delegated to the user: %v", err)
+		}
+		if !controllers["memory"] {
+			klog.Warning("The memory cgroup controller is not delegated to the user, --memory will be ignored.")
+		}
+		memcg = controllers["memory"]
+		memcgSwap = controllers["memory"]
+		// give the node its own cgroup namespace, so that systemd in the node owns its subtree
+		runArgs = append(runArgs, "--cgroupns=private")
+	}
+
 	// https://www.freedesktop.org/wiki/Software/systemd/ContainerInterface/
 	var virtualization string
 	if p.OCIBinary == Podman { // enable execing in /var
@@ -234,7 +257,12 @@ func CreateContainerNode(p CreateParams) error {
 		}
 	}
 
-	if cpuCfsPeriod && cpuCfsQuota {
+	cpus := cpuCfsPeriod && cpuCfsQuota
+	if rootless {
+		cpus = controllers["cpu"]
+	}
+
+	if cpus {
 		runArgs = append(runArgs, fmt.Sprintf("--cpus=%s", p.CPUs))
 	}
 
@@ -247,7 +275,7 @@ func CreateContainerNode(p CreateParams) error {
 	// adds node specific args
 	runArgs = append(runArgs, p.ExtraArgs...)
 
-	if enabled := isUsernsRemapEnabled(p.OCIBinary); enabled {
+	if !rootless && isUsernsRemapEnabled(p.OCIBinary) {
 		// We need this argument in order to make this command work
 		// in systems that have userns-remap enabled on the docker daemon
 		runArgs = append(runArgs, "--userns=host")
@@ -354,6 +382,35 @@ func StartContainer(ociBin string, container string) error {
 	return nil
 }
 
+// CommitContainer creates an image named imageName from the filesystem of a container
+func CommitContainer(ociBin string, container string, imageName string) error {
+	if _, err := runCmd(exec.Command(ociBin, "commit", container, imageName)); err != nil {
+		return errors.Wrapf(err, "commit %s", container)
+	}
+	return nil
+}
+
+// ImageID returns the id of an image
+func ImageID(ociBin string, imageName string) (string, error) {
+	rr, err := runCmd(exec.Command(ociBin, "image", "inspect", "--format", "{{.Id}}", imageName))
+	if err != nil {
+		return "", errors.Wrapf(err, "inspect image %s", imageName)
+	}
+	return strings.TrimSpace(rr.Stdout.String()), nil
+}
+
+// RemoveImage removes an image, ignoring images which do not exist
+func RemoveImage(ociBin string, imageName string) error {
+	rr, err := runCmd(exec.Command(ociBin, "image", "rm", imageName))
+	if err != nil {
+		if strings.Contains(strings.ToLower(rr.Output()), "no such image") || strings.Contains(rr.Output(), "image not known") {
+			return nil
+		}
+		return errors.Wrapf(err, "remove image %s", imageName)
+	}
+	return nil
+}
+
 // ContainerID returns id of a container name
 func ContainerID(ociBin string, nameOrID string) (string, error) {
 	rr, err := runCmd(exec.Command(ociBin, "container", "inspect", "-f", "{{.Id}}", nameOrID))
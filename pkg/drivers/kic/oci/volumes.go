@@ -23,6 +23,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os/exec"
+	"path"
+	"path/filepath"
 	"runtime"
 	"strings"
 
@@ -141,6 +143,36 @@ func ExtractTarballToVolume(ociBin string, tarballPath, volumeName, imageName st
 	return nil
 }
 
+// SaveVolumeToTarball runs a docker image imageName which archives the volume named volumeName
+// to an lz4 compressed tarball at tarballPath
+func SaveVolumeToTarball(ociBin string, volumeName, tarballPath, imageName string) error {
+	cmdArgs := []string{"run", "--rm", "--entrypoint", "/usr/bin/tar"}
+	if ociBin == Podman && runtime.GOOS == "linux" {
+		cmdArgs = append(cmdArgs, "--security-opt", "label=disable")
+	}
+	cmdArgs = append(cmdArgs, "-v", fmt.Sprintf("%s:/snapshotDir:ro", volumeName), "-v", fmt.Sprintf("%s:/snapshot", filepath.Dir(tarballPath)), imageName, "-I", "lz4", "-cf", path.Join("/snapshot", filepath.Base(tarballPath)), "-C", "/snapshotDir", ".")
+	cmd := exec.Command(ociBin, cmdArgs...)
+	if _, err := runCmd(cmd); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreVolumeFromTarball runs a docker image imageName which replaces the contents of the volume named volumeName
+// with the tarball at tarballPath, as created by SaveVolumeToTarball
+func RestoreVolumeFromTarball(ociBin string, tarballPath, volumeName, imageName string) error {
+	cmdArgs := []string{"run", "--rm", "--entrypoint", "/bin/bash"}
+	if ociBin == Podman && runtime.GOOS == "linux" {
+		cmdArgs = append(cmdArgs, "--security-opt", "label=disable")
+	}
+	cmdArgs = append(cmdArgs, "-v", fmt.Sprintf("%s:/snapshot.tar:ro", tarballPath), "-v", fmt.Sprintf("%s:/snapshotDir", volumeName), imageName, "-c", "find /snapshotDir -mindepth 1 -delete && tar -I lz4 -xf /snapshot.tar -C /snapshotDir")
+	cmd := exec.Command(ociBin, cmdArgs...)
+	if _, err := runCmd(cmd); err != nil {
+		return err
+	}
+	return nil
+}
+
 // createVolume creates a volume to be attached to the container with correct labels and prefixes based on profile name
 // Caution ! if volume already exists does NOT return an error and will not apply the minikube labels on it.
 // TODO: this should be fixed as a part of https://github.com/kubernetes/minikube/issues/6530
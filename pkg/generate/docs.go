@@ -134,6 +134,9 @@ func writeSubcommands(command *cobra.Command, w io.Writer) error {
 	}
 	subCommands := command.Commands()
 	for _, sc := range subCommands {
+		if sc.Hidden {
+			continue
+		}
 		if err := writeSubcommands(sc, w); err != nil {
 			return err
 		}
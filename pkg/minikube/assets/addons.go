@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 	"k8s.io/minikube/pkg/minikube/config"
@@ -77,6 +78,11 @@ var Addons = map[string]*Addon{
 			vmpath.GuestAddonsDir,
 			"auto-pause.yaml",
 			"0640"),
+		MustBinAsset(
+			"deploy/addons/auto-pause/auto-pause-config.yaml.tmpl",
+			vmpath.GuestAddonsDir,
+			"auto-pause-config.yaml",
+			"0640"),
 		MustBinAsset(
 			"deploy/addons/auto-pause/haproxy.cfg",
 			"/var/lib/minikube/",
@@ -624,7 +630,8 @@ var Addons = map[string]*Addon{
 }
 
 // GenerateTemplateData generates template data for template assets
-func GenerateTemplateData(addon *Addon, cfg config.KubernetesConfig) interface{} {
+func GenerateTemplateData(addon *Addon, cc config.ClusterConfig) interface{} {
+	cfg := cc.KubernetesConfig
 
 	a := runtime.GOARCH
 	// Some legacy docker images still need the -arch suffix
@@ -640,6 +647,9 @@ func GenerateTemplateData(addon *Addon, cfg config.KubernetesConfig) interface{}
 		LoadBalancerStartIP string
 		LoadBalancerEndIP   string
 		CustomIngressCert   string
+		ContainerRuntime    string
+		AutoPauseInterval   time.Duration
+		AutoPauseNamespaces string
 		Images              map[string]string
 		Registries          map[string]string
 		CustomRegistries    map[string]string
@@ -650,10 +660,21 @@ func GenerateTemplateData(addon *Addon, cfg config.KubernetesConfig) interface{}
 		LoadBalancerStartIP: cfg.LoadBalancerStartIP,
 		LoadBalancerEndIP:   cfg.LoadBalancerEndIP,
 		CustomIngressCert:   cfg.CustomIngressCert,
+		ContainerRuntime:    cfg.ContainerRuntime,
+		AutoPauseInterval:   cc.AutoPauseInterval,
+		AutoPauseNamespaces: strings.Join(cc.AutoPauseNamespaces, ","),
 		Images:              addon.Images,
 		Registries:          addon.Registries,
 		CustomRegistries:    make(map[string]string),
 	}
+	// profiles created before the auto-pause settings were configurable
+	if opts.AutoPauseInterval <= 0 {
+		opts.AutoPauseInterval = time.Minute
+	}
+	if cc.AutoPauseNamespaces == nil {
+		opts.AutoPauseNamespaces = "kube-system"
+	}
+
 	if opts.ImageRepository != "" && !strings.HasSuffix(opts.ImageRepository, "/") {
 		opts.ImageRepository += "/"
 	}
@@ -19,7 +19,9 @@ package audit
 import (
 	"os"
 	"os/user"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/viper"
@@ -50,15 +52,35 @@ func args() string {
 	return strings.Join(os.Args[2:], " ")
 }
 
+var (
+	// processStart approximates when the command started, for commands that exit early.
+	processStart = time.Now()
+	// logOnce ensures a command is only logged once, even if it exits while being logged.
+	logOnce sync.Once
+)
+
 // Log details about the executed command.
 func Log(startTime time.Time) {
+	logCommand(startTime, 0)
+}
+
+// LogExit logs details about the executed command before it exits with exitCode.
+func LogExit(exitCode int) {
+	logCommand(processStart, exitCode)
+}
+
+// logCommand logs details about the executed command along with its exit code.
+func logCommand(startTime time.Time, exitCode int) {
 	if len(os.Args) < 2 || !shouldLog() {
 		return
 	}
-	r := newRow(os.Args[1], args(), userName(), version.GetVersion(), startTime, time.Now())
-	if err := appendToLog(r); err != nil {
-		klog.Warning(err)
-	}
+	logOnce.Do(func() {
+		r := newRow(os.Args[1], args(), userName(), version.GetVersion(), startTime, time.Now())
+		r.exitCode = strconv.Itoa(exitCode)
+		if err := appendToLog(r); err != nil {
+			klog.Warning(err)
+		}
+	})
 }
 
 // shouldLog returns if the command should be logged.
@@ -73,7 +95,7 @@ func shouldLog() bool {
 	}
 
 	// commands that should not be logged.
-	no := []string{"status", "version"}
+	no := []string{"audit", "status", "version"}
 	a := os.Args[1]
 	for _, c := range no {
 		if a == c {
@@ -20,6 +20,9 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/spf13/viper"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/out/register"
 )
@@ -30,6 +33,9 @@ var currentLogFile *os.File
 // setLogFile sets the logPath and creates the log file if it doesn't exist.
 func setLogFile() error {
 	lp := localpath.AuditLog()
+	if err := rotateLogFile(lp); err != nil {
+		klog.Warningf("unable to rotate %s: %v", lp, err)
+	}
 	f, err := os.OpenFile(lp, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0644)
 	if err != nil {
 		return fmt.Errorf("unable to open %s: %v", lp, err)
@@ -55,3 +61,29 @@ func appendToLog(row *row) error {
 	}
 	return nil
 }
+
+// rotatedLogPath returns the path the log file at lp is moved to when rotated.
+func rotatedLogPath(lp string) string {
+	return lp + ".1"
+}
+
+// rotateLogFile replaces the previously rotated log with the log file at lp
+// if it has grown beyond the configured maximum size.
+func rotateLogFile(lp string) error {
+	maxSize := viper.GetInt64(config.MaxAuditFileSizeInMB) * 1024 * 1024
+	if maxSize <= 0 {
+		return nil
+	}
+	fi, err := os.Stat(lp)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if fi.Size() < maxSize {
+		return nil
+	}
+	klog.Infof("rotating %s, size %d reached the maximum of %d bytes", lp, fi.Size(), maxSize)
+	return os.Rename(lp, rotatedLogPath(lp))
+}
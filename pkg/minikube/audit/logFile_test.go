@@ -20,8 +20,12 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/spf13/viper"
+	"k8s.io/minikube/pkg/minikube/config"
 )
 
 func TestLogFile(t *testing.T) {
@@ -52,4 +56,37 @@ func TestLogFile(t *testing.T) {
 			t.Errorf("Log was not appended to file: %v", err)
 		}
 	})
+	t.Run("RotateLogFile", func(t *testing.T) {
+		tmpDir, err := ioutil.TempDir("", "audit")
+		if err != nil {
+			t.Fatalf("Error creating temporary directory: %v", err)
+		}
+		defer os.RemoveAll(tmpDir)
+		defer viper.Set(config.MaxAuditFileSizeInMB, viper.Get(config.MaxAuditFileSizeInMB))
+		viper.Set(config.MaxAuditFileSizeInMB, 1)
+
+		lp := filepath.Join(tmpDir, "audit.json")
+		if err := ioutil.WriteFile(lp, make([]byte, 1024), 0644); err != nil {
+			t.Fatalf("Error writing log: %v", err)
+		}
+		if err := rotateLogFile(lp); err != nil {
+			t.Fatalf("Error rotating small log: %v", err)
+		}
+		if _, err := os.Stat(rotatedLogPath(lp)); !os.IsNotExist(err) {
+			t.Errorf("Log smaller than the maximum size was rotated: %v", err)
+		}
+
+		if err := ioutil.WriteFile(lp, make([]byte, 1024*1024), 0644); err != nil {
+			t.Fatalf("Error writing log: %v", err)
+		}
+		if err := rotateLogFile(lp); err != nil {
+			t.Fatalf("Error rotating log: %v", err)
+		}
+		if _, err := os.Stat(lp); !os.IsNotExist(err) {
+			t.Errorf("Log larger than the maximum size was not rotated: %v", err)
+		}
+		if _, err := os.Stat(rotatedLogPath(lp)); err != nil {
+			t.Errorf("Rotated log is missing: %v", err)
+		}
+	})
 }
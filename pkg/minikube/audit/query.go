@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+// Filter selects rows of the audit log, empty fields match every row.
+type Filter struct {
+	Profile string
+	Command string
+	User    string
+	// Since and Until bound the start time of the command.
+	Since time.Time
+	Until time.Time
+	// Status is "success", "failure" or an exit code.
+	Status string
+}
+
+// matches returns if the row is selected by the filter.
+func (f Filter) matches(r row) (bool, error) {
+	if f.Profile != "" && r.profile != f.Profile {
+		return false, nil
+	}
+	if f.Command != "" && r.command != f.Command {
+		return false, nil
+	}
+	if f.User != "" && r.user != f.User {
+		return false, nil
+	}
+	if !f.Since.IsZero() || !f.Until.IsZero() {
+		st, err := time.Parse(constants.TimeFormat, r.startTime)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse start time %q: %v", r.startTime, err)
+		}
+		if !f.Since.IsZero() && st.Before(f.Since) {
+			return false, nil
+		}
+		if !f.Until.IsZero() && st.After(f.Until) {
+			return false, nil
+		}
+	}
+	return f.matchesStatus(r)
+}
+
+// matchesStatus returns if the exit code of the row is selected by the filter.
+func (f Filter) matchesStatus(r row) (bool, error) {
+	if f.Status == "" {
+		return true, nil
+	}
+	// rows written before exit codes were recorded were only logged when the command succeeded
+	code := 0
+	if r.exitCode != "" {
+		c, err := strconv.Atoi(r.exitCode)
+		if err != nil {
+			return false, fmt.Errorf("invalid exit code %q: %v", r.exitCode, err)
+		}
+		code = c
+	}
+	switch strings.ToLower(f.Status) {
+	case "success":
+		return code == 0, nil
+	case "failure":
+		return code != 0, nil
+	}
+	want, err := strconv.Atoi(f.Status)
+	if err != nil {
+		return false, fmt.Errorf("invalid status %q, must be 'success', 'failure' or an exit code", f.Status)
+	}
+	return code == want, nil
+}
+
+// Query creates a report of the rows from the current and rotated log files matching the filter.
+func Query(f Filter) (*RawReport, error) {
+	lp := localpath.AuditLog()
+	var logs []string
+	for _, p := range []string{rotatedLogPath(lp), lp} {
+		l, err := readLogs(p)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, l...)
+	}
+	rows, err := logsToRows(logs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert logs to rows: %v", err)
+	}
+	matched := []row{}
+	for _, r := range rows {
+		ok, err := f.matches(r)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, r)
+		}
+	}
+	return &RawReport{headers, matched}, nil
+}
+
+// readLogs returns the lines of the log file at path, or none if it does not exist.
+func readLogs(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to open %s: %v", path, err)
+	}
+	defer f.Close()
+	var logs []string
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if strings.TrimSpace(s.Text()) == "" {
+			continue
+		}
+		logs = append(logs, s.Text())
+	}
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read from %s: %v", path, err)
+	}
+	return logs, nil
+}
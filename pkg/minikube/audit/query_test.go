@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+func TestQuery(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "audit")
+	if err != nil {
+		t.Fatalf("failed creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+	oldHome := os.Getenv(localpath.MinikubeHome)
+	defer os.Setenv(localpath.MinikubeHome, oldHome)
+	if err := os.Setenv(localpath.MinikubeHome, tmpDir); err != nil {
+		t.Fatalf("failed setting %s: %v", localpath.MinikubeHome, err)
+	}
+	lp := localpath.AuditLog()
+	if err := os.MkdirAll(filepath.Dir(lp), 0755); err != nil {
+		t.Fatalf("failed creating logs directory: %v", err)
+	}
+
+	rotated := `{"data":{"args":"-p mini1","command":"start","endTime":"Wed, 03 Feb 2021 15:33:05 MST","profile":"mini1","startTime":"Wed, 03 Feb 2021 15:30:33 MST","user":"user1"},"datacontenttype":"application/json","id":"9b7593cb-fbec-49e5-a3ce-bdc2d0bfb208","source":"https://minikube.sigs.k8s.io/","specversion":"1.0","type":"io.k8s.sigs.minikube.audit"}
+`
+	current := `{"data":{"args":"-p mini1","command":"start","duration":"2m32s","endTime":"Thu, 04 Feb 2021 15:33:05 MST","exitCode":"80","profile":"mini1","startTime":"Thu, 04 Feb 2021 15:30:33 MST","user":"user1"},"datacontenttype":"application/json","id":"fec03227-2484-48b6-880a-88fd010b5efd","source":"https://minikube.sigs.k8s.io/","specversion":"1.0","type":"io.k8s.sigs.minikube.audit"}
+{"data":{"args":"--user user2","command":"logs","duration":"20s","endTime":"Fri, 05 Feb 2021 16:46:20 MST","exitCode":"0","profile":"minikube","startTime":"Fri, 05 Feb 2021 16:46:00 MST","user":"user2"},"datacontenttype":"application/json","id":"ae5f2fb8-4c02-4a8b-8c8c-bf0bdc7d8e0f","source":"https://minikube.sigs.k8s.io/","specversion":"1.0","type":"io.k8s.sigs.minikube.audit"}
+`
+	if err := ioutil.WriteFile(rotatedLogPath(lp), []byte(rotated), 0644); err != nil {
+		t.Fatalf("failed writing rotated log: %v", err)
+	}
+	if err := ioutil.WriteFile(lp, []byte(current), 0644); err != nil {
+		t.Fatalf("failed writing log: %v", err)
+	}
+
+	mst := time.FixedZone("MST", -7*60*60)
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"all", Filter{}, []string{"start", "start", "logs"}},
+		{"profile", Filter{Profile: "mini1"}, []string{"start", "start"}},
+		{"command", Filter{Command: "logs"}, []string{"logs"}},
+		{"user", Filter{User: "user1"}, []string{"start", "start"}},
+		{"since", Filter{Since: time.Date(2021, 2, 4, 0, 0, 0, 0, mst)}, []string{"start", "logs"}},
+		{"until", Filter{Until: time.Date(2021, 2, 4, 0, 0, 0, 0, mst)}, []string{"start"}},
+		{"success", Filter{Status: "success"}, []string{"start", "logs"}},
+		{"failure", Filter{Status: "failure"}, []string{"start"}},
+		{"exit code", Filter{Status: "80"}, []string{"start"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := Query(tc.filter)
+			if err != nil {
+				t.Fatalf("Query() failed: %v", err)
+			}
+			got := []string{}
+			for _, row := range r.rows {
+				got = append(got, row.command)
+			}
+			if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+				t.Errorf("Query(%+v) = %v; want %v", tc.filter, got, tc.want)
+			}
+		})
+	}
+
+	t.Run("invalid status", func(t *testing.T) {
+		if _, err := Query(Filter{Status: "crashed"}); err == nil {
+			t.Error("Query() with an invalid status did not fail")
+		}
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		r, err := Query(Filter{Status: "failure"})
+		if err != nil {
+			t.Fatalf("Query() failed: %v", err)
+		}
+		got, err := r.CSV()
+		if err != nil {
+			t.Fatalf("CSV() failed: %v", err)
+		}
+		want := `Command,Args,Profile,User,Version,Start Time,End Time,Duration,Exit Code
+start,-p mini1,mini1,user1,,"Thu, 04 Feb 2021 15:30:33 MST","Thu, 04 Feb 2021 15:33:05 MST",2m32s,80
+`
+		if got != want {
+			t.Errorf("CSV() = %q; want %q", got, want)
+		}
+	})
+}
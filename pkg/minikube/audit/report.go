@@ -18,9 +18,15 @@ package audit
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 )
 
+// headers are the column names of a report, in the order returned by row.toFields.
+var headers = []string{"Command", "Args", "Profile", "User", "Version", "Start Time", "End Time", "Duration", "Exit Code"}
+
 // RawReport contains the information required to generate formatted reports.
 type RawReport struct {
 	headers []string
@@ -54,13 +60,46 @@ func Report(lastNLines int) (*RawReport, error) {
 		return nil, fmt.Errorf("failed to convert logs to rows: %v", err)
 	}
 	r := &RawReport{
-		[]string{"Command", "Args", "Profile", "User", "Version", "Start Time", "End Time"},
+		headers,
 		rows,
 	}
 	return r, nil
 }
 
+// Len returns the number of rows in the report.
+func (rr *RawReport) Len() int {
+	return len(rr.rows)
+}
+
 // ASCIITable creates a formatted table using the headers and rows from the report.
 func (rr *RawReport) ASCIITable() string {
 	return rowsToASCIITable(rr.rows, rr.headers)
 }
+
+// JSON encodes the rows of the report as a JSON array of objects.
+func (rr *RawReport) JSON() ([]byte, error) {
+	ms := []map[string]string{}
+	for _, r := range rr.rows {
+		ms = append(ms, r.toMap())
+	}
+	return json.Marshal(ms)
+}
+
+// CSV encodes the headers and rows of the report as comma separated values.
+func (rr *RawReport) CSV() (string, error) {
+	b := new(bytes.Buffer)
+	w := csv.NewWriter(b)
+	if err := w.Write(rr.headers); err != nil {
+		return "", err
+	}
+	for _, r := range rr.rows {
+		if err := w.Write(r.toFields()); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
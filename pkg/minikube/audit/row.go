@@ -32,7 +32,9 @@ import (
 type row struct {
 	args      string
 	command   string
+	duration  string
 	endTime   string
+	exitCode  string
 	profile   string
 	startTime string
 	user      string
@@ -50,7 +52,9 @@ func (e *row) Type() string {
 func (e *row) assignFields() {
 	e.args = e.Data["args"]
 	e.command = e.Data["command"]
+	e.duration = e.Data["duration"]
 	e.endTime = e.Data["endTime"]
+	e.exitCode = e.Data["exitCode"]
 	e.profile = e.Data["profile"]
 	e.startTime = e.Data["startTime"]
 	e.user = e.Data["user"]
@@ -63,7 +67,9 @@ func (e *row) toMap() map[string]string {
 	return map[string]string{
 		"args":      e.args,
 		"command":   e.command,
+		"duration":  e.duration,
 		"endTime":   e.endTime,
+		"exitCode":  e.exitCode,
 		"profile":   e.profile,
 		"startTime": e.startTime,
 		"user":      e.user,
@@ -80,6 +86,7 @@ func newRow(command string, args string, user string, version string, startTime
 	return &row{
 		args:      args,
 		command:   command,
+		duration:  endTime.Sub(startTime).Round(time.Millisecond).String(),
 		endTime:   endTime.Format(constants.TimeFormat),
 		profile:   p,
 		startTime: startTime.Format(constants.TimeFormat),
@@ -91,7 +98,7 @@ func newRow(command string, args string, user string, version string, startTime
 // toFields converts a row to an array of fields,
 // to be used when converting to a table.
 func (e *row) toFields() []string {
-	return []string{e.command, e.args, e.profile, e.user, e.version, e.startTime, e.endTime}
+	return []string{e.command, e.args, e.profile, e.user, e.version, e.startTime, e.endTime, e.duration, e.exitCode}
 }
 
 // logsToRows converts audit logs into arrays of rows.
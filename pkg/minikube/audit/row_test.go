@@ -36,8 +36,11 @@ func TestRow(t *testing.T) {
 	stFormatted := st.Format(constants.TimeFormat)
 	et := time.Now()
 	etFormatted := et.Format(constants.TimeFormat)
+	d := et.Sub(st).Round(time.Millisecond).String()
+	e := "0"
 
 	r := newRow(c, a, u, v, st, et, p)
+	r.exitCode = e
 
 	t.Run("NewRow", func(t *testing.T) {
 		tests := []struct {
@@ -52,6 +55,7 @@ func TestRow(t *testing.T) {
 			{"version", r.version, v},
 			{"startTime", r.startTime, stFormatted},
 			{"endTime", r.endTime, etFormatted},
+			{"duration", r.duration, d},
 		}
 
 		for _, tt := range tests {
@@ -84,6 +88,8 @@ func TestRow(t *testing.T) {
 			{"version", v},
 			{"startTime", stFormatted},
 			{"endTime", etFormatted},
+			{"duration", d},
+			{"exitCode", e},
 		}
 
 		for _, tt := range tests {
@@ -97,7 +103,7 @@ func TestRow(t *testing.T) {
 	t.Run("toFields", func(t *testing.T) {
 		got := r.toFields()
 		gotString := strings.Join(got, ",")
-		want := []string{c, a, p, u, v, stFormatted, etFormatted}
+		want := []string{c, a, p, u, v, stFormatted, etFormatted, d, e}
 		wantString := strings.Join(want, ",")
 
 		if gotString != wantString {
@@ -106,7 +112,7 @@ func TestRow(t *testing.T) {
 	})
 
 	t.Run("assignFields", func(t *testing.T) {
-		l := fmt.Sprintf(`{"data":{"args":"%s","command":"%s","endTime":"%s","profile":"%s","startTime":"%s","user":"%s","version":"v0.17.1"},"datacontenttype":"application/json","id":"bc6ec9d4-0d08-4b57-ac3b-db8d67774768","source":"https://minikube.sigs.k8s.io/","specversion":"1.0","type":"io.k8s.sigs.minikube.audit"}`, a, c, etFormatted, p, stFormatted, u)
+		l := fmt.Sprintf(`{"data":{"args":"%s","command":"%s","duration":"%s","endTime":"%s","exitCode":"%s","profile":"%s","startTime":"%s","user":"%s","version":"v0.17.1"},"datacontenttype":"application/json","id":"bc6ec9d4-0d08-4b57-ac3b-db8d67774768","source":"https://minikube.sigs.k8s.io/","specversion":"1.0","type":"io.k8s.sigs.minikube.audit"}`, a, c, d, etFormatted, e, p, stFormatted, u)
 
 		r := &row{}
 		if err := json.Unmarshal([]byte(l), r); err != nil {
@@ -127,6 +133,8 @@ func TestRow(t *testing.T) {
 			{"version", r.version, v},
 			{"startTime", r.startTime, stFormatted},
 			{"endTime", r.endTime, etFormatted},
+			{"duration", r.duration, d},
+			{"exitCode", r.exitCode, e},
 		}
 
 		for _, tt := range tests {
@@ -17,6 +17,7 @@ limitations under the License.
 package bootstrapper
 
 import (
+	"context"
 	"time"
 
 	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
@@ -35,13 +36,14 @@ type LogOptions struct {
 
 // Bootstrapper contains all the methods needed to bootstrap a Kubernetes cluster
 type Bootstrapper interface {
-	StartCluster(config.ClusterConfig) error
+	// StartCluster, WaitForNode and JoinCluster give up once their context is done
+	StartCluster(context.Context, config.ClusterConfig) error
 	UpdateCluster(config.ClusterConfig) error
 	DeleteCluster(config.KubernetesConfig) error
-	WaitForNode(config.ClusterConfig, config.Node, time.Duration) error
-	JoinCluster(config.ClusterConfig, config.Node, string) error
+	WaitForNode(context.Context, config.ClusterConfig, config.Node, time.Duration) error
+	JoinCluster(context.Context, config.ClusterConfig, config.Node, string) error
 	UpdateNode(config.ClusterConfig, config.Node, cruntime.Manager) error
-	GenerateToken(config.ClusterConfig) (string, error)
+	GenerateToken(config.ClusterConfig, config.Node) (string, error)
 	// LogCommands returns a map of log type to a command which will display that log.
 	LogCommands(config.ClusterConfig, LogOptions) map[string]string
 	SetupCerts(config.KubernetesConfig, config.Node) error
@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"strconv"
+
+	"github.com/blang/semver"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+// auditPatch returns the patch of the ClusterConfiguration that enables API server auditing,
+// or nil if no audit policy is set
+func auditPatch(k8s config.KubernetesConfig, version semver.Version) (*config.KubeadmPatch, error) {
+	if k8s.AuditPolicy == "" {
+		return nil, nil
+	}
+
+	args := map[string]string{
+		"audit-policy-file": vmpath.GuestAuditPolicy,
+		"audit-log-path":    vmpath.GuestAuditLog,
+	}
+	for flag, v := range map[string]int{
+		"audit-log-maxage":    k8s.AuditLogMaxAge,
+		"audit-log-maxbackup": k8s.AuditLogMaxBackup,
+		"audit-log-maxsize":   k8s.AuditLogMaxSize,
+	} {
+		if v > 0 {
+			args[flag] = strconv.Itoa(v)
+		}
+	}
+
+	volumes := []map[string]interface{}{
+		{
+			"name":      "audit-policy",
+			"hostPath":  vmpath.GuestAuditPolicyDir,
+			"mountPath": vmpath.GuestAuditPolicyDir,
+			"readOnly":  true,
+		},
+		{
+			"name":      "audit-log",
+			"hostPath":  vmpath.GuestAuditLogDir,
+			"mountPath": vmpath.GuestAuditLogDir,
+			"pathType":  "DirectoryOrCreate",
+		},
+	}
+
+	patch := map[string]interface{}{
+		"apiServer": map[string]interface{}{
+			"extraArgs":    args,
+			"extraVolumes": volumes,
+		},
+	}
+	// v1alpha3 has no apiServer section
+	if version.LT(semver.MustParse("1.14.0-alpha.0")) {
+		for _, v := range volumes {
+			readOnly, _ := v["readOnly"].(bool)
+			v["writable"] = !readOnly
+			delete(v, "readOnly")
+		}
+		patch = map[string]interface{}{
+			"apiServerExtraArgs":    args,
+			"apiServerExtraVolumes": volumes,
+		}
+	}
+
+	b, err := yaml.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return &config.KubeadmPatch{Target: "ClusterConfiguration", Type: PatchTypeStrategic, Patch: string(b)}, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestAuditPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		k8s      config.KubernetesConfig
+		version  string
+		expected string
+	}{
+		{
+			name:    "disabled",
+			k8s:     config.KubernetesConfig{AuditLogMaxAge: 7},
+			version: "1.20.0",
+		},
+		{
+			name:    "policy",
+			k8s:     config.KubernetesConfig{AuditPolicy: "/home/user/policy.yaml", AuditLogMaxAge: 7, AuditLogMaxSize: 100},
+			version: "1.20.0",
+			expected: `apiServer:
+  extraArgs:
+    audit-log-maxage: "7"
+    audit-log-maxsize: "100"
+    audit-log-path: /var/log/kubernetes/audit/audit.log
+    audit-policy-file: /etc/kubernetes/audit/policy.yaml
+  extraVolumes:
+  - hostPath: /etc/kubernetes/audit
+    mountPath: /etc/kubernetes/audit
+    name: audit-policy
+    readOnly: true
+  - hostPath: /var/log/kubernetes/audit
+    mountPath: /var/log/kubernetes/audit
+    name: audit-log
+    pathType: DirectoryOrCreate
+`,
+		},
+		{
+			name:    "v1alpha3",
+			k8s:     config.KubernetesConfig{AuditPolicy: "/home/user/policy.yaml"},
+			version: "1.13.0",
+			expected: `apiServerExtraArgs:
+  audit-log-path: /var/log/kubernetes/audit/audit.log
+  audit-policy-file: /etc/kubernetes/audit/policy.yaml
+apiServerExtraVolumes:
+- hostPath: /etc/kubernetes/audit
+  mountPath: /etc/kubernetes/audit
+  name: audit-policy
+  writable: false
+- hostPath: /var/log/kubernetes/audit
+  mountPath: /var/log/kubernetes/audit
+  name: audit-log
+  pathType: DirectoryOrCreate
+  writable: true
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := auditPatch(tc.k8s, semver.MustParse(tc.version))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expected == "" {
+				if p != nil {
+					t.Fatalf("expected no patch, got %+v", p)
+				}
+				return
+			}
+			if p.Target != "ClusterConfiguration" || p.Type != PatchTypeStrategic {
+				t.Errorf("unexpected patch target %q or type %q", p.Target, p.Type)
+			}
+			if diff := cmp.Diff(tc.expected, p.Patch); diff != "" {
+				t.Errorf("auditPatch() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAuditPatchKeepsVolumes(t *testing.T) {
+	p, err := auditPatch(config.KubernetesConfig{AuditPolicy: "/policy.yaml"}, semver.MustParse("1.20.0"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := applyKubeadmPatches([]byte(patchTestConfig), []config.KubeadmPatch{*p})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	docs := docSeparator.Split(string(got), -1)
+	var cc struct {
+		APIServer struct {
+			ExtraArgs    map[string]string   `json:"extraArgs"`
+			ExtraVolumes []map[string]string `json:"extraVolumes"`
+		} `json:"apiServer"`
+	}
+	if err := yaml.Unmarshal([]byte(docs[1]), &cc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if cc.APIServer.ExtraArgs["enable-admission-plugins"] != "NamespaceLifecycle" {
+		t.Errorf("existing extra args were dropped: %v", cc.APIServer.ExtraArgs)
+	}
+	var names []string
+	for _, v := range cc.APIServer.ExtraVolumes {
+		names = append(names, v["name"])
+	}
+	if diff := cmp.Diff([]string{"certs", "audit-policy", "audit-log"}, names); diff != "" {
+		t.Errorf("extra volumes mismatch (-want +got):\n%s", diff)
+	}
+}
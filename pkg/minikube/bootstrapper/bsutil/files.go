@@ -22,6 +22,7 @@ import (
 	"path"
 
 	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/assets"
 	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/vmpath"
@@ -33,6 +34,8 @@ var KubeadmYamlPath = path.Join(vmpath.GuestEphemeralDir, "kubeadm.yaml")
 const (
 	// KubeletServiceFile is the file for the systemd kubelet.service
 	KubeletServiceFile = "/lib/systemd/system/kubelet.service"
+	// KubeletEtcServiceFile is the file for the systemd kubelet.service on hosts where /lib is read-only, such as Flatcar
+	KubeletEtcServiceFile = "/etc/systemd/system/kubelet.service"
 	// KubeletSystemdConfFile is config for the systemd kubelet.service
 	KubeletSystemdConfFile = "/etc/systemd/system/kubelet.service.d/10-kubeadm.conf"
 	// InitRestartWrapper is ...
@@ -41,6 +44,15 @@ const (
 	KubeletInitPath = "/etc/init.d/kubelet"
 )
 
+// KubeletServicePath returns where the kubelet.service is installed, falling back to /etc if /lib is read-only
+func KubeletServicePath(runner command.Runner) string {
+	if _, err := runner.RunCmd(exec.Command("sudo", "test", "-w", path.Dir(KubeletServiceFile))); err != nil {
+		klog.Infof("%s is not writable, installing the kubelet service as %s", path.Dir(KubeletServiceFile), KubeletEtcServiceFile)
+		return KubeletEtcServiceFile
+	}
+	return KubeletServiceFile
+}
+
 // CopyFiles combines mkdir requests into a single call to reduce load
 func CopyFiles(runner command.Runner, files []assets.CopyableFile) error {
 	dirs := []string{}
@@ -66,7 +66,13 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 		return nil, errors.Wrap(err, "getting cgroup driver")
 	}
 
-	componentOpts, err := createExtraComponentConfig(k8s.ExtraOptions, version, componentFeatureArgs, cp)
+	// the API server certificate of every control plane must also be valid for the HA virtual IP
+	certSANs := []string{"127.0.0.1", "localhost", cp.IP}
+	if config.IsHA(cc) {
+		certSANs = append(certSANs, k8s.APIServerHAVIP)
+	}
+	extraOpts := append(oidcOptions(k8s), k8s.ExtraOptions...)
+	componentOpts, err := createExtraComponentConfig(extraOpts, version, componentFeatureArgs, certSANs)
 	if err != nil {
 		return nil, errors.Wrap(err, "generating extra component config for kubeadm")
 	}
@@ -106,6 +112,7 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 		StaticPodPath       string
 		ControlPlaneAddress string
 		KubeProxyOptions    map[string]string
+		Rootless            bool
 	}{
 		CertDir:           vmpath.GuestKubernetesCertsDir,
 		ServiceCIDR:       constants.DefaultServiceCIDR,
@@ -130,6 +137,7 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 		StaticPodPath:       vmpath.GuestManifestsDir,
 		ControlPlaneAddress: constants.ControlPlaneAlias,
 		KubeProxyOptions:    createKubeProxyOptions(k8s.ExtraOptions),
+		Rootless:            cc.Rootless,
 	}
 
 	if k8s.ServiceCIDR != "" {
@@ -151,8 +159,21 @@ func GenerateKubeadmYAML(cc config.ClusterConfig, n config.Node, r cruntime.Mana
 	if err := configTmpl.Execute(&b, opts); err != nil {
 		return nil, err
 	}
-	klog.Infof("kubeadm config:\n%s\n", b.String())
-	return b.Bytes(), nil
+	patches := k8s.KubeadmPatches
+	ap, err := auditPatch(k8s, version)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating audit config")
+	}
+	if ap != nil {
+		// user patches go last, so that they can override the audit settings
+		patches = append([]config.KubeadmPatch{*ap}, patches...)
+	}
+	cfg, err := applyKubeadmPatches(b.Bytes(), patches)
+	if err != nil {
+		return nil, errors.Wrap(err, "applying kubeadm patches")
+	}
+	klog.Infof("kubeadm config:\n%s\n", cfg)
+	return cfg, nil
 }
 
 // These are the components that can be configured
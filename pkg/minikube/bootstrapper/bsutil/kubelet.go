@@ -21,7 +21,9 @@ import (
 	"bytes"
 	"os"
 	"path"
+	"strings"
 
+	"github.com/blang/semver"
 	"github.com/pkg/errors"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/ktmpl"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
@@ -74,6 +76,14 @@ func extraKubeletOpts(mc config.ClusterConfig, nc config.Node, r cruntime.Manage
 		return nil, errors.Wrap(err, "parses feature gate config for kubelet")
 	}
 
+	// the kubelet of a rootless node runs in a user namespace, where it can not set sysctls or oom scores
+	if mc.Rootless && version.GTE(semver.MustParse("1.22.0-alpha.0")) && !strings.Contains(kubeletFeatureArgs, "KubeletInUserNamespace") {
+		if kubeletFeatureArgs != "" {
+			kubeletFeatureArgs += ","
+		}
+		kubeletFeatureArgs += "KubeletInUserNamespace=true"
+	}
+
 	if kubeletFeatureArgs != "" {
 		extraOpts["feature-gates"] = kubeletFeatureArgs
 	}
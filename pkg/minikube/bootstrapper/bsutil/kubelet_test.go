@@ -203,3 +203,44 @@ ExecStart=/var/lib/minikube/binaries/v1.18.2/kubelet --authorization-mode=Webhoo
 		})
 	}
 }
+
+func TestExtraKubeletOptsRootless(t *testing.T) {
+	tests := []struct {
+		description string
+		version     string
+		rootless    bool
+		gates       string
+		expected    string
+	}{
+		{"rootful", "v1.22.0", false, "", ""},
+		{"rootless", "v1.22.0", true, "", "KubeletInUserNamespace=true"},
+		{"rootless with gates", "v1.22.0", true, "a=b", "a=b,KubeletInUserNamespace=true"},
+		{"rootless with explicit gate", "v1.22.0", true, "KubeletInUserNamespace=false", "KubeletInUserNamespace=false"},
+		{"rootless before the gate", constants.DefaultKubernetesVersion, true, "", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			cc := config.ClusterConfig{
+				Name:     "minikube",
+				Rootless: tc.rootless,
+				KubernetesConfig: config.KubernetesConfig{
+					KubernetesVersion: tc.version,
+					ContainerRuntime:  "docker",
+					FeatureGates:      tc.gates,
+				},
+				Nodes: []config.Node{{IP: "192.168.1.100", Name: "minikube", ControlPlane: true}},
+			}
+			runtime, err := cruntime.New(cruntime.Config{Type: "docker"})
+			if err != nil {
+				t.Fatalf("runtime: %v", err)
+			}
+			opts, err := extraKubeletOpts(cc, cc.Nodes[0], runtime)
+			if err != nil {
+				t.Fatalf("extraKubeletOpts: %v", err)
+			}
+			if got := opts["feature-gates"]; got != tc.expected {
+				t.Errorf("feature-gates = %q, want %q", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"bytes"
+	"path"
+
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/ktmpl"
+	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+// KubeVipManifestPath is where the kube-vip static pod manifest is written on control plane nodes
+var KubeVipManifestPath = path.Join(vmpath.GuestManifestsDir, "kube-vip.yaml")
+
+// GenerateKubeVipManifest generates the kube-vip static pod manifest for a control plane node of a multi-control-plane cluster.
+// iface is the network interface of the node which owns its IP, where the virtual IP will be advertised.
+func GenerateKubeVipManifest(cc config.ClusterConfig, n config.Node, iface string) ([]byte, error) {
+	if !config.IsHA(cc) {
+		return nil, errors.Errorf("cluster %s has no virtual IP", cc.Name)
+	}
+	port := n.Port
+	if port == 0 {
+		port = cc.KubernetesConfig.NodePort
+	}
+
+	opts := struct {
+		Image      string
+		Interface  string
+		Port       int
+		VIP        string
+		KubeConfig string
+		CertsDir   string
+	}{
+		Image:      images.KubeVip(cc.KubernetesConfig.ImageRepository),
+		Interface:  iface,
+		Port:       port,
+		VIP:        cc.KubernetesConfig.APIServerHAVIP,
+		KubeConfig: path.Join(vmpath.GuestPersistentDir, "kubeconfig"),
+		CertsDir:   vmpath.GuestKubernetesCertsDir,
+	}
+
+	var b bytes.Buffer
+	if err := ktmpl.KubeVipTemplate.Execute(&b, opts); err != nil {
+		return nil, errors.Wrap(err, "kube-vip template")
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestGenerateKubeVipManifest(t *testing.T) {
+	cc := config.ClusterConfig{
+		Name: "ha",
+		KubernetesConfig: config.KubernetesConfig{
+			KubernetesVersion: "v1.20.2",
+			NodePort:          8443,
+		},
+		Nodes: []config.Node{
+			{Name: "", IP: "192.168.49.2", Port: 8443, ControlPlane: true, Worker: true},
+			{Name: "m02", IP: "192.168.49.3", ControlPlane: true, Worker: true},
+		},
+	}
+
+	if _, err := GenerateKubeVipManifest(cc, cc.Nodes[0], "eth0"); err == nil {
+		t.Errorf("expected an error for a cluster without a virtual IP")
+	}
+
+	cc.KubernetesConfig.APIServerHAVIP = "192.168.49.254"
+	got, err := GenerateKubeVipManifest(cc, cc.Nodes[1], "eth1")
+	if err != nil {
+		t.Fatalf("GenerateKubeVipManifest: %v", err)
+	}
+	for _, want := range []string{
+		"image: ghcr.io/kube-vip/kube-vip:",
+		"- name: vip_interface\n      value: eth1\n",
+		"- name: port\n      value: \"8443\"\n",
+		"- name: vip_address\n      value: 192.168.49.254\n",
+		"path: /var/lib/minikube/kubeconfig\n",
+	} {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("manifest does not contain %q:\n%s", want, got)
+		}
+	}
+
+	cc.KubernetesConfig.ImageRepository = "registry.local"
+	got, err = GenerateKubeVipManifest(cc, cc.Nodes[0], "eth0")
+	if err != nil {
+		t.Fatalf("GenerateKubeVipManifest: %v", err)
+	}
+	if !strings.Contains(string(got), "image: registry.local/kube-vip:") {
+		t.Errorf("manifest does not use the image repository:\n%s", got)
+	}
+}
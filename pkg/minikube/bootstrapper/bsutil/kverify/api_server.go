@@ -18,6 +18,7 @@ limitations under the License.
 package kverify
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"io/ioutil"
@@ -31,7 +32,6 @@ import (
 
 	"github.com/docker/machine/libmachine/state"
 	"github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
@@ -44,16 +44,16 @@ import (
 )
 
 // WaitForAPIServerProcess waits for api server to be healthy returns error if it doesn't
-func WaitForAPIServerProcess(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr command.Runner, start time.Time, timeout time.Duration) error {
+func WaitForAPIServerProcess(ctx context.Context, r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr command.Runner, start time.Time, timeout time.Duration) error {
 	klog.Infof("waiting for apiserver process to appear ...")
-	err := wait.PollImmediate(time.Millisecond*500, timeout, func() (bool, error) {
+	err := pollImmediate(ctx, time.Millisecond*500, timeout, func() (bool, error) {
 		if time.Since(start) > timeout {
 			return false, fmt.Errorf("cluster wait timed out during process check")
 		}
 
 		if time.Since(start) > minLogCheckTime {
-			announceProblems(r, bs, cfg, cr)
-			time.Sleep(kconst.APICallRetryInterval * 5)
+			announceProblems(ctx, r, bs, cfg, cr)
+			_ = retry.Sleep(ctx, kconst.APICallRetryInterval*5)
 		}
 
 		if _, ierr := APIServerPID(cr); ierr != nil {
@@ -63,6 +63,9 @@ func WaitForAPIServerProcess(r cruntime.Manager, bs bootstrapper.Bootstrapper, c
 		return true, nil
 	})
 	if err != nil {
+		if ctx.Err() != nil {
+			return errors.Wrap(err, "waiting for apiserver process")
+		}
 		return fmt.Errorf("apiserver process never appeared")
 	}
 	klog.Infof("duration metric: took %s to wait for apiserver process to appear ...", time.Since(start))
@@ -80,7 +83,7 @@ func APIServerPID(cr command.Runner) (int, error) {
 }
 
 // WaitForHealthyAPIServer waits for api server status to be running
-func WaitForHealthyAPIServer(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr command.Runner, client *kubernetes.Clientset, start time.Time, hostname string, port int, timeout time.Duration) error {
+func WaitForHealthyAPIServer(ctx context.Context, r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr command.Runner, client *kubernetes.Clientset, start time.Time, hostname string, port int, timeout time.Duration) error {
 	klog.Infof("waiting for apiserver healthz status ...")
 	hStart := time.Now()
 
@@ -90,8 +93,8 @@ func WaitForHealthyAPIServer(r cruntime.Manager, bs bootstrapper.Bootstrapper, c
 		}
 
 		if time.Since(start) > minLogCheckTime {
-			announceProblems(r, bs, cfg, cr)
-			time.Sleep(kconst.APICallRetryInterval * 5)
+			announceProblems(ctx, r, bs, cfg, cr)
+			_ = retry.Sleep(ctx, kconst.APICallRetryInterval*5)
 		}
 
 		status, err := apiServerHealthzNow(hostname, port)
@@ -105,8 +108,8 @@ func WaitForHealthyAPIServer(r cruntime.Manager, bs bootstrapper.Bootstrapper, c
 		return true, nil
 	}
 
-	if err := wait.PollImmediate(kconst.APICallRetryInterval, kconst.DefaultControlPlaneTimeout, healthz); err != nil {
-		return fmt.Errorf("apiserver healthz never reported healthy: %v", err)
+	if err := pollImmediate(ctx, kconst.APICallRetryInterval, kconst.DefaultControlPlaneTimeout, healthz); err != nil {
+		return errors.Wrap(err, "apiserver healthz never reported healthy")
 	}
 
 	vcheck := func() (bool, error) {
@@ -120,7 +123,10 @@ func WaitForHealthyAPIServer(r cruntime.Manager, bs bootstrapper.Bootstrapper, c
 		return true, nil
 	}
 
-	if err := wait.PollImmediate(kconst.APICallRetryInterval, kconst.DefaultControlPlaneTimeout, vcheck); err != nil {
+	if err := pollImmediate(ctx, kconst.APICallRetryInterval, kconst.DefaultControlPlaneTimeout, vcheck); err != nil {
+		if ctx.Err() != nil {
+			return errors.Wrap(err, "waiting for apiserver version")
+		}
 		return fmt.Errorf("controlPlane never updated to %s", cfg.KubernetesConfig.KubernetesVersion)
 	}
 
@@ -0,0 +1,49 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kverify verifies a running Kubernetes cluster is healthy
+package kverify
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/cni"
+	"k8s.io/minikube/pkg/util/retry"
+)
+
+// WaitForCNI waits for the CNI to be running on every node
+func WaitForCNI(ctx context.Context, cs kubernetes.Interface, cnm cni.Manager, timeout time.Duration) error {
+	klog.Infof("waiting %s for %s to be ready ...", timeout, cnm)
+	start := time.Now()
+
+	checkReady := func() error {
+		if err := cnm.Ready(cs); err != nil {
+			klog.Infof("%s is not ready: %v", cnm, err)
+			return err
+		}
+		return nil
+	}
+
+	if err := retry.LocalContext(ctx, checkReady, timeout); err != nil {
+		return errors.Wrapf(err, "%s", cnm)
+	}
+	klog.Infof("duration metric: took %s to wait for %s to be ready ...", time.Since(start), cnm)
+	return nil
+}
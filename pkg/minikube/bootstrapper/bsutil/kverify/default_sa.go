@@ -18,18 +18,18 @@ limitations under the License.
 package kverify
 
 import (
+	"context"
 	"time"
 
 	"github.com/pkg/errors"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	kconst "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 )
 
 // WaitForDefaultSA waits for the default service account to be created.
-func WaitForDefaultSA(cs *kubernetes.Clientset, timeout time.Duration) error {
+func WaitForDefaultSA(ctx context.Context, cs *kubernetes.Clientset, timeout time.Duration) error {
 	klog.Info("waiting for default service account to be created ...")
 	start := time.Now()
 	saReady := func() (bool, error) {
@@ -47,7 +47,7 @@ func WaitForDefaultSA(cs *kubernetes.Clientset, timeout time.Duration) error {
 		}
 		return false, nil
 	}
-	if err := wait.PollImmediate(kconst.APICallRetryInterval, timeout, saReady); err != nil {
+	if err := pollImmediate(ctx, kconst.APICallRetryInterval, timeout, saReady); err != nil {
 		return errors.Wrapf(err, "waited %s for SA", time.Since(start))
 	}
 
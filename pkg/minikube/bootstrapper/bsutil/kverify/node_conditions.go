@@ -18,6 +18,7 @@ limitations under the License.
 package kverify
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -97,7 +98,7 @@ func (e *ErrNetworkNotReady) Error() string {
 }
 
 // NodePressure verfies that node is not under disk, memory, pid or network pressure.
-func NodePressure(cs *kubernetes.Clientset) error {
+func NodePressure(ctx context.Context, cs *kubernetes.Clientset) error {
 	klog.Info("verifying NodePressure condition ...")
 	start := time.Now()
 	defer func() {
@@ -112,7 +113,7 @@ func NodePressure(cs *kubernetes.Clientset) error {
 		return err
 	}
 
-	err = retry.Expo(listNodes, kconst.APICallRetryInterval, 2*time.Minute)
+	err = retry.ExpoContext(ctx, listNodes, kconst.APICallRetryInterval, 2*time.Minute)
 	if err != nil {
 		return errors.Wrap(err, "list nodes retry")
 	}
@@ -18,20 +18,20 @@ limitations under the License.
 package kverify
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	kconst "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 )
 
 // WaitForNodeReady waits till kube client reports node status as "ready"
-func WaitForNodeReady(cs *kubernetes.Clientset, timeout time.Duration) error {
+func WaitForNodeReady(ctx context.Context, cs *kubernetes.Clientset, timeout time.Duration) error {
 	klog.Infof("waiting %s for node status to be ready ...", timeout)
 	start := time.Now()
 	defer func() {
@@ -57,7 +57,7 @@ func WaitForNodeReady(cs *kubernetes.Clientset, timeout time.Duration) error {
 		}
 		return true, nil
 	}
-	if err := wait.PollImmediate(kconst.APICallRetryInterval, kconst.DefaultControlPlaneTimeout, checkReady); err != nil {
+	if err := pollImmediate(ctx, kconst.APICallRetryInterval, kconst.DefaultControlPlaneTimeout, checkReady); err != nil {
 		return errors.Wrapf(err, "wait node ready")
 	}
 	return nil
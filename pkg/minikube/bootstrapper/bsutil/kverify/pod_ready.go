@@ -18,6 +18,7 @@ limitations under the License.
 package kverify
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -25,14 +26,13 @@ import (
 	"github.com/pkg/errors"
 	core "k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	kconst "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 )
 
 // WaitExtra calls WaitForPodReadyByLabel for each pod in labels list and returns any errors occurred.
-func WaitExtra(cs *kubernetes.Clientset, labels []string, timeout time.Duration) error {
+func WaitExtra(ctx context.Context, cs *kubernetes.Clientset, labels []string, timeout time.Duration) error {
 	klog.Infof("extra waiting for kube-system core pods %s to be Ready ...", labels)
 	start := time.Now()
 	defer func() {
@@ -41,7 +41,10 @@ func WaitExtra(cs *kubernetes.Clientset, labels []string, timeout time.Duration)
 
 	var errs []string
 	for _, label := range labels {
-		if err := waitForPodReadyByLabel(cs, label, "kube-system", timeout); err != nil {
+		if err := waitForPodReadyByLabel(ctx, cs, label, "kube-system", timeout); err != nil {
+			if ctx.Err() != nil {
+				return errors.Wrapf(err, "waiting for %q", label)
+			}
 			errs = append(errs, fmt.Sprintf("%q: %q", label, err.Error()))
 		}
 	}
@@ -55,7 +58,7 @@ func WaitExtra(cs *kubernetes.Clientset, labels []string, timeout time.Duration)
 // waitForPodReadyByLabel waits for pod with label ([key:]val) in a namespace to be in Ready condition.
 // If namespace is not provided, it defaults to "kube-system".
 // If label key is not provided, it will try with "component" and "k8s-app".
-func waitForPodReadyByLabel(cs *kubernetes.Clientset, label, namespace string, timeout time.Duration) error {
+func waitForPodReadyByLabel(ctx context.Context, cs *kubernetes.Clientset, label, namespace string, timeout time.Duration) error {
 	klog.Infof("waiting %v for pod with %q label in %q namespace to be Ready ...", timeout, label, namespace)
 	start := time.Now()
 	defer func() {
@@ -109,7 +112,7 @@ func waitForPodReadyByLabel(cs *kubernetes.Clientset, label, namespace string, t
 		klog.Infof("pod with %q label in %q namespace was not found, will retry", label, namespace)
 		return false, nil
 	}
-	if err := wait.PollImmediate(kconst.APICallRetryInterval, kconst.DefaultControlPlaneTimeout, checkReady); err != nil {
+	if err := pollImmediate(ctx, kconst.APICallRetryInterval, kconst.DefaultControlPlaneTimeout, checkReady); err != nil {
 		return errors.Wrapf(err, "wait pod Ready")
 	}
 
@@ -18,6 +18,7 @@ limitations under the License.
 package kverify
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -37,14 +38,14 @@ import (
 )
 
 // WaitForSystemPods verifies essential pods for running kurnetes is running
-func WaitForSystemPods(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr command.Runner, client *kubernetes.Clientset, start time.Time, timeout time.Duration) error {
+func WaitForSystemPods(ctx context.Context, r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr command.Runner, client *kubernetes.Clientset, start time.Time, timeout time.Duration) error {
 	klog.Info("waiting for kube-system pods to appear ...")
 	pStart := time.Now()
 
 	podList := func() error {
 		if time.Since(start) > minLogCheckTime {
-			announceProblems(r, bs, cfg, cr)
-			time.Sleep(kconst.APICallRetryInterval * 5)
+			announceProblems(ctx, r, bs, cfg, cr)
+			_ = retry.Sleep(ctx, kconst.APICallRetryInterval*5)
 		}
 
 		// Wait for any system pod, as waiting for apiserver may block until etcd
@@ -66,7 +67,10 @@ func WaitForSystemPods(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg con
 		return nil
 	}
 
-	if err := retry.Local(podList, timeout); err != nil {
+	if err := retry.LocalContext(ctx, podList, timeout); err != nil {
+		if ctx.Err() != nil {
+			return errors.Wrap(err, "waiting for kube-system pods")
+		}
 		return fmt.Errorf("apiserver never returned a pod list")
 	}
 	klog.Infof("duration metric: took %s to wait for pod list to return data ...", time.Since(pStart))
@@ -110,7 +114,7 @@ func ExpectAppsRunning(cs *kubernetes.Clientset, expected []string) error {
 }
 
 // WaitForAppsRunning waits for expected Apps To be running
-func WaitForAppsRunning(cs *kubernetes.Clientset, expected []string, timeout time.Duration) error {
+func WaitForAppsRunning(ctx context.Context, cs *kubernetes.Clientset, expected []string, timeout time.Duration) error {
 	klog.Info("waiting for k8s-apps to be running ...")
 	start := time.Now()
 
@@ -118,7 +122,7 @@ func WaitForAppsRunning(cs *kubernetes.Clientset, expected []string, timeout tim
 		return ExpectAppsRunning(cs, expected)
 	}
 
-	if err := retry.Local(checkRunning, timeout); err != nil {
+	if err := retry.LocalContext(ctx, checkRunning, timeout); err != nil {
 		return errors.Wrapf(err, "expected k8s-apps")
 	}
 	klog.Infof("duration metric: took %s to wait for k8s-apps to be running ...", time.Since(start))
@@ -146,10 +150,10 @@ func podStatusMsg(pod core.Pod) string {
 }
 
 // announceProblems checks for problems, and slows polling down if any are found
-func announceProblems(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr command.Runner) {
+func announceProblems(ctx context.Context, r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.ClusterConfig, cr command.Runner) {
 	problems := logs.FindProblems(r, bs, cfg, cr)
 	if len(problems) > 0 {
 		logs.OutputProblems(problems, 5)
-		time.Sleep(kconst.APICallRetryInterval * 15)
+		_ = retry.Sleep(ctx, kconst.APICallRetryInterval*15)
 	}
 }
@@ -18,10 +18,12 @@ limitations under the License.
 package kverify
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/sysinit"
@@ -39,7 +41,7 @@ func ServiceStatus(cr command.Runner, svc string) state.State {
 
 // WaitForService will wait for a "systemd" or "init.d" service to be running on the node...
 // not to be confused with Kubernetes Services
-func WaitForService(cr command.Runner, svc string, timeout time.Duration) error {
+func WaitForService(ctx context.Context, cr command.Runner, svc string, timeout time.Duration) error {
 	pStart := time.Now()
 	klog.Infof("waiting for %s service to be running ....", svc)
 	kr := func() error {
@@ -49,7 +51,10 @@ func WaitForService(cr command.Runner, svc string, timeout time.Duration) error
 		return nil
 	}
 
-	if err := retry.Local(kr, timeout); err != nil {
+	if err := retry.LocalContext(ctx, kr, timeout); err != nil {
+		if ctx.Err() != nil {
+			return errors.Wrapf(err, "waiting for %s", svc)
+		}
 		return fmt.Errorf("not running: %s", err)
 	}
 
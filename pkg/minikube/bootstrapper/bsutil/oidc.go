@@ -0,0 +1,48 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+// oidcOptions returns the API server options for OpenID Connect authentication, or none if no issuer is set.
+// They come before the --extra-config options, which can override them.
+func oidcOptions(k8s config.KubernetesConfig) config.ExtraOptionSlice {
+	if k8s.OIDCIssuerURL == "" {
+		return nil
+	}
+
+	args := []struct{ key, value string }{
+		{"oidc-issuer-url", k8s.OIDCIssuerURL},
+		{"oidc-client-id", k8s.OIDCClientID},
+		{"oidc-username-claim", k8s.OIDCUsernameClaim},
+		{"oidc-groups-claim", k8s.OIDCGroupsClaim},
+	}
+	if k8s.OIDCCAFile != "" {
+		args = append(args, struct{ key, value string }{"oidc-ca-file", vmpath.GuestOIDCCACert})
+	}
+
+	opts := config.ExtraOptionSlice{}
+	for _, a := range args {
+		if a.value != "" {
+			opts = append(opts, config.ExtraOption{Component: Apiserver, Key: a.key, Value: a.value})
+		}
+	}
+	return opts
+}
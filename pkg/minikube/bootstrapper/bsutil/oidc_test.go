@@ -0,0 +1,82 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestOIDCOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		k8s      config.KubernetesConfig
+		expected map[string]string
+	}{
+		{
+			name:     "disabled",
+			k8s:      config.KubernetesConfig{OIDCClientID: "kubernetes"},
+			expected: map[string]string{},
+		},
+		{
+			name: "issuer",
+			k8s:  config.KubernetesConfig{OIDCIssuerURL: "https://dex.example.com", OIDCClientID: "kubernetes", OIDCUsernameClaim: "email"},
+			expected: map[string]string{
+				"oidc-issuer-url":     "https://dex.example.com",
+				"oidc-client-id":      "kubernetes",
+				"oidc-username-claim": "email",
+			},
+		},
+		{
+			name: "ca and extra-config override",
+			k8s: config.KubernetesConfig{
+				OIDCIssuerURL: "https://dex.example.com",
+				OIDCClientID:  "kubernetes",
+				OIDCCAFile:    "/home/user/dex-ca.crt",
+				ExtraOptions:  config.ExtraOptionSlice{{Component: Apiserver, Key: "oidc-client-id", Value: "other"}},
+			},
+			expected: map[string]string{
+				"oidc-issuer-url": "https://dex.example.com",
+				"oidc-client-id":  "other",
+				"oidc-ca-file":    "/var/lib/minikube/certs/oidc-ca.crt",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := append(oidcOptions(tc.k8s), tc.k8s.ExtraOptions...)
+			args, err := extraConfigForComponent(Apiserver, opts, semver.MustParse("1.20.0"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := map[string]string{}
+			for k, v := range args {
+				if strings.HasPrefix(k, "oidc-") {
+					got[k] = v
+				}
+			}
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("OIDC options mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
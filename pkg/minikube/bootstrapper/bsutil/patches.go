@@ -0,0 +1,300 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+// Kinds of patches that can be applied to the kubeadm config
+const (
+	// PatchTypeStrategic merges the patch into the document. Lists of objects with a name are merged by name, other lists are replaced.
+	PatchTypeStrategic = "strategic"
+	// PatchTypeJSON applies a list of RFC 6902 operations to the document.
+	PatchTypeJSON = "json"
+)
+
+// KubeadmPatchTargets are the documents of the generated kubeadm config that can be patched
+var KubeadmPatchTargets = []string{
+	"InitConfiguration",
+	"ClusterConfiguration",
+	"KubeletConfiguration",
+	"KubeProxyConfiguration",
+}
+
+var docSeparator = regexp.MustCompile(`(?m)^---[ \t]*\n`)
+
+// ParseKubeadmPatches parses a multi-document patch file.
+// A document with a kind is a strategic merge patch of the document of that kind,
+// a document with a target and a patch is a JSON patch of the target.
+func ParseKubeadmPatches(data []byte) ([]config.KubeadmPatch, error) {
+	var patches []config.KubeadmPatch
+	for i, doc := range docSeparator.Split(string(data), -1) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		var fields map[string]interface{}
+		if err := yaml.Unmarshal([]byte(doc), &fields); err != nil {
+			return nil, errors.Wrapf(err, "document %d", i+1)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		p, err := parseKubeadmPatch(fields)
+		if err != nil {
+			return nil, errors.Wrapf(err, "document %d", i+1)
+		}
+		patches = append(patches, p)
+	}
+	return patches, nil
+}
+
+// MarshalKubeadmPatches writes patches as a multi-document patch file, which ParseKubeadmPatches reads back
+func MarshalKubeadmPatches(patches []config.KubeadmPatch) ([]byte, error) {
+	docs := []string{}
+	for _, p := range patches {
+		var fields map[string]interface{}
+		switch p.Type {
+		case PatchTypeStrategic:
+			if err := yaml.Unmarshal([]byte(p.Patch), &fields); err != nil {
+				return nil, errors.Wrapf(err, "%s patch", p.Target)
+			}
+			if fields == nil {
+				fields = map[string]interface{}{}
+			}
+			fields["kind"] = p.Target
+		case PatchTypeJSON:
+			var ops []interface{}
+			if err := yaml.Unmarshal([]byte(p.Patch), &ops); err != nil {
+				return nil, errors.Wrapf(err, "%s patch", p.Target)
+			}
+			fields = map[string]interface{}{"target": p.Target, "patch": ops}
+		default:
+			return nil, fmt.Errorf("invalid type %q of the %s patch", p.Type, p.Target)
+		}
+		doc, err := yaml.Marshal(fields)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, string(doc))
+	}
+	return []byte(strings.Join(docs, "---\n")), nil
+}
+
+func parseKubeadmPatch(fields map[string]interface{}) (config.KubeadmPatch, error) {
+	p := config.KubeadmPatch{}
+	if kind, ok := fields["kind"].(string); ok {
+		// minikube picks the API version matching the Kubernetes version
+		delete(fields, "kind")
+		delete(fields, "apiVersion")
+		patch, err := yaml.Marshal(fields)
+		if err != nil {
+			return p, err
+		}
+		p = config.KubeadmPatch{Target: kind, Type: PatchTypeStrategic, Patch: string(patch)}
+	} else {
+		target, _ := fields["target"].(string)
+		ops, ok := fields["patch"].([]interface{})
+		if target == "" || !ok {
+			return p, fmt.Errorf("expected either a kind, or a target and a list of JSON patch operations")
+		}
+		patch, err := yaml.Marshal(ops)
+		if err != nil {
+			return p, err
+		}
+		p = config.KubeadmPatch{Target: target, Type: PatchTypeJSON, Patch: string(patch)}
+	}
+
+	if !isPatchTarget(p.Target) {
+		return p, fmt.Errorf("%q can not be patched, valid kinds are: %s", p.Target, strings.Join(KubeadmPatchTargets, ", "))
+	}
+	if p.Type == PatchTypeJSON {
+		if _, err := decodeJSONPatch(p.Patch); err != nil {
+			return p, err
+		}
+	}
+	return p, nil
+}
+
+func isPatchTarget(kind string) bool {
+	for _, t := range KubeadmPatchTargets {
+		if t == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func decodeJSONPatch(patch string) (jsonpatch.Patch, error) {
+	js, err := yaml.YAMLToJSON([]byte(patch))
+	if err != nil {
+		return nil, errors.Wrap(err, "converting JSON patch")
+	}
+	ops, err := jsonpatch.DecodePatch(js)
+	if err != nil {
+		return nil, errors.Wrap(err, "decoding JSON patch")
+	}
+	for _, op := range ops {
+		switch op.Kind() {
+		case "add", "remove", "replace", "move", "copy", "test":
+		default:
+			return nil, fmt.Errorf("invalid JSON patch operation %q", op.Kind())
+		}
+	}
+	return ops, nil
+}
+
+// applyKubeadmPatches applies the patches to the documents of the kubeadm config they target.
+// Documents without patches are left as they are.
+func applyKubeadmPatches(cfg []byte, patches []config.KubeadmPatch) ([]byte, error) {
+	if len(patches) == 0 {
+		return cfg, nil
+	}
+
+	docs := docSeparator.Split(string(cfg), -1)
+	applied := map[string]bool{}
+	for i, doc := range docs {
+		var meta struct {
+			Kind string `json:"kind"`
+		}
+		if err := yaml.Unmarshal([]byte(doc), &meta); err != nil {
+			return nil, errors.Wrap(err, "unmarshalling kubeadm config")
+		}
+
+		patched := false
+		js, err := yaml.YAMLToJSON([]byte(doc))
+		if err != nil {
+			return nil, errors.Wrapf(err, "converting %s", meta.Kind)
+		}
+		for _, p := range patches {
+			if p.Target != meta.Kind {
+				continue
+			}
+			js, err = applyKubeadmPatch(js, p)
+			if err != nil {
+				return nil, errors.Wrapf(err, "patching %s", p.Target)
+			}
+			patched = true
+			applied[p.Target] = true
+		}
+		if !patched {
+			continue
+		}
+
+		out, err := yaml.JSONToYAML(js)
+		if err != nil {
+			return nil, errors.Wrapf(err, "converting %s", meta.Kind)
+		}
+		klog.Infof("patched %s in the kubeadm config", meta.Kind)
+		docs[i] = string(out)
+	}
+
+	for _, p := range patches {
+		if !applied[p.Target] {
+			return nil, fmt.Errorf("the kubeadm config for this Kubernetes version has no %s to patch", p.Target)
+		}
+	}
+	return []byte(strings.Join(docs, "---\n")), nil
+}
+
+func applyKubeadmPatch(doc []byte, p config.KubeadmPatch) ([]byte, error) {
+	switch p.Type {
+	case PatchTypeJSON:
+		ops, err := decodeJSONPatch(p.Patch)
+		if err != nil {
+			return nil, err
+		}
+		return ops.Apply(doc)
+	case PatchTypeStrategic:
+		var orig, patch interface{}
+		if err := yaml.Unmarshal(doc, &orig); err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal([]byte(p.Patch), &patch); err != nil {
+			return nil, errors.Wrap(err, "unmarshalling patch")
+		}
+		return yaml.Marshal(mergeValues(orig, patch))
+	default:
+		return nil, fmt.Errorf("unknown patch type %q", p.Type)
+	}
+}
+
+// mergeValues merges patch into orig: maps are merged recursively, null removes a key,
+// lists of named objects are merged by name and anything else is replaced
+func mergeValues(orig, patch interface{}) interface{} {
+	switch pv := patch.(type) {
+	case map[string]interface{}:
+		ov, ok := orig.(map[string]interface{})
+		if !ok {
+			ov = map[string]interface{}{}
+		}
+		for k, v := range pv {
+			if v == nil {
+				delete(ov, k)
+				continue
+			}
+			ov[k] = mergeValues(ov[k], v)
+		}
+		return ov
+	case []interface{}:
+		ov, ok := orig.([]interface{})
+		if !ok || !namedList(ov) || !namedList(pv) {
+			return pv
+		}
+		for _, item := range pv {
+			name := item.(map[string]interface{})["name"]
+			found := false
+			for i, o := range ov {
+				if o.(map[string]interface{})["name"] == name {
+					ov[i] = mergeValues(o, item)
+					found = true
+					break
+				}
+			}
+			if !found {
+				ov = append(ov, item)
+			}
+		}
+		return ov
+	default:
+		return patch
+	}
+}
+
+// namedList returns whether every item of the list is an object with a name
+func namedList(l []interface{}) bool {
+	for _, item := range l {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := m["name"]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bsutil
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+const patchTestConfig = `apiVersion: kubeadm.k8s.io/v1beta2
+kind: InitConfiguration
+nodeRegistration:
+  name: "mk"
+---
+apiVersion: kubeadm.k8s.io/v1beta2
+kind: ClusterConfiguration
+apiServer:
+  certSANs: ["127.0.0.1", "localhost"]
+  extraArgs:
+    enable-admission-plugins: "NamespaceLifecycle"
+  extraVolumes:
+    - name: certs
+      hostPath: /etc/certs
+      mountPath: /etc/certs
+---
+apiVersion: kubelet.config.k8s.io/v1beta1
+kind: KubeletConfiguration
+cgroupDriver: systemd
+`
+
+func TestParseKubeadmPatches(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		expected  []config.KubeadmPatch
+		shouldErr bool
+	}{
+		{
+			name: "strategic and json",
+			data: `apiVersion: kubeadm.k8s.io/v1beta2
+kind: ClusterConfiguration
+apiServer:
+  extraArgs:
+    audit-log-path: "-"
+---
+target: KubeletConfiguration
+patch:
+- op: replace
+  path: /cgroupDriver
+  value: cgroupfs
+`,
+			expected: []config.KubeadmPatch{
+				{Target: "ClusterConfiguration", Type: PatchTypeStrategic, Patch: "apiServer:\n  extraArgs:\n    audit-log-path: '-'\n"},
+				{Target: "KubeletConfiguration", Type: PatchTypeJSON, Patch: "- op: replace\n  path: /cgroupDriver\n  value: cgroupfs\n"},
+			},
+		},
+		{name: "empty documents", data: "---\n\n---\n", expected: nil},
+		{name: "unknown kind", data: "kind: JoinConfiguration\n", shouldErr: true},
+		{name: "no kind or target", data: "apiServer: {}\n", shouldErr: true},
+		{name: "invalid operations", data: "target: ClusterConfiguration\npatch:\n- path: /apiServer\n", shouldErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseKubeadmPatches([]byte(tc.data))
+			if err != nil && !tc.shouldErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err == nil && tc.shouldErr {
+				t.Fatalf("expected error but got none: %v", got)
+			}
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("ParseKubeadmPatches() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyKubeadmPatches(t *testing.T) {
+	tests := []struct {
+		name      string
+		patches   []config.KubeadmPatch
+		expected  string
+		shouldErr bool
+	}{
+		{
+			name:     "no patches",
+			expected: patchTestConfig,
+		},
+		{
+			name: "strategic",
+			patches: []config.KubeadmPatch{
+				{Target: "ClusterConfiguration", Type: PatchTypeStrategic, Patch: `apiServer:
+  certSANs: ["minikube"]
+  extraArgs:
+    audit-policy-file: /etc/audit/policy.yaml
+  extraVolumes:
+  - name: certs
+    readOnly: true
+  - name: audit
+    hostPath: /etc/audit
+    mountPath: /etc/audit
+`},
+			},
+			expected: `apiVersion: kubeadm.k8s.io/v1beta2
+kind: InitConfiguration
+nodeRegistration:
+  name: "mk"
+---
+apiServer:
+  certSANs:
+  - minikube
+  extraArgs:
+    audit-policy-file: /etc/audit/policy.yaml
+    enable-admission-plugins: NamespaceLifecycle
+  extraVolumes:
+  - hostPath: /etc/certs
+    mountPath: /etc/certs
+    name: certs
+    readOnly: true
+  - hostPath: /etc/audit
+    mountPath: /etc/audit
+    name: audit
+apiVersion: kubeadm.k8s.io/v1beta2
+kind: ClusterConfiguration
+---
+apiVersion: kubelet.config.k8s.io/v1beta1
+kind: KubeletConfiguration
+cgroupDriver: systemd
+`,
+		},
+		{
+			name: "json and null",
+			patches: []config.KubeadmPatch{
+				{Target: "KubeletConfiguration", Type: PatchTypeJSON, Patch: "- op: add\n  path: /maxPods\n  value: 200\n"},
+				{Target: "KubeletConfiguration", Type: PatchTypeStrategic, Patch: "cgroupDriver: null\n"},
+			},
+			expected: `apiVersion: kubeadm.k8s.io/v1beta2
+kind: InitConfiguration
+nodeRegistration:
+  name: "mk"
+---
+apiVersion: kubeadm.k8s.io/v1beta2
+kind: ClusterConfiguration
+apiServer:
+  certSANs: ["127.0.0.1", "localhost"]
+  extraArgs:
+    enable-admission-plugins: "NamespaceLifecycle"
+  extraVolumes:
+    - name: certs
+      hostPath: /etc/certs
+      mountPath: /etc/certs
+---
+apiVersion: kubelet.config.k8s.io/v1beta1
+kind: KubeletConfiguration
+maxPods: 200
+`,
+		},
+		{
+			name:      "missing target",
+			patches:   []config.KubeadmPatch{{Target: "KubeProxyConfiguration", Type: PatchTypeStrategic, Patch: "mode: ipvs\n"}},
+			shouldErr: true,
+		},
+		{
+			name:      "failing operation",
+			patches:   []config.KubeadmPatch{{Target: "InitConfiguration", Type: PatchTypeJSON, Patch: "- op: remove\n  path: /localAPIEndpoint\n"}},
+			shouldErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := applyKubeadmPatches([]byte(patchTestConfig), tc.patches)
+			if err != nil && !tc.shouldErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err == nil && tc.shouldErr {
+				t.Fatalf("expected error but got none:\n%s", got)
+			}
+			if tc.shouldErr {
+				return
+			}
+			if diff := cmp.Diff(tc.expected, string(got)); diff != "" {
+				t.Errorf("applyKubeadmPatches() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -84,6 +84,15 @@ func SetupCerts(cmd command.Runner, k8s config.KubernetesConfig, n config.Node)
 	if err != nil {
 		return nil, err
 	}
+	if k8s.OIDCCAFile != "" {
+		certFile, err := assets.NewFileAsset(k8s.OIDCCAFile, path.Dir(vmpath.GuestOIDCCACert), path.Base(vmpath.GuestOIDCCACert), "0644")
+		if err != nil {
+			return nil, errors.Wrapf(err, "OIDC CA asset %s", k8s.OIDCCAFile)
+		}
+		copyableFiles = append(copyableFiles, certFile)
+		// the rest of the node trusts the issuer too
+		caCerts[k8s.OIDCCAFile] = path.Join(vmpath.GuestCertAuthDir, "minikubeOIDC.pem")
+	}
 	for src, dst := range caCerts {
 		certFile, err := assets.NewFileAsset(src, path.Dir(dst), path.Base(dst), "0644")
 		if err != nil {
@@ -202,6 +211,9 @@ func generateProfileCerts(k8s config.KubernetesConfig, n config.Node, ccs CACert
 	if v := oci.DaemonHost(k8s.ContainerRuntime); v != oci.DefaultBindIPV4 {
 		apiServerIPs = append(apiServerIPs, net.ParseIP(v))
 	}
+	if k8s.APIServerHAVIP != "" {
+		apiServerIPs = append(apiServerIPs, net.ParseIP(k8s.APIServerHAVIP))
+	}
 
 	apiServerNames := append(k8s.APIServerNames, k8s.APIServerName, constants.ControlPlaneAlias)
 	apiServerAlternateNames := append(
@@ -273,19 +285,20 @@ func generateProfileCerts(k8s config.KubernetesConfig, n config.Node, ccs CACert
 
 		if canRead(cp) && canRead(kp) {
 			klog.Infof("skipping %s signed cert generation: %s", spec.subject, kp)
-			continue
-		}
-
-		klog.Infof("generating %s signed cert: %s", spec.subject, kp)
-		err := util.GenerateSignedCert(
-			cp, kp, spec.subject,
-			spec.ips, spec.alternateNames,
-			spec.caCertPath, spec.caKeyPath,
-		)
-		if err != nil {
-			return xfer, errors.Wrapf(err, "generate signed cert for %q", spec.subject)
+		} else {
+			klog.Infof("generating %s signed cert: %s", spec.subject, kp)
+			err := util.GenerateSignedCert(
+				cp, kp, spec.subject,
+				spec.ips, spec.alternateNames,
+				spec.caCertPath, spec.caKeyPath,
+			)
+			if err != nil {
+				return xfer, errors.Wrapf(err, "generate signed cert for %q", spec.subject)
+			}
 		}
 
+		// certs which depend on the node are cached per ip/name combination, and every
+		// control plane of a multi-control-plane cluster needs its own one copied over
 		if spec.hash != "" {
 			klog.Infof("copying %s -> %s", cp, spec.certPath)
 			if err := copy.Copy(cp, spec.certPath); err != nil {
@@ -148,18 +148,10 @@ func KindNet(repo string) string {
 	return path.Join(repo, "kindnetd:v20210220-5b7e6d01")
 }
 
-// CalicoDaemonSet returns the image used for calicoDaemonSet
-func CalicoDaemonSet(repo string) string {
+// KubeVip returns the image used for the virtual IP in front of the API servers of multi-control-plane clusters
+func KubeVip(repo string) string {
 	if repo == "" {
-		repo = "calico"
+		repo = "ghcr.io/kube-vip"
 	}
-	return path.Join(repo, "node:v3.14.1")
-}
-
-// CalicoDeployment returns the image used for calicoDeployment
-func CalicoDeployment(repo string) string {
-	if repo == "" {
-		repo = "calico"
-	}
-	return path.Join(repo, "kube-controllers:v3.14.1")
+	return path.Join(repo, "kube-vip:v0.3.8")
 }
@@ -24,7 +24,7 @@ import (
 
 func TestAuxiliary(t *testing.T) {
 	want := []string{
-		"gcr.io/k8s-minikube/storage-provisioner:v4",
+		"gcr.io/k8s-minikube/storage-provisioner:v5",
 		"docker.io/kubernetesui/dashboard:v2.1.0",
 		"docker.io/kubernetesui/metrics-scraper:v1.0.4",
 	}
@@ -36,7 +36,7 @@ func TestAuxiliary(t *testing.T) {
 
 func TestAuxiliaryMirror(t *testing.T) {
 	want := []string{
-		"test.mirror/storage-provisioner:v4",
+		"test.mirror/storage-provisioner:v5",
 		"test.mirror/dashboard:v2.1.0",
 		"test.mirror/metrics-scraper:v1.0.4",
 	}
@@ -172,7 +172,7 @@ func (k *Bootstrapper) clearStaleConfigs(cfg config.ClusterConfig) error {
 	return nil
 }
 
-func (k *Bootstrapper) init(cfg config.ClusterConfig) error {
+func (k *Bootstrapper) init(ctx context.Context, cfg config.ClusterConfig) error {
 	version, err := util.ParseKubernetesVersion(cfg.KubernetesConfig.KubernetesVersion)
 	if err != nil {
 		return errors.Wrap(err, "parsing Kubernetes version")
@@ -232,20 +232,19 @@ func (k *Bootstrapper) init(cfg config.ClusterConfig) error {
 	}
 
 	conf := bsutil.KubeadmYamlPath
-	ctx, cancel := context.WithTimeout(context.Background(), initTimeoutMinutes*time.Minute)
+	ictx, cancel := context.WithTimeout(ctx, initTimeoutMinutes*time.Minute)
 	defer cancel()
 	kr, kw := io.Pipe()
-	c := exec.CommandContext(ctx, "/bin/bash", "-c", fmt.Sprintf("%s init --config %s %s --ignore-preflight-errors=%s",
+	c := exec.Command("/bin/bash", "-c", fmt.Sprintf("%s init --config %s %s --ignore-preflight-errors=%s",
 		bsutil.InvokeKubeadm(cfg.KubernetesConfig.KubernetesVersion), conf, extraFlags, strings.Join(ignore, ",")))
 	c.Stdout = kw
 	c.Stderr = kw
-	sc, err := k.c.StartCmd(c)
-	if err != nil {
-		return errors.Wrap(err, "start")
-	}
 	go outputKubeadmInitSteps(kr)
-	if _, err := k.c.WaitCmd(sc); err != nil {
-		if ctx.Err() == context.DeadlineExceeded {
+	if _, err := k.c.RunCmdContext(ictx, c); err != nil {
+		if ctx.Err() != nil {
+			return errors.Wrap(err, "kubeadm init")
+		}
+		if ictx.Err() == context.DeadlineExceeded {
 			return ErrInitTimedout
 		}
 
@@ -263,7 +262,7 @@ func (k *Bootstrapper) init(cfg config.ClusterConfig) error {
 
 	go func() {
 		// we need to have cluster role binding before applying overlay to avoid #7428
-		if err := k.elevateKubeSystemPrivileges(cfg); err != nil {
+		if err := k.elevateKubeSystemPrivileges(ctx, cfg); err != nil {
 			klog.Errorf("unable to create cluster role binding, some addons might not work: %v", err)
 		}
 		wg.Done()
@@ -365,8 +364,8 @@ func (k *Bootstrapper) unpause(cfg config.ClusterConfig) error {
 	return nil
 }
 
-// StartCluster starts the cluster
-func (k *Bootstrapper) StartCluster(cfg config.ClusterConfig) error {
+// StartCluster starts the cluster, giving up once ctx is done
+func (k *Bootstrapper) StartCluster(ctx context.Context, cfg config.ClusterConfig) error {
 	start := time.Now()
 	klog.Infof("StartCluster: %+v", cfg)
 	defer func() {
@@ -380,10 +379,13 @@ func (k *Bootstrapper) StartCluster(cfg config.ClusterConfig) error {
 
 	if err := bsutil.ExistingConfig(k.c); err == nil {
 		klog.Infof("found existing configuration files, will attempt cluster restart")
-		rerr := k.restartControlPlane(cfg)
+		rerr := k.restartControlPlane(ctx, cfg)
 		if rerr == nil {
 			return nil
 		}
+		if ctx.Err() != nil {
+			return rerr
+		}
 
 		out.ErrT(style.Embarrassed, "Unable to restart cluster, will reset it: {{.error}}", out.V{"error": rerr})
 		if err := k.DeleteCluster(cfg.KubernetesConfig); err != nil {
@@ -397,18 +399,18 @@ func (k *Bootstrapper) StartCluster(cfg config.ClusterConfig) error {
 		return errors.Wrap(err, "cp")
 	}
 
-	err := k.init(cfg)
+	err := k.init(ctx, cfg)
 	if err == nil {
 		return nil
 	}
 
 	// retry again if it is not a fail fast error
-	if _, ff := err.(*FailFastError); !ff {
+	if _, ff := err.(*FailFastError); !ff && ctx.Err() == nil {
 		out.ErrT(style.Conflict, "initialization failed, will try again: {{.error}}", out.V{"error": err})
 		if err := k.DeleteCluster(cfg.KubernetesConfig); err != nil {
 			klog.Warningf("delete failed: %v", err)
 		}
-		return k.init(cfg)
+		return k.init(ctx, cfg)
 	}
 	return err
 }
@@ -436,8 +438,8 @@ func (k *Bootstrapper) client(ip string, port int) (*kubernetes.Clientset, error
 	return c, err
 }
 
-// WaitForNode blocks until the node appears to be healthy
-func (k *Bootstrapper) WaitForNode(cfg config.ClusterConfig, n config.Node, timeout time.Duration) error {
+// WaitForNode blocks until the node appears to be healthy, or ctx is done
+func (k *Bootstrapper) WaitForNode(ctx context.Context, cfg config.ClusterConfig, n config.Node, timeout time.Duration) error {
 	start := time.Now()
 	register.Reg.SetStep(register.VerifyingKubernetes)
 	out.Step(style.HealthCheck, "Verifying Kubernetes components...")
@@ -464,7 +466,7 @@ func (k *Bootstrapper) WaitForNode(cfg config.ClusterConfig, n config.Node, time
 	if !kverify.ShouldWait(cfg.VerifyComponents) {
 		klog.Infof("skip waiting for components based on config.")
 
-		if err := kverify.NodePressure(client); err != nil {
+		if err := kverify.NodePressure(ctx, client); err != nil {
 			adviseNodePressure(err, cfg.Name, cfg.Driver)
 			return errors.Wrap(err, "node pressure")
 		}
@@ -472,7 +474,7 @@ func (k *Bootstrapper) WaitForNode(cfg config.ClusterConfig, n config.Node, time
 	}
 
 	if cfg.VerifyComponents[kverify.ExtraKey] {
-		if err := kverify.WaitExtra(client, kverify.CorePodsList, timeout); err != nil {
+		if err := kverify.WaitExtra(ctx, client, kverify.CorePodsList, timeout); err != nil {
 			return errors.Wrap(err, "extra waiting")
 		}
 	}
@@ -484,49 +486,59 @@ func (k *Bootstrapper) WaitForNode(cfg config.ClusterConfig, n config.Node, time
 
 	if n.ControlPlane {
 		if cfg.VerifyComponents[kverify.APIServerWaitKey] {
-			if err := kverify.WaitForAPIServerProcess(cr, k, cfg, k.c, start, timeout); err != nil {
+			if err := kverify.WaitForAPIServerProcess(ctx, cr, k, cfg, k.c, start, timeout); err != nil {
 				return errors.Wrap(err, "wait for apiserver proc")
 			}
 
-			if err := kverify.WaitForHealthyAPIServer(cr, k, cfg, k.c, client, start, hostname, port, timeout); err != nil {
+			if err := kverify.WaitForHealthyAPIServer(ctx, cr, k, cfg, k.c, client, start, hostname, port, timeout); err != nil {
 				return errors.Wrap(err, "wait for healthy API server")
 			}
 		}
 
 		if cfg.VerifyComponents[kverify.SystemPodsWaitKey] {
-			if err := kverify.WaitForSystemPods(cr, k, cfg, k.c, client, start, timeout); err != nil {
+			if err := kverify.WaitForSystemPods(ctx, cr, k, cfg, k.c, client, start, timeout); err != nil {
 				return errors.Wrap(err, "waiting for system pods")
 			}
 		}
 
 		if cfg.VerifyComponents[kverify.DefaultSAWaitKey] {
-			if err := kverify.WaitForDefaultSA(client, timeout); err != nil {
+			if err := kverify.WaitForDefaultSA(ctx, client, timeout); err != nil {
 				return errors.Wrap(err, "waiting for default service account")
 			}
 		}
 
 		if cfg.VerifyComponents[kverify.AppsRunningKey] {
-			if err := kverify.WaitForAppsRunning(client, kverify.AppsRunningList, timeout); err != nil {
+			if err := kverify.WaitForAppsRunning(ctx, client, kverify.AppsRunningList, timeout); err != nil {
 				return errors.Wrap(err, "waiting for apps_running")
 			}
 		}
 	}
 
 	if cfg.VerifyComponents[kverify.KubeletKey] {
-		if err := kverify.WaitForService(k.c, "kubelet", timeout); err != nil {
+		if err := kverify.WaitForService(ctx, k.c, "kubelet", timeout); err != nil {
 			return errors.Wrap(err, "waiting for kubelet")
 		}
 	}
 
+	if cfg.VerifyComponents[kverify.CNIReadyKey] {
+		cnm, err := cni.New(cfg)
+		if err != nil {
+			return errors.Wrap(err, "cni")
+		}
+		if err := kverify.WaitForCNI(ctx, client, cnm, timeout); err != nil {
+			return errors.Wrap(err, "waiting for cni to be ready")
+		}
+	}
+
 	if cfg.VerifyComponents[kverify.NodeReadyKey] {
-		if err := kverify.WaitForNodeReady(client, timeout); err != nil {
+		if err := kverify.WaitForNodeReady(ctx, client, timeout); err != nil {
 			return errors.Wrap(err, "waiting for node to be ready")
 		}
 	}
 
 	klog.Infof("duration metric: took %s to wait for : %+v ...", time.Since(start), cfg.VerifyComponents)
 
-	if err := kverify.NodePressure(client); err != nil {
+	if err := kverify.NodePressure(ctx, client); err != nil {
 		adviseNodePressure(err, cfg.Name, cfg.Driver)
 		return errors.Wrap(err, "node pressure")
 	}
@@ -576,7 +588,7 @@ func (k *Bootstrapper) needsReconfigure(conf string, hostname string, port int,
 }
 
 // restartCluster restarts the Kubernetes cluster configured by kubeadm
-func (k *Bootstrapper) restartControlPlane(cfg config.ClusterConfig) error {
+func (k *Bootstrapper) restartControlPlane(ctx context.Context, cfg config.ClusterConfig) error {
 	klog.Infof("restartCluster start")
 
 	start := time.Now()
@@ -686,10 +698,10 @@ func (k *Bootstrapper) restartControlPlane(cfg config.ClusterConfig) error {
 			}
 			return fmt.Errorf("kubelet not initialised")
 		}
-		_ = retry.Expo(wait, 250*time.Millisecond, 1*time.Minute)
+		_ = retry.ExpoContext(ctx, wait, 250*time.Millisecond, 1*time.Minute)
 		klog.Infof("kubelet initialised")
 		klog.Infof("duration metric: took %s waiting for restarted kubelet to initialise ...", time.Since(start))
-		if err := kverify.WaitExtra(client, kverify.CorePodsList, kconst.DefaultControlPlaneTimeout); err != nil {
+		if err := kverify.WaitExtra(ctx, client, kverify.CorePodsList, kconst.DefaultControlPlaneTimeout); err != nil {
 			return errors.Wrap(err, "extra")
 		}
 	}
@@ -700,11 +712,11 @@ func (k *Bootstrapper) restartControlPlane(cfg config.ClusterConfig) error {
 	}
 
 	// We must ensure that the apiserver is healthy before proceeding
-	if err := kverify.WaitForAPIServerProcess(cr, k, cfg, k.c, time.Now(), kconst.DefaultControlPlaneTimeout); err != nil {
+	if err := kverify.WaitForAPIServerProcess(ctx, cr, k, cfg, k.c, time.Now(), kconst.DefaultControlPlaneTimeout); err != nil {
 		return errors.Wrap(err, "apiserver healthz")
 	}
 
-	if err := kverify.WaitForHealthyAPIServer(cr, k, cfg, k.c, client, time.Now(), hostname, port, kconst.DefaultControlPlaneTimeout); err != nil {
+	if err := kverify.WaitForHealthyAPIServer(ctx, cr, k, cfg, k.c, client, time.Now(), hostname, port, kconst.DefaultControlPlaneTimeout); err != nil {
 		return errors.Wrap(err, "apiserver health")
 	}
 
@@ -713,11 +725,11 @@ func (k *Bootstrapper) restartControlPlane(cfg config.ClusterConfig) error {
 		return errors.Wrap(err, "apply cni")
 	}
 
-	if err := kverify.WaitForSystemPods(cr, k, cfg, k.c, client, time.Now(), kconst.DefaultControlPlaneTimeout); err != nil {
+	if err := kverify.WaitForSystemPods(ctx, cr, k, cfg, k.c, client, time.Now(), kconst.DefaultControlPlaneTimeout); err != nil {
 		return errors.Wrap(err, "system pods")
 	}
 
-	if err := kverify.NodePressure(client); err != nil {
+	if err := kverify.NodePressure(ctx, client); err != nil {
 		adviseNodePressure(err, cfg.Name, cfg.Driver)
 	}
 
@@ -726,7 +738,7 @@ func (k *Bootstrapper) restartControlPlane(cfg config.ClusterConfig) error {
 		_, err := k.c.RunCmd(exec.Command("/bin/bash", "-c", fmt.Sprintf("%s phase addon all --config %s", baseCmd, conf)))
 		return err
 	}
-	if err = retry.Expo(addonPhase, 100*time.Microsecond, 30*time.Second); err != nil {
+	if err = retry.ExpoContext(ctx, addonPhase, 100*time.Microsecond, 30*time.Second); err != nil {
 		klog.Warningf("addon install failed, wil retry: %v", err)
 		return errors.Wrap(err, "addons")
 	}
@@ -738,8 +750,8 @@ func (k *Bootstrapper) restartControlPlane(cfg config.ClusterConfig) error {
 	return nil
 }
 
-// JoinCluster adds a node to an existing cluster
-func (k *Bootstrapper) JoinCluster(cc config.ClusterConfig, n config.Node, joinCmd string) error {
+// JoinCluster adds a node to an existing cluster, giving up once ctx is done
+func (k *Bootstrapper) JoinCluster(ctx context.Context, cc config.ClusterConfig, n config.Node, joinCmd string) error {
 	start := time.Now()
 	klog.Infof("JoinCluster: %+v", cc)
 	defer func() {
@@ -749,6 +761,20 @@ func (k *Bootstrapper) JoinCluster(cc config.ClusterConfig, n config.Node, joinC
 	// Join the master by specifying its token
 	joinCmd = fmt.Sprintf("%s --node-name=%s", joinCmd, config.MachineName(cc, n))
 
+	if n.ControlPlane {
+		port := n.Port
+		if port <= 0 {
+			port = constants.APIServerPort
+		}
+		joinCmd = fmt.Sprintf("%s --apiserver-advertise-address=%s --apiserver-bind-port=%d", joinCmd, n.IP, port)
+
+		// resetting a control plane node which has already joined would remove its etcd member
+		if _, err := k.c.RunCmd(exec.Command("sudo", "test", "-f", "/etc/kubernetes/admin.conf")); err == nil {
+			klog.Infof("control plane node %s has already joined the cluster, restarting kubelet", config.MachineName(cc, n))
+			return k.startKubelet()
+		}
+	}
+
 	join := func() error {
 		// reset first to clear any possibly existing state
 		_, err := k.c.RunCmd(exec.Command("/bin/bash", "-c", fmt.Sprintf("%s reset -f", bsutil.InvokeKubeadm(cc.KubernetesConfig.KubernetesVersion))))
@@ -766,19 +792,31 @@ func (k *Bootstrapper) JoinCluster(cc config.ClusterConfig, n config.Node, joinC
 		return nil
 	}
 
-	if err := retry.Expo(join, 10*time.Second, 3*time.Minute); err != nil {
+	if err := retry.ExpoContext(ctx, join, 10*time.Second, 3*time.Minute); err != nil {
 		return errors.Wrap(err, "joining cp")
 	}
 
+	// kubeadm reset clears the manifests directory, so kube-vip has to be written after joining
+	if n.ControlPlane && config.IsHA(cc) {
+		if err := k.configureKubeVip(cc, n); err != nil {
+			return errors.Wrap(err, "kube-vip")
+		}
+	}
+
+	return k.startKubelet()
+}
+
+// startKubelet enables and starts the kubelet service
+func (k *Bootstrapper) startKubelet() error {
 	if _, err := k.c.RunCmd(exec.Command("/bin/bash", "-c", "sudo systemctl daemon-reload && sudo systemctl enable kubelet && sudo systemctl start kubelet")); err != nil {
 		return errors.Wrap(err, "starting kubelet")
 	}
-
 	return nil
 }
 
-// GenerateToken creates a token and returns the appropriate kubeadm join command to run, or the already existing token
-func (k *Bootstrapper) GenerateToken(cc config.ClusterConfig) (string, error) {
+// GenerateToken creates a token and returns the appropriate kubeadm join command to run, or the already existing token.
+// For control plane nodes, the cluster certificates are uploaded and the join command includes the key to fetch them.
+func (k *Bootstrapper) GenerateToken(cc config.ClusterConfig, n config.Node) (string, error) {
 	// Take that generated token and use it to get a kubeadm join command
 	tokenCmd := exec.Command("/bin/bash", "-c", fmt.Sprintf("%s token create --print-join-command --ttl=0", bsutil.InvokeKubeadm(cc.KubernetesConfig.KubernetesVersion)))
 	r, err := k.c.RunCmd(tokenCmd)
@@ -793,9 +831,34 @@ func (k *Bootstrapper) GenerateToken(cc config.ClusterConfig) (string, error) {
 		joinCmd = fmt.Sprintf("%s --cri-socket %s", joinCmd, cc.KubernetesConfig.CRISocket)
 	}
 
+	if n.ControlPlane {
+		key, err := k.uploadCerts(cc)
+		if err != nil {
+			return "", errors.Wrap(err, "uploading certs")
+		}
+		joinCmd = fmt.Sprintf("%s --control-plane --certificate-key %s", joinCmd, key)
+	}
+
 	return joinCmd, nil
 }
 
+// uploadCerts uploads the control plane certificates to the cluster, returning the key required to download them
+func (k *Bootstrapper) uploadCerts(cc config.ClusterConfig) (string, error) {
+	c := exec.Command("/bin/bash", "-c", fmt.Sprintf("%s init phase upload-certs --upload-certs --config %s", bsutil.InvokeKubeadm(cc.KubernetesConfig.KubernetesVersion), bsutil.KubeadmYamlPath))
+	rr, err := k.c.RunCmd(c)
+	if err != nil {
+		return "", err
+	}
+
+	// the key is printed on the last line of the output
+	lines := strings.Split(strings.TrimSpace(rr.Stdout.String()), "\n")
+	key := strings.TrimSpace(lines[len(lines)-1])
+	if key == "" {
+		return "", fmt.Errorf("no certificate key in output: %q", rr.Output())
+	}
+	return key, nil
+}
+
 // DeleteCluster removes the components that were started earlier
 func (k *Bootstrapper) DeleteCluster(k8s config.KubernetesConfig) error {
 	cr, err := cruntime.New(cruntime.Config{Type: k8s.ContainerRuntime, Runner: k.c, Socket: k8s.CRISocket})
@@ -912,7 +975,7 @@ func (k *Bootstrapper) UpdateNode(cfg config.ClusterConfig, n config.Node, r cru
 
 	files := []assets.CopyableFile{
 		assets.NewMemoryAssetTarget(kubeletCfg, bsutil.KubeletSystemdConfFile, "0644"),
-		assets.NewMemoryAssetTarget(kubeletService, bsutil.KubeletServiceFile, "0644"),
+		assets.NewMemoryAssetTarget(kubeletService, bsutil.KubeletServicePath(k.c), "0644"),
 	}
 
 	if n.ControlPlane {
@@ -936,13 +999,45 @@ func (k *Bootstrapper) UpdateNode(cfg config.ClusterConfig, n config.Node, r cru
 		return errors.Wrap(err, "control plane")
 	}
 
-	if err := machine.AddHostAlias(k.c, constants.ControlPlaneAlias, net.ParseIP(cp.IP)); err != nil {
+	cpIP := cp.IP
+	if config.IsHA(cfg) {
+		cpIP = cfg.KubernetesConfig.APIServerHAVIP
+	}
+	if err := machine.AddHostAlias(k.c, constants.ControlPlaneAlias, net.ParseIP(cpIP)); err != nil {
 		return errors.Wrap(err, "host alias")
 	}
 
+	// the other control planes get kube-vip once they have joined
+	if config.IsHA(cfg) && config.IsPrimaryControlPlane(cfg, n) {
+		if err := k.configureKubeVip(cfg, n); err != nil {
+			return errors.Wrap(err, "kube-vip")
+		}
+	}
+
 	return nil
 }
 
+// configureKubeVip writes the kube-vip static pod manifest, which announces the HA virtual IP from the node
+func (k *Bootstrapper) configureKubeVip(cfg config.ClusterConfig, n config.Node) error {
+	rr, err := k.c.RunCmd(exec.Command("/bin/bash", "-c", fmt.Sprintf("ip -o -4 addr show to %s | awk '{print $2}'", n.IP)))
+	if err != nil {
+		return errors.Wrap(err, "network interface")
+	}
+	iface := strings.TrimSpace(rr.Stdout.String())
+	if iface == "" {
+		return fmt.Errorf("no network interface found with address %s", n.IP)
+	}
+
+	manifest, err := bsutil.GenerateKubeVipManifest(cfg, n, iface)
+	if err != nil {
+		return errors.Wrap(err, "generating kube-vip manifest")
+	}
+
+	return bsutil.CopyFiles(k.c, []assets.CopyableFile{
+		assets.NewMemoryAssetTarget(manifest, bsutil.KubeVipManifestPath, "0600"),
+	})
+}
+
 // kubectlPath returns the path to the kubelet
 func kubectlPath(cfg config.ClusterConfig) string {
 	return path.Join(vmpath.GuestPersistentDir, "binaries", cfg.KubernetesConfig.KubernetesVersion, "kubectl")
@@ -975,23 +1070,23 @@ func (k *Bootstrapper) applyNodeLabels(cfg config.ClusterConfig) error {
 }
 
 // elevateKubeSystemPrivileges gives the kube-system service account cluster admin privileges to work with RBAC.
-func (k *Bootstrapper) elevateKubeSystemPrivileges(cfg config.ClusterConfig) error {
+func (k *Bootstrapper) elevateKubeSystemPrivileges(ctx context.Context, cfg config.ClusterConfig) error {
 	start := time.Now()
 	defer func() {
 		klog.Infof("duration metric: took %s to wait for elevateKubeSystemPrivileges.", time.Since(start))
 	}()
 
 	// Allow no more than 5 seconds for creating cluster role bindings
-	ctx, cancel := context.WithTimeout(context.Background(), applyTimeoutSeconds*time.Second)
+	actx, cancel := context.WithTimeout(ctx, applyTimeoutSeconds*time.Second)
 	defer cancel()
 	rbacName := "minikube-rbac"
 	// kubectl create clusterrolebinding minikube-rbac --clusterrole=cluster-admin --serviceaccount=kube-system:default
-	cmd := exec.CommandContext(ctx, "sudo", kubectlPath(cfg),
+	cmd := exec.Command("sudo", kubectlPath(cfg),
 		"create", "clusterrolebinding", rbacName, "--clusterrole=cluster-admin", "--serviceaccount=kube-system:default",
 		fmt.Sprintf("--kubeconfig=%s", path.Join(vmpath.GuestPersistentDir, "kubeconfig")))
-	rr, err := k.c.RunCmd(cmd)
+	rr, err := k.c.RunCmdContext(actx, cmd)
 	if err != nil {
-		if ctx.Err() == context.DeadlineExceeded {
+		if actx.Err() == context.DeadlineExceeded {
 			return errors.Wrapf(err, "timeout apply sa")
 		}
 		// Error from server (AlreadyExists): clusterrolebindings.rbac.authorization.k8s.io "minikube-rbac" already exists
@@ -1016,7 +1111,9 @@ func (k *Bootstrapper) elevateKubeSystemPrivileges(cfg config.ClusterConfig) err
 		}
 
 		// retry up to make sure SA is created
-		if err := wait.PollImmediate(kconst.APICallRetryInterval, time.Minute, checkSA); err != nil {
+		wctx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+		if err := wait.PollImmediateUntil(kconst.APICallRetryInterval, checkSA, wctx.Done()); err != nil {
 			return errors.Wrap(err, "ensure sa was created")
 		}
 	}
@@ -0,0 +1,353 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package bundle
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/drivers/kic"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/cni"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/download"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/image"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/out"
+	"k8s.io/minikube/pkg/version"
+)
+
+// manifestName is the name of the manifest within a bundle
+const manifestName = "bundle.json"
+
+// Manifest describes what a bundle was built for and what it contains
+type Manifest struct {
+	MinikubeVersion   string
+	KubernetesVersion string
+	ContainerRuntime  string
+	Driver            string
+	OS                string
+	Arch              string
+	KicBaseImage      string `json:",omitempty"`
+	CNI               string `json:",omitempty"`
+	// Images are the images which have to be loaded into the cluster, as they are not part of the preload
+	Images []string `json:",omitempty"`
+	// Files are the paths of the bundled files, relative to the minikube home directory
+	Files []string
+}
+
+// Options selects what a bundle is built for
+type Options struct {
+	KubernetesVersion string
+	ContainerRuntime  string
+	Driver            string
+	CNI               string
+	Addons            []string
+}
+
+// Create caches everything a cluster built with opts needs, and writes it as a bundle to path
+func Create(path string, opts Options) (*Manifest, error) {
+	m := &Manifest{
+		MinikubeVersion:   version.GetVersion(),
+		KubernetesVersion: opts.KubernetesVersion,
+		ContainerRuntime:  opts.ContainerRuntime,
+		Driver:            opts.Driver,
+		OS:                runtime.GOOS,
+		Arch:              runtime.GOARCH,
+		CNI:               opts.CNI,
+	}
+	var files []string
+
+	if err := download.Preload(opts.KubernetesVersion, opts.ContainerRuntime); err != nil {
+		return nil, errors.Wrap(err, "preload")
+	}
+	if tarball := download.TarballPath(opts.KubernetesVersion, opts.ContainerRuntime); exists(tarball) {
+		files = append(files, tarball, download.PreloadChecksumPath(opts.KubernetesVersion, opts.ContainerRuntime))
+	} else {
+		klog.Infof("no preload for %s on %s, bundling the Kubernetes images", opts.KubernetesVersion, opts.ContainerRuntime)
+		imgs, err := bootstrapper.GetCachedImageList("", opts.KubernetesVersion, bootstrapper.Kubeadm)
+		if err != nil {
+			return nil, errors.Wrap(err, "kubernetes images")
+		}
+		if err := machine.CacheImagesForBootstrapper("", opts.KubernetesVersion, bootstrapper.Kubeadm); err != nil {
+			return nil, err
+		}
+		files = append(files, imageFiles(imgs)...)
+	}
+
+	if err := machine.CacheBinariesForBootstrapper(opts.KubernetesVersion, bootstrapper.Kubeadm); err != nil {
+		return nil, errors.Wrap(err, "binaries")
+	}
+	for _, bin := range bootstrapper.GetCachedBinaryList(bootstrapper.Kubeadm) {
+		files = append(files, localpath.MakeMiniPath("cache", "linux", opts.KubernetesVersion, bin))
+	}
+	kubectl := "kubectl"
+	if runtime.GOOS == "windows" {
+		kubectl = "kubectl.exe"
+	}
+	p, err := download.Binary(kubectl, opts.KubernetesVersion, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return nil, errors.Wrap(err, "kubectl")
+	}
+	files = append(files, p)
+
+	switch {
+	case opts.Driver == driver.Docker:
+		if err := image.SaveToDir([]string{kic.BaseImage}, constants.ImageCacheDir); err != nil {
+			return nil, errors.Wrap(err, "base image")
+		}
+		m.KicBaseImage = kic.BaseImage
+		files = append(files, imageFiles([]string{kic.BaseImage})...)
+	case driver.IsKIC(opts.Driver):
+		out.WarningT("The base image can not be bundled for the {{.driver}} driver, it has to be present on the host already", out.V{"driver": opts.Driver})
+	case driver.IsVM(opts.Driver):
+		u, err := download.ISO(download.DefaultISOURLs(), false)
+		if err != nil {
+			return nil, errors.Wrap(err, "iso")
+		}
+		files = append(files, filepath.FromSlash(strings.TrimPrefix(download.LocalISOResource(u), "file://")))
+	}
+
+	var images []string
+	cc := config.ClusterConfig{
+		Driver: opts.Driver,
+		KubernetesConfig: config.KubernetesConfig{
+			KubernetesVersion: opts.KubernetesVersion,
+			ContainerRuntime:  opts.ContainerRuntime,
+			CNI:               opts.CNI,
+		},
+	}
+	cniManifest, err := cni.CacheManifest(cc)
+	if err != nil {
+		return nil, errors.Wrap(err, "cni manifest")
+	}
+	if cniManifest != "" {
+		files = append(files, cniManifest)
+	}
+	cniImages, err := cni.Images(cc)
+	if err != nil {
+		return nil, errors.Wrap(err, "cni images")
+	}
+	images = append(images, cniImages...)
+
+	for _, name := range opts.Addons {
+		imgs, err := addonImages(name)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, imgs...)
+	}
+	images = dedupe(images)
+	if err := image.SaveToDir(images, constants.ImageCacheDir); err != nil {
+		return nil, errors.Wrap(err, "images")
+	}
+	m.Images = images
+	files = append(files, imageFiles(images)...)
+
+	for _, f := range dedupe(files) {
+		rel, err := filepath.Rel(localpath.MiniPath(), f)
+		if err != nil {
+			return nil, err
+		}
+		m.Files = append(m.Files, filepath.ToSlash(rel))
+	}
+	return m, write(path, m)
+}
+
+// Extract seeds the minikube caches with the contents of the bundle at path, and returns its manifest
+func Extract(path string) (*Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "open bundle")
+	}
+	defer f.Close()
+
+	var m *Manifest
+	tr := tar.NewReader(f)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "read %s", path)
+		}
+		if h.Name == manifestName {
+			m = &Manifest{}
+			if err := json.NewDecoder(tr).Decode(m); err != nil {
+				return nil, errors.Wrap(err, "decode manifest")
+			}
+			continue
+		}
+		if h.Typeflag != tar.TypeReg {
+			continue
+		}
+		dst, err := destination(h.Name)
+		if err != nil {
+			return nil, err
+		}
+		if err := extractFile(tr, dst, os.FileMode(h.Mode)); err != nil {
+			return nil, err
+		}
+	}
+	if m == nil {
+		return nil, fmt.Errorf("%s is not a minikube bundle: %s is missing", path, manifestName)
+	}
+	return m, nil
+}
+
+// write writes the manifest and the files it lists into a tar archive at path
+func write(path string, m *Manifest) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "create bundle")
+	}
+	tw := tar.NewWriter(f)
+
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		f.Close()
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: manifestName, Mode: 0644, Size: int64(len(b))}); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := tw.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	for _, rel := range m.Files {
+		if err := addFile(tw, rel); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// addFile adds the file at rel, relative to the minikube home directory, to the archive
+func addFile(tw *tar.Writer, rel string) error {
+	src := filepath.Join(localpath.MiniPath(), filepath.FromSlash(rel))
+	fi, err := os.Stat(src)
+	if err != nil {
+		return errors.Wrapf(err, "stat %s", src)
+	}
+	h, err := tar.FileInfoHeader(fi, "")
+	if err != nil {
+		return err
+	}
+	h.Name = rel
+	if err := tw.WriteHeader(h); err != nil {
+		return err
+	}
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(tw, f)
+	return errors.Wrapf(err, "add %s", src)
+}
+
+// destination returns where a bundled file is extracted to, refusing anything outside of the caches
+func destination(name string) (string, error) {
+	clean := filepath.ToSlash(filepath.Clean(filepath.FromSlash(name)))
+	if !strings.HasPrefix(clean, "cache/") || strings.Contains(clean, "../") || filepath.IsAbs(name) {
+		return "", fmt.Errorf("refusing to extract %q: bundled files must be within the cache directory", name)
+	}
+	return filepath.Join(localpath.MiniPath(), filepath.FromSlash(clean)), nil
+}
+
+// extractFile writes r to dst
+func extractFile(r io.Reader, dst string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return errors.Wrapf(err, "mkdir %s", filepath.Dir(dst))
+	}
+	klog.Infof("extracting %s ...", dst)
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode.Perm())
+	if err != nil {
+		return errors.Wrapf(err, "create %s", dst)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "write %s", dst)
+	}
+	return f.Close()
+}
+
+// addonImages returns the images of an addon, with their registries
+func addonImages(name string) ([]string, error) {
+	a, ok := assets.Addons[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown addon %q", name)
+	}
+	var images []string
+	for k, img := range a.Images {
+		if r := a.Registries[k]; r != "" {
+			img = r + "/" + img
+		}
+		images = append(images, img)
+	}
+	sort.Strings(images)
+	return images, nil
+}
+
+// imageFiles returns the paths images are cached at
+func imageFiles(images []string) []string {
+	var files []string
+	for _, img := range images {
+		files = append(files, localpath.SanitizeCacheDir(filepath.Join(constants.ImageCacheDir, img)))
+	}
+	return files
+}
+
+// dedupe removes duplicates from a list, keeping its order
+func dedupe(list []string) []string {
+	seen := map[string]bool{}
+	var result []string
+	for _, s := range list {
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		result = append(result, s)
+	}
+	return result
+}
+
+// exists returns true if path exists
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
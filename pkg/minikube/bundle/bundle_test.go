@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package bundle
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+func TestWriteExtract(t *testing.T) {
+	oldMinikubeHome := os.Getenv(localpath.MinikubeHome)
+	defer os.Setenv(localpath.MinikubeHome, oldMinikubeHome)
+
+	tmp, err := ioutil.TempDir("", "bundle")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	src := filepath.Join(tmp, "src")
+	os.Setenv(localpath.MinikubeHome, src)
+	files := map[string]string{
+		"cache/linux/v1.20.2/kubelet":             "kubelet",
+		"cache/images/k8s.gcr.io/pause_3.2":       "pause",
+		"cache/cni/https_example.com_calico.yaml": "calico",
+	}
+	m := &Manifest{KubernetesVersion: "v1.20.2", ContainerRuntime: "docker", Driver: "docker"}
+	for rel, content := range files {
+		p := localpath.MakeMiniPath(filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+		m.Files = append(m.Files, rel)
+	}
+	archive := filepath.Join(tmp, "bundle.tar")
+	if err := write(archive, m); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	os.Setenv(localpath.MinikubeHome, filepath.Join(tmp, "dst"))
+	got, err := Extract(archive)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if diff := cmp.Diff(m, got); diff != "" {
+		t.Errorf("manifest mismatch (-want +got):\n%s", diff)
+	}
+	for rel, content := range files {
+		b, err := ioutil.ReadFile(localpath.MakeMiniPath(filepath.FromSlash(rel)))
+		if err != nil {
+			t.Fatalf("%s was not extracted: %v", rel, err)
+		}
+		if string(b) != content {
+			t.Errorf("%s = %q, want %q", rel, b, content)
+		}
+	}
+}
+
+func TestDestination(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"cache/iso/minikube-v1.19.0.iso", false},
+		{"cache/images/../../config/config.json", true},
+		{"../cache/evil", true},
+		{"/etc/passwd", true},
+		{"profiles/minikube/config.json", true},
+	}
+	for _, tc := range tests {
+		_, err := destination(tc.name)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("destination(%q) error = %v, want error: %v", tc.name, err, tc.wantErr)
+		}
+	}
+}
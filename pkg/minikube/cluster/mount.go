@@ -29,9 +29,18 @@ import (
 	"k8s.io/minikube/pkg/minikube/command"
 )
 
+const (
+	// MountType9p is served by the 9p server of the minikube mount process
+	MountType9p = "9p"
+	// MountTypeSSHFS is served by the sftp-server of the host, over the ssh connection to the node
+	MountTypeSSHFS = "sshfs"
+	// MountTypeNFS is an export of the NFS server of the host
+	MountTypeNFS = "nfs"
+)
+
 // MountConfig defines the options available to the Mount command
 type MountConfig struct {
-	// Type is the filesystem type (Typically 9p)
+	// Type is the filesystem type: 9p, sshfs or nfs
 	Type string
 	// UID is the User ID which this path will be mounted as
 	UID string
@@ -54,7 +63,21 @@ type mountRunner interface {
 	RunCmd(*exec.Cmd) (*command.RunResult, error)
 }
 
-// Mount runs the mount command from the 9p client on the VM to the 9p server on the host
+// MountOptions parses mount options given as "key=value" or "key"
+func MountOptions(opts []string) map[string]string {
+	options := map[string]string{}
+	for _, o := range opts {
+		if !strings.Contains(o, "=") {
+			options[o] = ""
+			continue
+		}
+		parts := strings.SplitN(o, "=", 2)
+		options[parts[0]] = parts[1]
+	}
+	return options
+}
+
+// Mount runs the mount command from the 9p or NFS client on the VM to the server on the host
 func Mount(r mountRunner, source string, target string, c *MountConfig) error {
 	if err := Unmount(r, target); err != nil {
 		return errors.Wrap(err, "umount")
@@ -102,20 +125,24 @@ func resolveGID(id string) string {
 
 // mntCmd returns a mount command based on a config.
 func mntCmd(source string, target string, c *MountConfig) string {
-	options := map[string]string{
-		"dfltgid": resolveGID(c.GID),
-		"dfltuid": resolveUID(c.UID),
-		"trans":   "tcp",
-	}
-
-	if c.Port != 0 {
-		options["port"] = strconv.Itoa(c.Port)
-	}
-	if c.Version != "" {
-		options["version"] = c.Version
-	}
-	if c.MSize != 0 {
-		options["msize"] = strconv.Itoa(c.MSize)
+	options := map[string]string{}
+	if c.Type == MountTypeNFS {
+		// the export maps all files to the host user, so there is no uid or gid to pass on
+		options["vers"] = "3"
+		options["nolock"] = ""
+	} else {
+		options["dfltgid"] = resolveGID(c.GID)
+		options["dfltuid"] = resolveUID(c.UID)
+		options["trans"] = "tcp"
+		if c.Port != 0 {
+			options["port"] = strconv.Itoa(c.Port)
+		}
+		if c.Version != "" {
+			options["version"] = c.Version
+		}
+		if c.MSize != 0 {
+			options["msize"] = strconv.Itoa(c.MSize)
+		}
 	}
 
 	// Copy in all of the user-supplied keys and values
@@ -123,7 +150,11 @@ func mntCmd(source string, target string, c *MountConfig) string {
 		options[k] = v
 	}
 
-	// Convert everything into a sorted list for better test results
+	return fmt.Sprintf("sudo mount -t %s -o %s %s %s", c.Type, joinOptions(options), source, target)
+}
+
+// joinOptions converts mount options into a sorted list for better test results
+func joinOptions(options map[string]string) string {
 	opts := []string{}
 	for k, v := range options {
 		// Mount option with no value, such as "noextend"
@@ -134,7 +165,7 @@ func mntCmd(source string, target string, c *MountConfig) string {
 		opts = append(opts, fmt.Sprintf("%s=%s", k, v))
 	}
 	sort.Strings(opts)
-	return fmt.Sprintf("sudo mount -t %s -o %s %s %s", c.Type, strings.Join(opts, ","), source, target)
+	return strings.Join(opts, ",")
 }
 
 // Unmount unmounts a path
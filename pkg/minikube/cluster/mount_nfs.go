@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cluster
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+// exportsFile is the exports(5) file of the NFS server of the host
+const exportsFile = "/etc/exports"
+
+// MountNFS exports source of the host at hostIP to the node of profile at nodeIP, and mounts it into target.
+// The exports file is only rewritten if the export changed, as rewriting it requires sudo.
+func MountNFS(r mountRunner, profile string, hostIP string, nodeIP string, source string, target string, c *MountConfig) error {
+	if runtime.GOOS == "windows" {
+		return errors.New("NFS mounts are not supported on Windows")
+	}
+	if strings.ContainsAny(source, " \t") {
+		return errors.Errorf("NFS can not export %q, as it contains whitespace", source)
+	}
+	if err := exportNFS(exportID(profile, target), nfsExport(runtime.GOOS, source, nodeIP, os.Getuid(), os.Getgid())); err != nil {
+		return errors.Wrap(err, "export")
+	}
+	return Mount(r, fmt.Sprintf("%s:%s", hostIP, source), target, c)
+}
+
+// UnexportNFS removes the export mounted into target of profile from the exports file, if it is there
+func UnexportNFS(profile string, target string) error {
+	exports, err := readExports()
+	if err != nil {
+		return err
+	}
+	updated := removeExport(exports, exportID(profile, target))
+	if bytes.Equal(exports, updated) {
+		return nil
+	}
+	return writeExports(updated)
+}
+
+// nfsExport returns the exports(5) entry sharing source with clientIP, mapping all files to uid and gid
+func nfsExport(goos string, source string, clientIP string, uid int, gid int) string {
+	if goos == "darwin" {
+		return fmt.Sprintf("%s %s -alldirs -mapall=%d:%d", source, clientIP, uid, gid)
+	}
+	// insecure, as the ports of the guest NAT are above 1024
+	return fmt.Sprintf("%s %s(rw,async,no_subtree_check,insecure,all_squash,anonuid=%d,anongid=%d)", source, clientIP, uid, gid)
+}
+
+// exportID identifies the export mounted into target of profile in the exports file
+func exportID(profile string, target string) string {
+	return fmt.Sprintf("%s %s", profile, target)
+}
+
+func exportNFS(id string, entry string) error {
+	exports, err := readExports()
+	if err != nil {
+		return err
+	}
+	updated := addExport(exports, id, entry)
+	if bytes.Equal(exports, updated) {
+		klog.Infof("%s already exports %q", exportsFile, entry)
+		return nil
+	}
+	return writeExports(updated)
+}
+
+func readExports() ([]byte, error) {
+	exports, err := ioutil.ReadFile(exportsFile)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrapf(err, "read %s", exportsFile)
+	}
+	return exports, nil
+}
+
+// writeExports replaces the exports file and reloads the NFS server of the host
+func writeExports(exports []byte) error {
+	klog.Infof("Updating %s, which requires sudo", exportsFile)
+	c := exec.Command("sudo", "tee", exportsFile)
+	c.Stdin = bytes.NewReader(exports)
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return errors.Wrapf(err, "write %s", exportsFile)
+	}
+
+	reload := exec.Command("sudo", "exportfs", "-ra")
+	if runtime.GOOS == "darwin" {
+		reload = exec.Command("sudo", "nfsd", "restart")
+	}
+	reload.Stderr = os.Stderr
+	if err := reload.Run(); err != nil {
+		return errors.Wrapf(err, "reload NFS server: %v", reload.Args)
+	}
+	return nil
+}
+
+func exportMarkers(id string) (string, string) {
+	return fmt.Sprintf("# BEGIN minikube %s", id), fmt.Sprintf("# END minikube %s", id)
+}
+
+// addExport returns exports with entry between the markers of id, replacing what was there
+func addExport(exports []byte, id string, entry string) []byte {
+	begin, end := exportMarkers(id)
+	updated := removeExport(exports, id)
+	if len(updated) > 0 && !bytes.HasSuffix(updated, []byte("\n")) {
+		updated = append(updated, '\n')
+	}
+	return append(updated, []byte(fmt.Sprintf("%s\n%s\n%s\n", begin, entry, end))...)
+}
+
+// removeExport returns exports without the markers of id and the entry between them
+func removeExport(exports []byte, id string) []byte {
+	begin, end := exportMarkers(id)
+	var b bytes.Buffer
+	inside := false
+	for _, line := range strings.SplitAfter(string(exports), "\n") {
+		switch strings.TrimSpace(line) {
+		case begin:
+			inside = true
+			continue
+		case end:
+			if inside {
+				inside = false
+				continue
+			}
+		}
+		if !inside {
+			b.WriteString(line)
+		}
+	}
+	if inside {
+		// without an end marker, there is no telling which entries are ours
+		return exports
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cluster
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mitchellh/go-ps"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+// MountPidPath returns the file holding the pid of the minikube mount process serving target of profile
+func MountPidPath(profile string, target string) string {
+	name := strings.ReplaceAll(strings.Trim(target, "/"), "/", "-")
+	return localpath.MakeMiniPath("profiles", profile, "mounts", name+".pid")
+}
+
+// WriteMountPid records pid as the minikube mount process serving target of profile
+func WriteMountPid(profile string, target string, pid int) error {
+	p := MountPidPath(profile, target)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return errors.Wrap(err, "mkdir")
+	}
+	return ioutil.WriteFile(p, []byte(strconv.Itoa(pid)), 0o644)
+}
+
+// MountPid returns the pid of the minikube mount process serving target of profile, or 0 if there is none
+func MountPid(profile string, target string) (int, error) {
+	p := MountPidPath(profile, target)
+	data, err := ioutil.ReadFile(p)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Wrap(err, "read pid")
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, errors.Wrap(err, "error parsing pid")
+	}
+	// os.FindProcess does not check if pid is running :(
+	entry, err := ps.FindProcess(pid)
+	if err != nil {
+		return 0, errors.Wrap(err, "ps.FindProcess")
+	}
+	if entry == nil || !strings.HasPrefix(filepath.Base(entry.Executable()), "minikube") {
+		klog.Infof("Stale pid: %d", pid)
+		return 0, os.Remove(p)
+	}
+	return pid, nil
+}
+
+// KillMountProcess kills the minikube mount process serving target of profile, if it is running
+func KillMountProcess(profile string, target string) error {
+	pid, err := MountPid(profile, target)
+	if err != nil || pid == 0 {
+		return err
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return errors.Wrap(err, "os.FindProcess")
+	}
+	klog.Infof("Killing pid %d ...", pid)
+	if err := proc.Kill(); err != nil {
+		return errors.Wrapf(err, "Kill(%d)", pid)
+	}
+	return os.Remove(MountPidPath(profile, target))
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/util/retry"
+)
+
+// sftpServerPaths are where OpenSSH installs its sftp-server on common host systems
+var sftpServerPaths = []string{
+	"/usr/lib/openssh/sftp-server",
+	"/usr/libexec/openssh/sftp-server",
+	"/usr/lib/ssh/sftp-server",
+	"/usr/libexec/sftp-server",
+}
+
+// ErrSSHFSNotInstalled is returned when the node has no sshfs, as nodes created from an older ISO or kicbase image
+var ErrSSHFSNotInstalled = errors.New("sshfs is not installed in the node")
+
+// SSHFS is a running sshfs mount. sshfs runs within the node and reads from the sftp-server of the host
+// through the stdin and stdout of the ssh connection to the node, so the mount lasts as long as both processes.
+type SSHFS struct {
+	server *exec.Cmd
+	client *exec.Cmd
+}
+
+// StartSSHFS mounts source of the host into target of the node, running sshfs over ssh with sshArgs
+func StartSSHFS(r mountRunner, sshBinary string, sshArgs []string, source string, target string, c *MountConfig) (*SSHFS, error) {
+	server, err := sftpServer()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.RunCmd(exec.Command("which", "sshfs")); err != nil {
+		klog.Infof("which sshfs: %v", err)
+		return nil, ErrSSHFSNotInstalled
+	}
+	if err := Unmount(r, target); err != nil {
+		return nil, errors.Wrap(err, "umount")
+	}
+	if _, err := r.RunCmd(exec.Command("/bin/bash", "-c", fmt.Sprintf("sudo mkdir -m %o -p %s", c.Mode, target))); err != nil {
+		return nil, errors.Wrap(err, "create folder pre-mount")
+	}
+
+	args := append([]string{}, sshArgs...)
+	args = append(args, sshfsCmd(source, target, c))
+	s := &SSHFS{
+		server: exec.Command(server),
+		client: exec.Command(sshBinary, args...),
+	}
+	if s.server.Stdin, err = s.client.StdoutPipe(); err != nil {
+		return nil, errors.Wrap(err, "ssh stdout")
+	}
+	if s.client.Stdin, err = s.server.StdoutPipe(); err != nil {
+		return nil, errors.Wrap(err, "sftp-server stdout")
+	}
+	s.client.Stderr = os.Stderr
+
+	klog.Infof("Starting %v", s.server.Args)
+	if err := s.server.Start(); err != nil {
+		return nil, errors.Wrap(err, "start sftp-server")
+	}
+	klog.Infof("Starting %v", s.client.Args)
+	if err := s.client.Start(); err != nil {
+		s.Stop()
+		return nil, errors.Wrap(err, "start ssh")
+	}
+
+	mounted := func() error {
+		_, err := r.RunCmd(exec.Command("/bin/bash", "-c", fmt.Sprintf("findmnt -T %s | grep %s", target, target)))
+		return err
+	}
+	if err := retry.Local(mounted, 30*time.Second); err != nil {
+		s.Stop()
+		return nil, errors.Wrap(err, "wait for sshfs")
+	}
+	return s, nil
+}
+
+// Wait waits for the ssh connection to close, and stops the sftp-server of the host
+func (s *SSHFS) Wait() error {
+	err := s.client.Wait()
+	if kerr := s.server.Process.Kill(); kerr != nil {
+		klog.Warningf("unable to stop sftp-server: %v", kerr)
+	}
+	_ = s.server.Wait()
+	return err
+}
+
+// Stop closes the ssh connection and stops the sftp-server of the host, which makes sshfs exit
+func (s *SSHFS) Stop() {
+	for _, c := range []*exec.Cmd{s.client, s.server} {
+		if c.Process == nil {
+			continue
+		}
+		if err := c.Process.Kill(); err != nil {
+			klog.Warningf("unable to stop %s: %v", c.Path, err)
+		}
+	}
+}
+
+// sshfsCmd returns the sshfs command run within the node, which reads from its stdin rather than connecting itself
+func sshfsCmd(source string, target string, c *MountConfig) string {
+	options := map[string]string{
+		"slave":       "",
+		"allow_other": "",
+		"gid":         resolveGID(c.GID),
+		"uid":         resolveUID(c.UID),
+	}
+	for k, v := range c.Options {
+		options[k] = v
+	}
+	return fmt.Sprintf("sudo sshfs :%s %s -o %s", source, target, joinOptions(options))
+}
+
+func sftpServer() (string, error) {
+	for _, p := range sftpServerPaths {
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		}
+	}
+	return "", errors.Errorf("no sftp-server found in %v, please install OpenSSH", sftpServerPaths)
+}
@@ -64,6 +64,15 @@ func TestMntCmd(t *testing.T) {
 			}},
 			want: "sudo mount -t 9p -o dfltgid=0,dfltuid=0,trans=tcp,version=9p2000.L src tgt",
 		},
+		{
+			name:   "nfs",
+			source: "192.168.49.1:/Users/me/src",
+			target: "/src",
+			cfg: &MountConfig{Type: "nfs", Mode: os.FileMode(0755), UID: "docker", GID: "docker", Port: 12345, Options: map[string]string{
+				"vers": "4",
+			}},
+			want: "sudo mount -t nfs -o nolock,vers=4 192.168.49.1:/Users/me/src /src",
+		},
 	}
 
 	for _, tc := range tests {
@@ -76,3 +85,80 @@ func TestMntCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestSSHFSCmd(t *testing.T) {
+	cfg := &MountConfig{Type: "sshfs", UID: "docker", GID: "1000", Options: map[string]string{"cache": "yes"}}
+	got := sshfsCmd("/home/me/src", "/src", cfg)
+	want := "sudo sshfs :/home/me/src /src -o allow_other,cache=yes,gid=1000,slave,uid=$(id -u docker)"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("command diff (-want +got): %s", diff)
+	}
+}
+
+func TestMountOptions(t *testing.T) {
+	got := MountOptions([]string{"cache=fscache", "noextend", "opt=a=b"})
+	want := map[string]string{"cache": "fscache", "noextend": "", "opt": "a=b"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("options diff (-want +got): %s", diff)
+	}
+}
+
+func TestNFSExport(t *testing.T) {
+	tests := []struct {
+		goos string
+		want string
+	}{
+		{"linux", "/home/me/src 192.168.49.2(rw,async,no_subtree_check,insecure,all_squash,anonuid=1000,anongid=100)"},
+		{"darwin", "/home/me/src 192.168.49.2 -alldirs -mapall=1000:100"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.goos, func(t *testing.T) {
+			got := nfsExport(tc.goos, "/home/me/src", "192.168.49.2", 1000, 100)
+			if got != tc.want {
+				t.Errorf("nfsExport(%s) = %q, want %q", tc.goos, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAddRemoveExport(t *testing.T) {
+	existing := "/srv 10.0.0.0/8(ro)"
+	exports := addExport([]byte(existing), "p1 /src", "/a 1.2.3.4")
+	exports = addExport(exports, "p2 /src", "/b 1.2.3.5")
+	want := `/srv 10.0.0.0/8(ro)
+# BEGIN minikube p1 /src
+/a 1.2.3.4
+# END minikube p1 /src
+# BEGIN minikube p2 /src
+/b 1.2.3.5
+# END minikube p2 /src
+`
+	if diff := cmp.Diff(want, string(exports)); diff != "" {
+		t.Errorf("add diff (-want +got): %s", diff)
+	}
+
+	// replacing an export keeps a single copy of it
+	replaced := addExport(exports, "p1 /src", "/c 1.2.3.4")
+	want = `/srv 10.0.0.0/8(ro)
+# BEGIN minikube p2 /src
+/b 1.2.3.5
+# END minikube p2 /src
+# BEGIN minikube p1 /src
+/c 1.2.3.4
+# END minikube p1 /src
+`
+	if diff := cmp.Diff(want, string(replaced)); diff != "" {
+		t.Errorf("replace diff (-want +got): %s", diff)
+	}
+
+	removed := removeExport(removeExport(replaced, "p1 /src"), "p2 /src")
+	if diff := cmp.Diff(existing+"\n", string(removed)); diff != "" {
+		t.Errorf("remove diff (-want +got): %s", diff)
+	}
+
+	// without an end marker, nothing is removed
+	broken := []byte("# BEGIN minikube p1 /src\n/a 1.2.3.4\n/srv 10.0.0.0/8(ro)\n")
+	if got := removeExport(broken, "p1 /src"); string(got) != string(broken) {
+		t.Errorf("removeExport of unterminated block = %q, want it unchanged", got)
+	}
+}
@@ -17,11 +17,16 @@ limitations under the License.
 package cluster
 
 import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/cruntime"
 	"k8s.io/minikube/pkg/minikube/sysinit"
 	"k8s.io/minikube/pkg/util/retry"
@@ -103,3 +108,43 @@ func unpause(cr cruntime.Manager, r command.Runner, namespaces []string) ([]stri
 
 	return ids, nil
 }
+
+// AutoPauseStatus is the state reported by the /status endpoint of the auto-pause service
+type AutoPauseStatus struct {
+	Paused     bool     `json:"paused"`
+	Runtime    string   `json:"runtime"`
+	Namespaces []string `json:"namespaces"`
+	Interval   string   `json:"interval"`
+}
+
+// ErrAutoPauseStatusUnsupported is returned when the auto-pause service predates the /status endpoint,
+// as on nodes created from an older ISO or kicbase image
+var ErrAutoPauseStatusUnsupported = errors.New("auto-pause service does not report its status")
+
+// GetAutoPauseStatus queries the auto-pause service running on the node
+func GetAutoPauseStatus(r command.Runner) (*AutoPauseStatus, error) {
+	rr, err := r.RunCmd(exec.Command("curl", "-sS", "--max-time", "5", "--write-out", "\n%{http_code}", fmt.Sprintf("http://127.0.0.1:%d/status", constants.AutoPausePort)))
+	if err != nil {
+		return nil, errors.Wrap(err, "auto-pause status")
+	}
+
+	// the last line is the HTTP status code written by curl
+	body := strings.TrimSpace(rr.Stdout.String())
+	code := ""
+	if i := strings.LastIndex(body, "\n"); i >= 0 {
+		body, code = body[:i], body[i+1:]
+	}
+	switch code {
+	case "200":
+	case "404":
+		return nil, ErrAutoPauseStatusUnsupported
+	default:
+		return nil, errors.Errorf("auto-pause status returned %q: %s", code, body)
+	}
+
+	st := &AutoPauseStatus{}
+	if err := json.Unmarshal([]byte(body), st); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal %q", body)
+	}
+	return st, nil
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/constants"
+)
+
+func TestGetAutoPauseStatus(t *testing.T) {
+	args := []string{"curl", "-sS", "--max-time", "5", "--write-out", "\n%{http_code}", fmt.Sprintf("http://127.0.0.1:%d/status", constants.AutoPausePort)}
+	key := (&command.RunResult{Args: args}).Command()
+
+	tests := []struct {
+		name    string
+		output  string
+		want    *AutoPauseStatus
+		wantErr error
+	}{
+		{
+			name:   "running",
+			output: `{"paused":false,"runtime":"docker","namespaces":["kube-system"],"interval":"1m0s"}` + "\n200",
+			want:   &AutoPauseStatus{Runtime: "docker", Namespaces: []string{"kube-system"}, Interval: "1m0s"},
+		},
+		{
+			name:    "old service without /status",
+			output:  "404 page not found\n404",
+			wantErr: ErrAutoPauseStatusUnsupported,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := command.NewFakeCommandRunner()
+			r.SetCommandToOutput(map[string]string{key: tc.output})
+
+			got, err := GetAutoPauseStatus(r)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("GetAutoPauseStatus() error = %v, want %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GetAutoPauseStatus() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cni
+
+import (
+	"text/template"
+)
+
+func init() {
+	Register(Definition{
+		Name:        "antrea",
+		DisplayName: "Antrea",
+		// Antrea detects the MTU, and uses the pod CIDR allocated to each node, so its release manifest is applied as is
+		ManifestURL:    template.Must(template.New("antrea").Parse("https://github.com/antrea-io/antrea/releases/download/v{{.Version}}/antrea.yml")),
+		DefaultVersion: "1.0.1",
+		Versions:       map[string]string{"0.13": "0.13.1", "1.0": "1.0.1"},
+		ReadySelector:  "app=antrea,component=antrea-agent",
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"text/template"
 
 	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/minikube/pkg/minikube/assets"
 	"k8s.io/minikube/pkg/minikube/config"
 )
@@ -58,7 +59,7 @@ func (c Bridge) String() string {
 }
 
 func (c Bridge) netconf() (assets.CopyableFile, error) {
-	input := &tmplInput{PodCIDR: DefaultPodCIDR}
+	input := &tmplInput{PodCIDR: podCIDR(c.cc, DefaultPodCIDR)}
 
 	b := bytes.Buffer{}
 	if err := bridgeConf.Execute(&b, input); err != nil {
@@ -90,3 +91,8 @@ func (c Bridge) Apply(r Runner) error {
 func (c Bridge) CIDR() string {
 	return DefaultPodCIDR
 }
+
+// Ready returns nil once the CNI is running on every node, which is as soon as its config has been written
+func (c Bridge) Ready(cs kubernetes.Interface) error {
+	return nil
+}
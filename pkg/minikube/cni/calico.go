@@ -17,15 +17,21 @@ limitations under the License.
 package cni
 
 import (
-	"bytes"
 	"text/template"
-
-	"github.com/pkg/errors"
-	"k8s.io/minikube/pkg/minikube/assets"
-	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
-	"k8s.io/minikube/pkg/minikube/config"
 )
 
+func init() {
+	Register(Definition{
+		Name:           "calico",
+		DisplayName:    "Calico",
+		Manifest:       calicoTmpl,
+		DefaultVersion: "3.14.1",
+		Versions:       map[string]string{"3.14": "3.14.1"},
+		SupportsMTU:    true,
+		ReadySelector:  "k8s-app=calico-node",
+	})
+}
+
 // calicoTmpl is from https://docs.projectcalico.org/manifests/calico.yaml
 var calicoTmpl = template.Must(template.New("calico").Parse(`---
 # Source: calico/templates/calico-config.yaml
@@ -43,7 +49,7 @@ data:
   # Configure the MTU to use for workload interfaces and the
   # tunnels.  For IPIP, set to your network MTU - 20; for VXLAN
   # set to your network MTU - 50.
-  veth_mtu: "1440"
+  veth_mtu: "{{if .MTU}}{{.MTU}}{{else}}1440{{end}}"
 
   # The CNI network configuration to install on each node.  The special
   # values in this config will be automatically populated.
@@ -586,7 +592,7 @@ spec:
         # It can be deleted if this is a fresh installation, or if you have already
         # upgraded to use calico-ipam.
         - name: upgrade-ipam
-          image: calico/cni:v3.14.1
+          image: {{.Repository "calico"}}/cni:v{{.Version}}
           command: ["/opt/cni/bin/calico-ipam", "-upgrade"]
           env:
             - name: KUBERNETES_NODE_NAME
@@ -608,7 +614,7 @@ spec:
         # This container installs the CNI binaries
         # and CNI network config file on each node.
         - name: install-cni
-          image: calico/cni:v3.14.1
+          image: {{.Repository "calico"}}/cni:v{{.Version}}
           command: ["/install-cni.sh"]
           env:
             # Name of the CNI config file to create.
@@ -644,7 +650,7 @@ spec:
         # Adds a Flex Volume Driver that creates a per-pod Unix Domain Socket to allow Dikastes
         # to communicate with Felix over the Policy Sync API.
         - name: flexvol-driver
-          image: calico/pod2daemon-flexvol:v3.14.1
+          image: {{.Repository "calico"}}/pod2daemon-flexvol:v{{.Version}}
           volumeMounts:
           - name: flexvol-driver-host
             mountPath: /host/driver
@@ -655,7 +661,7 @@ spec:
         # container programs network policy and routes on each
         # host.
         - name: calico-node
-          image: {{ .DaemonSetImageName }}
+          image: {{.Repository "calico"}}/node:v{{.Version}}
           env:
             # Use Kubernetes API as the backing datastore.
             - name: DATASTORE_TYPE
@@ -701,8 +707,8 @@ spec:
             # The default IPv4 pool to create on startup if none exists. Pod IPs will be
             # chosen from this range. Changing this value after installation will have
             # no effect. This should fall within --cluster-cidr
-            # - name: CALICO_IPV4POOL_CIDR
-            #   value: "192.168.0.0/16"
+            - name: CALICO_IPV4POOL_CIDR
+              value: "{{.PodCIDR}}"
             # Disable file logging so kubectl logs works.
             - name: CALICO_DISABLE_FILE_LOGGING
               value: "true"
@@ -840,7 +846,7 @@ spec:
       priorityClassName: system-cluster-critical
       containers:
         - name: calico-kube-controllers
-          image: {{ .DeploymentImageName }}
+          image: {{.Repository "calico"}}/kube-controllers:v{{.Version}}
           env:
             # Choose which controllers to run.
             - name: ENABLED_CONTROLLERS
@@ -871,47 +877,3 @@ metadata:
 # Source: calico/templates/configure-canal.yaml
 
 `))
-
-// Calico is the Calico CNI manager
-type Calico struct {
-	cc config.ClusterConfig
-}
-
-type calicoTmplStruct struct {
-	DeploymentImageName string
-	DaemonSetImageName  string
-}
-
-// String returns a string representation of this CNI
-func (c Calico) String() string {
-	return "Calico"
-}
-
-// manifest returns a Kubernetes manifest for a CNI
-func (c Calico) manifest() (assets.CopyableFile, error) {
-	input := &calicoTmplStruct{
-		DeploymentImageName: images.CalicoDeployment(c.cc.KubernetesConfig.ImageRepository),
-		DaemonSetImageName:  images.CalicoDaemonSet(c.cc.KubernetesConfig.ImageRepository),
-	}
-
-	b := bytes.Buffer{}
-	if err := calicoTmpl.Execute(&b, input); err != nil {
-		return nil, err
-	}
-	return manifestAsset(b.Bytes()), nil
-}
-
-// Apply enables the CNI
-func (c Calico) Apply(r Runner) error {
-	m, err := c.manifest()
-	if err != nil {
-		return errors.Wrap(err, "manifest")
-	}
-	return applyManifest(c.cc, r, m)
-}
-
-// CIDR returns the default CIDR used by this CNI
-func (c Calico) CIDR() string {
-	// Calico docs specify 192.168.0.0/16 - but we do this for compatibility with other CNI's.
-	return DefaultPodCIDR
-}
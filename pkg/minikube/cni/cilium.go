@@ -18,13 +18,27 @@ package cni
 
 import (
 	"os/exec"
+	"text/template"
 
 	"github.com/pkg/errors"
 	"k8s.io/minikube/pkg/minikube/config"
 )
 
+func init() {
+	Register(Definition{
+		Name:           "cilium",
+		DisplayName:    "Cilium",
+		Manifest:       ciliumTmpl,
+		DefaultVersion: "1.8.0",
+		Versions:       map[string]string{"1.8": "1.8.0"},
+		SupportsMTU:    true,
+		ReadySelector:  "k8s-app=cilium",
+		Prepare:        mountBPF,
+	})
+}
+
 // From https://raw.githubusercontent.com/cilium/cilium/v1.8/install/kubernetes/quick-install.yaml
-var ciliumTmpl = `---
+var ciliumTmpl = template.Must(template.New("cilium").Parse(`---
 # Source: cilium/charts/agent/templates/serviceaccount.yaml
 apiVersion: v1
 kind: ServiceAccount
@@ -169,6 +183,9 @@ data:
   cluster-pool-ipv4-cidr: "10.0.0.0/8"
   cluster-pool-ipv4-mask-size: "24"
   disable-cnp-status-updates: "true"
+{{- if .MTU}}
+  mtu: "{{.MTU}}"
+{{- end}}
 ---
 # Source: cilium/charts/agent/templates/clusterrole.yaml
 apiVersion: rbac.authorization.k8s.io/v1
@@ -440,7 +457,7 @@ spec:
               key: custom-cni-conf
               name: cilium-config
               optional: true
-        image: "docker.io/cilium/cilium:v1.8.0"
+        image: "docker.io/cilium/cilium:v{{.Version}}"
         imagePullPolicy: IfNotPresent
         lifecycle:
           postStart:
@@ -503,7 +520,7 @@ spec:
               key: wait-bpf-mount
               name: cilium-config
               optional: true
-        image: "docker.io/cilium/cilium:v1.8.0"
+        image: "docker.io/cilium/cilium:v{{.Version}}"
         imagePullPolicy: IfNotPresent
         name: clean-cilium-state
         securityContext:
@@ -641,7 +658,7 @@ spec:
               key: AWS_DEFAULT_REGION
               name: cilium-aws
               optional: true
-        image: "docker.io/cilium/operator-generic:v1.8.0"
+        image: "docker.io/cilium/operator-generic:v{{.Version}}"
         imagePullPolicy: IfNotPresent
         name: cilium-operator
         livenessProbe:
@@ -667,29 +684,13 @@ spec:
       - configMap:
           name: cilium-config
         name: cilium-config-path
-`
+`))
 
-// Cilium is the Cilium CNI manager
-type Cilium struct {
-	cc config.ClusterConfig
-}
-
-// String returns a string representation of this CNI
-func (c Cilium) String() string {
-	return "Cilium"
-}
-
-// Apply enables the CNI
-func (c Cilium) Apply(r Runner) error {
+// mountBPF mounts the BPF filesystem required by Cilium
+func mountBPF(cc config.ClusterConfig, r Runner) error {
 	// see https://kubernetes.io/docs/tasks/administer-cluster/network-policy-provider/cilium-network-policy/
 	if _, err := r.RunCmd(exec.Command("sudo", "/bin/bash", "-c", "grep 'bpffs /sys/fs/bpf' /proc/mounts || sudo mount bpffs -t bpf /sys/fs/bpf")); err != nil {
 		return errors.Wrap(err, "bpf mount")
 	}
-
-	return applyManifest(c.cc, r, manifestAsset([]byte(ciliumTmpl)))
-}
-
-// CIDR returns the default CIDR used by this CNI
-func (c Cilium) CIDR() string {
-	return DefaultPodCIDR
+	return nil
 }
@@ -22,9 +22,11 @@ import (
 	"fmt"
 	"os/exec"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/kapi"
 	"k8s.io/minikube/pkg/minikube/assets"
@@ -53,15 +55,29 @@ type Manager interface {
 	// CIDR returns the default CIDR used by this CNI
 	CIDR() string
 
+	// Ready returns nil once the CNI is running on every node
+	Ready(kubernetes.Interface) error
+
 	// String representation
 	String() string
 }
 
 // tmplInputs are inputs to CNI templates
 type tmplInput struct {
-	ImageName    string
-	PodCIDR      string
-	DefaultRoute string
+	ImageName       string
+	ImageRepository string
+	PodCIDR         string
+	DefaultRoute    string
+	MTU             int
+	Version         string
+}
+
+// Repository returns the image repository to use in place of the default one
+func (t tmplInput) Repository(defaultRepo string) string {
+	if t.ImageRepository != "" {
+		return t.ImageRepository
+	}
+	return defaultRepo
 }
 
 // New returns a new CNI manager
@@ -73,6 +89,21 @@ func New(cc config.ClusterConfig) (Manager, error) {
 
 	klog.Infof("Creating CNI manager for %q", cc.KubernetesConfig.CNI)
 
+	name, version := splitVersion(cc.KubernetesConfig.CNI)
+	if d, ok := registry[name]; ok {
+		return NewTemplated(cc, d, version)
+	}
+
+	switch name {
+	case "", "auto", "false", "kindnet", "true", "bridge":
+		if version != "" {
+			return nil, fmt.Errorf("the %q CNI does not support pinning a version", name)
+		}
+	}
+	if cc.KubernetesConfig.CNIMTU != 0 {
+		return nil, fmt.Errorf("setting the MTU is only supported by %s", strings.Join(mtuCNIs(), ", "))
+	}
+
 	switch cc.KubernetesConfig.CNI {
 	case "", "auto":
 		return chooseDefault(cc), nil
@@ -82,12 +113,6 @@ func New(cc config.ClusterConfig) (Manager, error) {
 		return KindNet{cc: cc}, nil
 	case "bridge":
 		return Bridge{cc: cc}, nil
-	case "calico":
-		return Calico{cc: cc}, nil
-	case "cilium":
-		return Cilium{cc: cc}, nil
-	case "flannel":
-		return Flannel{cc: cc}, nil
 	default:
 		return NewCustom(cc, cc.KubernetesConfig.CNI)
 	}
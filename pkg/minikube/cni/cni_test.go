@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cni
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	apps "k8s.io/api/apps/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		cni     string
+		mtu     int
+		want    string
+		version string
+		wantErr bool
+	}{
+		{cni: "bridge", want: "bridge CNI"},
+		{cni: "calico", want: "Calico", version: "3.14.1"},
+		{cni: "calico@3.14", want: "Calico", version: "3.14.1"},
+		{cni: "calico@v3.14.1", want: "Calico", version: "3.14.1"},
+		{cni: "calico@3.14.0", wantErr: true},
+		{cni: "calico@3.18", wantErr: true},
+		{cni: "weave@2.7", wantErr: true},
+		{cni: "calico@2", wantErr: true},
+		{cni: "weave", mtu: 1400, want: "Weave Net", version: "2.8.1"},
+		{cni: "antrea@0.13", want: "Antrea", version: "0.13.1"},
+		{cni: "flannel", mtu: 1400, wantErr: true},
+		{cni: "kindnet@0.5", wantErr: true},
+		{cni: "kindnet", mtu: 1400, wantErr: true},
+		{cni: "https://example.com/cni.yaml", want: "https://example.com/cni.yaml"},
+		{cni: "/does/not/exist.yaml", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.cni, func(t *testing.T) {
+			cc := config.ClusterConfig{KubernetesConfig: config.KubernetesConfig{CNI: tc.cni, CNIMTU: tc.mtu}}
+			cnm, err := New(cc)
+			if err != nil {
+				if !tc.wantErr {
+					t.Fatalf("New(%q) failed: %v", tc.cni, err)
+				}
+				return
+			}
+			if tc.wantErr {
+				t.Fatalf("New(%q) = %s; want error", tc.cni, cnm)
+			}
+			if cnm.String() != tc.want {
+				t.Errorf("New(%q) = %s; want %s", tc.cni, cnm, tc.want)
+			}
+			if tc.version == "" {
+				return
+			}
+			tm, ok := cnm.(Templated)
+			if !ok {
+				t.Fatalf("New(%q) = %T; want Templated", tc.cni, cnm)
+			}
+			if tm.Version() != tc.version {
+				t.Errorf("New(%q).Version() = %s; want %s", tc.cni, tm.Version(), tc.version)
+			}
+		})
+	}
+}
+
+func TestTemplatedManifest(t *testing.T) {
+	eo := config.ExtraOptionSlice{}
+	if err := eo.Set("kubeadm.pod-network-cidr=10.100.0.0/16"); err != nil {
+		t.Fatalf("extra option: %v", err)
+	}
+	tests := []struct {
+		cni  string
+		mtu  int
+		want []string
+	}{
+		{"calico@3.14", 1400, []string{"image: calico/node:v3.14.1", `veth_mtu: "1400"`, `value: "10.100.0.0/16"`}},
+		{"calico", 0, []string{"image: calico/kube-controllers:v3.14.1", `veth_mtu: "1440"`}},
+		{"cilium", 1400, []string{`image: "docker.io/cilium/cilium:v1.8.0"`, `mtu: "1400"`}},
+		{"flannel@0.12", 0, []string{"image: quay.io/coreos/flannel:v0.12.0-amd64", `"Network": "10.100.0.0/16"`}},
+		{"weave", 0, []string{"image: 'docker.io/weaveworks/weave-npc:2.8.1'", `value: "10.100.0.0/16"`}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.cni, func(t *testing.T) {
+			cc := config.ClusterConfig{KubernetesConfig: config.KubernetesConfig{CNI: tc.cni, CNIMTU: tc.mtu, ExtraOptions: eo}}
+			cnm, err := New(cc)
+			if err != nil {
+				t.Fatalf("New(%q) failed: %v", tc.cni, err)
+			}
+			m, err := cnm.(Templated).manifest()
+			if err != nil {
+				t.Fatalf("manifest() failed: %v", err)
+			}
+			b, err := ioutil.ReadAll(m)
+			if err != nil {
+				t.Fatalf("read manifest: %v", err)
+			}
+			for _, w := range tc.want {
+				if !strings.Contains(string(b), w) {
+					t.Errorf("manifest for %s does not contain %q", tc.cni, w)
+				}
+			}
+		})
+	}
+}
+
+func TestManifestImages(t *testing.T) {
+	manifest := `
+spec:
+  containers:
+    - name: calico-node
+      image: docker.io/calico/node:v3.18.1
+    - image: "docker.io/calico/cni:v3.18.1"
+      name: install-cni
+  initContainers:
+    - name: flexvol
+      image: docker.io/calico/node:v3.18.1
+`
+	want := []string{"docker.io/calico/node:v3.18.1", "docker.io/calico/cni:v3.18.1"}
+	got := manifestImages([]byte(manifest))
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("manifestImages() = %v; want %v", got, want)
+	}
+}
+
+func TestImages(t *testing.T) {
+	cc := config.ClusterConfig{KubernetesConfig: config.KubernetesConfig{CNI: "calico@3.14"}}
+	got, err := Images(cc)
+	if err != nil {
+		t.Fatalf("Images() failed: %v", err)
+	}
+	if !strings.Contains(strings.Join(got, ","), "calico/node:v3.14.1") {
+		t.Errorf("Images() = %v; want calico/node:v3.14.1", got)
+	}
+}
+
+func TestDaemonSetsReady(t *testing.T) {
+	ds := func(name string, app string, desired int32, ready int32) *apps.DaemonSet {
+		return &apps.DaemonSet{
+			ObjectMeta: meta.ObjectMeta{Name: name, Namespace: "kube-system", Labels: map[string]string{"app": app}},
+			Status:     apps.DaemonSetStatus{DesiredNumberScheduled: desired, NumberReady: ready},
+		}
+	}
+	cs := fake.NewSimpleClientset(
+		ds("kube-flannel-ds-amd64", "flannel", 2, 2),
+		ds("kube-flannel-ds-arm64", "flannel", 0, 0),
+		ds("kindnet", "kindnet", 3, 1),
+	)
+
+	tests := []struct {
+		selector string
+		wantErr  bool
+	}{
+		{"app=flannel", false},
+		{"app=kindnet", true},
+		{"app=weave", true},
+	}
+	for _, tc := range tests {
+		err := daemonSetsReady(cs, tc.selector)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("daemonSetsReady(%q) = %v; want error: %t", tc.selector, err, tc.wantErr)
+		}
+	}
+}
@@ -21,11 +21,12 @@ import (
 	"path"
 
 	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/minikube/pkg/minikube/assets"
 	"k8s.io/minikube/pkg/minikube/config"
 )
 
-// Custom is a CNI manager than applies a user-specified manifest
+// Custom is a CNI manager than applies a user-specified manifest, from a path or URL
 type Custom struct {
 	cc       config.ClusterConfig
 	manifest string
@@ -38,9 +39,11 @@ func (c Custom) String() string {
 
 // NewCustom returns a well-formed Custom CNI manager
 func NewCustom(cc config.ClusterConfig, manifest string) (Custom, error) {
-	_, err := os.Stat(manifest)
-	if err != nil {
-		return Custom{}, errors.Wrap(err, "stat")
+	if !isURL(manifest) {
+		_, err := os.Stat(manifest)
+		if err != nil {
+			return Custom{}, errors.Wrap(err, "stat")
+		}
 	}
 
 	return Custom{
@@ -51,6 +54,14 @@ func NewCustom(cc config.ClusterConfig, manifest string) (Custom, error) {
 
 // Apply enables the CNI
 func (c Custom) Apply(r Runner) error {
+	if isURL(c.manifest) {
+		b, err := fetchManifest(c.manifest)
+		if err != nil {
+			return errors.Wrap(err, "manifest")
+		}
+		return applyManifest(c.cc, r, manifestAsset(b))
+	}
+
 	m, err := assets.NewFileAsset(c.manifest, path.Dir(manifestPath()), path.Base(manifestPath()), "0644")
 	if err != nil {
 		return errors.Wrap(err, "manifest")
@@ -63,3 +74,8 @@ func (c Custom) Apply(r Runner) error {
 func (c Custom) CIDR() string {
 	return DefaultPodCIDR
 }
+
+// Ready returns nil once the CNI is running on every node, which is unknown for custom manifests
+func (c Custom) Ready(cs kubernetes.Interface) error {
+	return nil
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package cni
 
 import (
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/driver"
@@ -50,3 +51,8 @@ func (c Disabled) CIDR() string {
 	// Even without any CNI we want our nodes to have spec.PodCIDR set.
 	return DefaultPodCIDR
 }
+
+// Ready returns nil once the CNI is running on every node
+func (c Disabled) Ready(cs kubernetes.Interface) error {
+	return nil
+}
@@ -19,6 +19,7 @@ package cni
 import (
 	"os/exec"
 	"path/filepath"
+	"text/template"
 
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
@@ -26,8 +27,20 @@ import (
 	"k8s.io/minikube/pkg/minikube/driver"
 )
 
+func init() {
+	Register(Definition{
+		Name:           "flannel",
+		DisplayName:    "Flannel",
+		Manifest:       flannelTmpl,
+		DefaultVersion: "0.12.0",
+		Versions:       map[string]string{"0.12": "0.12.0"},
+		ReadySelector:  "app=flannel",
+		Prepare:        prepareFlannel,
+	})
+}
+
 // From https://raw.githubusercontent.com/coreos/flannel/master/Documentation/kube-flannel.yml
-var flannelTmpl = `---
+var flannelTmpl = template.Must(template.New("flannel").Parse(`---
 apiVersion: policy/v1beta1
 kind: PodSecurityPolicy
 metadata:
@@ -154,7 +167,7 @@ data:
     }
   net-conf.json: |
     {
-      "Network": "10.244.0.0/16",
+      "Network": "{{.PodCIDR}}",
       "Backend": {
         "Type": "vxlan"
       }
@@ -198,7 +211,7 @@ spec:
       serviceAccountName: flannel
       initContainers:
       - name: install-cni
-        image: quay.io/coreos/flannel:v0.12.0-amd64
+        image: quay.io/coreos/flannel:v{{.Version}}-amd64
         command:
         - cp
         args:
@@ -212,7 +225,7 @@ spec:
           mountPath: /etc/kube-flannel/
       containers:
       - name: kube-flannel
-        image: quay.io/coreos/flannel:v0.12.0-amd64
+        image: quay.io/coreos/flannel:v{{.Version}}-amd64
         command:
         - /opt/bin/flanneld
         args:
@@ -292,7 +305,7 @@ spec:
       serviceAccountName: flannel
       initContainers:
       - name: install-cni
-        image: quay.io/coreos/flannel:v0.12.0-arm64
+        image: quay.io/coreos/flannel:v{{.Version}}-arm64
         command:
         - cp
         args:
@@ -306,7 +319,7 @@ spec:
           mountPath: /etc/kube-flannel/
       containers:
       - name: kube-flannel
-        image: quay.io/coreos/flannel:v0.12.0-arm64
+        image: quay.io/coreos/flannel:v{{.Version}}-arm64
         command:
         - /opt/bin/flanneld
         args:
@@ -386,7 +399,7 @@ spec:
       serviceAccountName: flannel
       initContainers:
       - name: install-cni
-        image: quay.io/coreos/flannel:v0.12.0-arm
+        image: quay.io/coreos/flannel:v{{.Version}}-arm
         command:
         - cp
         args:
@@ -400,7 +413,7 @@ spec:
           mountPath: /etc/kube-flannel/
       containers:
       - name: kube-flannel
-        image: quay.io/coreos/flannel:v0.12.0-arm
+        image: quay.io/coreos/flannel:v{{.Version}}-arm
         command:
         - /opt/bin/flanneld
         args:
@@ -480,7 +493,7 @@ spec:
       serviceAccountName: flannel
       initContainers:
       - name: install-cni
-        image: quay.io/coreos/flannel:v0.12.0-ppc64le
+        image: quay.io/coreos/flannel:v{{.Version}}-ppc64le
         command:
         - cp
         args:
@@ -494,7 +507,7 @@ spec:
           mountPath: /etc/kube-flannel/
       containers:
       - name: kube-flannel
-        image: quay.io/coreos/flannel:v0.12.0-ppc64le
+        image: quay.io/coreos/flannel:v{{.Version}}-ppc64le
         command:
         - /opt/bin/flanneld
         args:
@@ -574,7 +587,7 @@ spec:
       serviceAccountName: flannel
       initContainers:
       - name: install-cni
-        image: quay.io/coreos/flannel:v0.12.0-s390x
+        image: quay.io/coreos/flannel:v{{.Version}}-s390x
         command:
         - cp
         args:
@@ -588,7 +601,7 @@ spec:
           mountPath: /etc/kube-flannel/
       containers:
       - name: kube-flannel
-        image: quay.io/coreos/flannel:v0.12.0-s390x
+        image: quay.io/coreos/flannel:v{{.Version}}-s390x
         command:
         - /opt/bin/flanneld
         args:
@@ -629,27 +642,17 @@ spec:
         - name: flannel-cfg
           configMap:
             name: kube-flannel-cfg
-`
+`))
 
-// Flannel is the Flannel CNI manager
-type Flannel struct {
-	cc config.ClusterConfig
-}
-
-// String returns a string representation of this CNI
-func (c Flannel) String() string {
-	return "Flannel"
-}
-
-// Apply enables the CNI
-func (c Flannel) Apply(r Runner) error {
+// prepareFlannel checks for the portmap plug-in, and disables the CRI-O bridge which conflicts with Flannel
+func prepareFlannel(cc config.ClusterConfig, r Runner) error {
 	// Mostly applicable to the 'none' driver
 	_, err := r.RunCmd(exec.Command("stat", "/opt/cni/bin/portmap"))
 	if err != nil {
 		return errors.Wrap(err, "required 'portmap' CNI plug-in not found")
 	}
 
-	if driver.IsKIC(c.cc.Driver) {
+	if driver.IsKIC(cc.Driver) {
 		conflict := "/etc/cni/net.d/100-crio-bridge.conf"
 
 		_, err := r.RunCmd(exec.Command("stat", conflict))
@@ -663,11 +666,5 @@ func (c Flannel) Apply(r Runner) error {
 			klog.Errorf("unable to disable %s: %v", conflict, err)
 		}
 	}
-
-	return applyManifest(c.cc, r, manifestAsset([]byte(flannelTmpl)))
-}
-
-// CIDR returns the default CIDR used by this CNI
-func (c Flannel) CIDR() string {
-	return DefaultPodCIDR
+	return nil
 }
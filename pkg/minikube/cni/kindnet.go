@@ -22,6 +22,7 @@ import (
 	"text/template"
 
 	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/minikube/pkg/minikube/assets"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/images"
 	"k8s.io/minikube/pkg/minikube/config"
@@ -156,7 +157,7 @@ func (c KindNet) String() string {
 func (c KindNet) manifest() (assets.CopyableFile, error) {
 	input := &tmplInput{
 		DefaultRoute: "0.0.0.0/0", // assumes IPv4
-		PodCIDR:      DefaultPodCIDR,
+		PodCIDR:      podCIDR(c.cc, DefaultPodCIDR),
 		ImageName:    images.KindNet(c.cc.KubernetesConfig.ImageRepository),
 	}
 
@@ -186,3 +187,8 @@ func (c KindNet) Apply(r Runner) error {
 func (c KindNet) CIDR() string {
 	return DefaultPodCIDR
 }
+
+// Ready returns nil once the CNI is running on every node
+func (c KindNet) Ready(cs kubernetes.Interface) error {
+	return daemonSetsReady(cs, "app=kindnet")
+}
@@ -0,0 +1,384 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cni
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/download"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+// Definition declares a CNI that is deployed by applying a manifest
+type Definition struct {
+	// Name selects the CNI with --cni, optionally followed by @VERSION
+	Name string
+	// DisplayName is shown to the user
+	DisplayName string
+	// Manifest is a template executed with a tmplInput
+	Manifest *template.Template
+	// ManifestURL is a template executed with a tmplInput to find a remote manifest, if Manifest is nil
+	ManifestURL *template.Template
+	// DefaultVersion is used when no version is pinned
+	DefaultVersion string
+	// Versions maps release series, such as "0.13", to the version deployed for them.
+	// A pinned version only changes the image tags of an embedded Manifest, so it lists just the version the Manifest is from.
+	Versions map[string]string
+	// PodCIDR is the default CIDR used by this CNI, or DefaultPodCIDR if empty
+	PodCIDR string
+	// SupportsMTU is true if the manifest honours a custom MTU
+	SupportsMTU bool
+	// ReadySelector is a label selector for the kube-system daemonsets which run the CNI on every node
+	ReadySelector string
+	// Prepare is run on the control plane before the manifest is applied
+	Prepare func(config.ClusterConfig, Runner) error
+}
+
+// registry are the CNIs which may be selected by name
+var registry = map[string]Definition{}
+
+// Register makes a CNI available to --cni
+func Register(d Definition) {
+	if _, ok := registry[d.Name]; ok {
+		klog.Fatalf("CNI %q was registered twice", d.Name)
+	}
+	registry[d.Name] = d
+}
+
+// Registered returns the names of the registered CNIs
+func Registered() []string {
+	names := []string{}
+	for n := range registry {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// mtuCNIs returns the names of the registered CNIs which support setting the MTU
+func mtuCNIs() []string {
+	names := []string{}
+	for _, n := range Registered() {
+		if registry[n].SupportsMTU {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// splitVersion splits a --cni value, such as antrea@0.13, into its name and version
+func splitVersion(cni string) (string, string) {
+	i := strings.LastIndex(cni, "@")
+	if i <= 0 {
+		return cni, ""
+	}
+	return cni[:i], cni[i+1:]
+}
+
+// resolveVersion returns the version of a CNI to deploy for a pinned version, either a
+// release series or the exact version of one of them: manifests exist only for those
+func (d Definition) resolveVersion(pin string) (string, error) {
+	pin = strings.TrimPrefix(pin, "v")
+	if pin == "" {
+		return d.DefaultVersion, nil
+	}
+	if v, ok := d.Versions[pin]; ok {
+		return v, nil
+	}
+	versions := []string{}
+	for _, v := range d.Versions {
+		if v == pin {
+			return v, nil
+		}
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+	return "", fmt.Errorf("unsupported %s version %q, use one of %s", d.Name, pin, strings.Join(versions, ", "))
+}
+
+// Templated is a CNI manager that applies the manifest of a registered CNI
+type Templated struct {
+	cc      config.ClusterConfig
+	def     Definition
+	version string
+}
+
+// NewTemplated returns a CNI manager for a registered CNI, with an optionally pinned version
+func NewTemplated(cc config.ClusterConfig, d Definition, pin string) (Templated, error) {
+	v, err := d.resolveVersion(pin)
+	if err != nil {
+		return Templated{}, err
+	}
+	if cc.KubernetesConfig.CNIMTU != 0 && !d.SupportsMTU {
+		return Templated{}, fmt.Errorf("the %s CNI does not support setting the MTU", d.DisplayName)
+	}
+	return Templated{cc: cc, def: d, version: v}, nil
+}
+
+// String returns a string representation of this CNI
+func (c Templated) String() string {
+	return c.def.DisplayName
+}
+
+// Version returns the version of the CNI that is deployed
+func (c Templated) Version() string {
+	return c.version
+}
+
+// manifest returns a Kubernetes manifest for the CNI
+func (c Templated) manifest() (assets.CopyableFile, error) {
+	input := tmplInput{
+		ImageRepository: c.cc.KubernetesConfig.ImageRepository,
+		PodCIDR:         podCIDR(c.cc, c.CIDR()),
+		MTU:             c.cc.KubernetesConfig.CNIMTU,
+		Version:         c.version,
+	}
+	if c.def.Manifest == nil {
+		u, err := c.manifestURL()
+		if err != nil {
+			return nil, err
+		}
+		b, err := fetchManifest(u)
+		if err != nil {
+			return nil, err
+		}
+		return manifestAsset(b), nil
+	}
+
+	b := bytes.Buffer{}
+	if err := c.def.Manifest.Execute(&b, input); err != nil {
+		return nil, err
+	}
+	return manifestAsset(b.Bytes()), nil
+}
+
+// manifestURL returns the URL of the remote manifest, or "" if the manifest is built in
+func (c Templated) manifestURL() (string, error) {
+	if c.def.Manifest != nil {
+		return "", nil
+	}
+	var u bytes.Buffer
+	if err := c.def.ManifestURL.Execute(&u, tmplInput{Version: c.version}); err != nil {
+		return "", errors.Wrap(err, "manifest url")
+	}
+	return u.String(), nil
+}
+
+// Apply enables the CNI
+func (c Templated) Apply(r Runner) error {
+	if c.def.Prepare != nil {
+		if err := c.def.Prepare(c.cc, r); err != nil {
+			return err
+		}
+	}
+	m, err := c.manifest()
+	if err != nil {
+		return errors.Wrap(err, "manifest")
+	}
+	return applyManifest(c.cc, r, m)
+}
+
+// CIDR returns the default CIDR used by this CNI
+func (c Templated) CIDR() string {
+	if c.def.PodCIDR != "" {
+		return c.def.PodCIDR
+	}
+	return DefaultPodCIDR
+}
+
+// Ready returns nil once the CNI is running on every node
+func (c Templated) Ready(cs kubernetes.Interface) error {
+	return daemonSetsReady(cs, c.def.ReadySelector)
+}
+
+// podCIDR returns the pod CIDR of the cluster, which may be overridden by kubeadm.pod-network-cidr
+func podCIDR(cc config.ClusterConfig, defaultCIDR string) string {
+	if cidr := cc.KubernetesConfig.ExtraOptions.Get("pod-network-cidr", "kubeadm"); cidr != "" {
+		return cidr
+	}
+	return defaultCIDR
+}
+
+// daemonSetsReady returns nil if the kube-system daemonsets matching selector have a ready pod on every node they are scheduled to
+func daemonSetsReady(cs kubernetes.Interface, selector string) error {
+	dss, err := cs.AppsV1().DaemonSets("kube-system").List(meta.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return errors.Wrap(err, "list daemonsets")
+	}
+	var desired, ready int32
+	for _, ds := range dss.Items {
+		desired += ds.Status.DesiredNumberScheduled
+		ready += ds.Status.NumberReady
+	}
+	if desired == 0 {
+		return fmt.Errorf("no pods scheduled for daemonsets matching %q", selector)
+	}
+	if ready < desired {
+		return fmt.Errorf("%d of %d pods ready for daemonsets matching %q", ready, desired, selector)
+	}
+	return nil
+}
+
+// isURL returns if a --cni value is the URL of a manifest
+func isURL(cni string) bool {
+	return strings.HasPrefix(cni, "https://") || strings.HasPrefix(cni, "http://")
+}
+
+// manifestCachePath returns where the manifest downloaded from url is cached
+func manifestCachePath(url string) string {
+	name := strings.NewReplacer("://", "_", "/", "_", ":", "_", "?", "_").Replace(url)
+	return localpath.MakeMiniPath("cache", "cni", name)
+}
+
+// fetchManifest downloads a remote manifest, falling back to the cached copy of the last download
+func fetchManifest(url string) ([]byte, error) {
+	cached := manifestCachePath(url)
+	if download.Offline() {
+		klog.Infof("downloads are disabled, using cached CNI manifest %s", cached)
+		b, err := ioutil.ReadFile(cached)
+		if err != nil {
+			return nil, errors.Wrapf(download.ErrOffline, "CNI manifest %s", url)
+		}
+		return b, nil
+	}
+
+	b, err := getManifest(url)
+	if err != nil {
+		if cb, cerr := ioutil.ReadFile(cached); cerr == nil {
+			klog.Warningf("unable to download CNI manifest, using cached copy %s: %v", cached, err)
+			return cb, nil
+		}
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(cached), 0755); err != nil {
+		klog.Warningf("unable to cache CNI manifest: %v", err)
+	} else if err := ioutil.WriteFile(cached, b, 0644); err != nil {
+		klog.Warningf("unable to cache CNI manifest: %v", err)
+	}
+	return b, nil
+}
+
+// getManifest downloads a remote manifest
+func getManifest(url string) ([]byte, error) {
+	klog.Infof("downloading CNI manifest from %s ...", url)
+	c := &http.Client{Timeout: 30 * time.Second}
+	resp, err := c.Get(url)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get %s", url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get %s: unexpected status %s", url, resp.Status)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read %s", url)
+	}
+	return b, nil
+}
+
+// CacheManifest downloads the remote manifest of the CNI of a cluster into the cache, so that it can be applied
+// without network access. It returns the path of the cached manifest, or "" if the manifest is built into minikube.
+func CacheManifest(cc config.ClusterConfig) (string, error) {
+	m, err := New(cc)
+	if err != nil {
+		return "", err
+	}
+	var url string
+	switch c := m.(type) {
+	case Templated:
+		url, err = c.manifestURL()
+		if err != nil {
+			return "", err
+		}
+	case Custom:
+		if isURL(c.manifest) {
+			url = c.manifest
+		}
+	}
+	if url == "" {
+		return "", nil
+	}
+	if _, err := fetchManifest(url); err != nil {
+		return "", err
+	}
+	return manifestCachePath(url), nil
+}
+
+// Images returns the images deployed by the CNI of a cluster, so that they can be cached ahead of time
+func Images(cc config.ClusterConfig) ([]string, error) {
+	m, err := New(cc)
+	if err != nil {
+		return nil, err
+	}
+	var f assets.CopyableFile
+	var b []byte
+	switch c := m.(type) {
+	case Templated:
+		f, err = c.manifest()
+	case KindNet:
+		f, err = c.manifest()
+	case Custom:
+		if isURL(c.manifest) {
+			b, err = fetchManifest(c.manifest)
+		} else {
+			b, err = ioutil.ReadFile(c.manifest)
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "manifest")
+	}
+	if f != nil {
+		if b, err = ioutil.ReadAll(f); err != nil {
+			return nil, errors.Wrap(err, "read manifest")
+		}
+	}
+	return manifestImages(b), nil
+}
+
+// imageRe matches the images of a manifest
+var imageRe = regexp.MustCompile(`(?m)^\s*(?:-\s+)?image:\s*["']?([^\s"']+)`)
+
+// manifestImages returns the images referenced by a manifest, without duplicates
+func manifestImages(b []byte) []string {
+	images := []string{}
+	seen := map[string]bool{}
+	for _, m := range imageRe.FindAllSubmatch(b, -1) {
+		img := string(m[1])
+		if !seen[img] {
+			seen[img] = true
+			images = append(images, img)
+		}
+	}
+	return images
+}
@@ -19,6 +19,7 @@ package command
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -29,6 +30,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/assets"
 )
 
@@ -63,6 +65,9 @@ type Runner interface {
 	// not all implementors are guaranteed to handle all the properties of cmd.
 	RunCmd(cmd *exec.Cmd) (*RunResult, error)
 
+	// RunCmdContext runs a cmd like RunCmd, but stops it and returns the error of ctx once ctx is done.
+	RunCmdContext(ctx context.Context, cmd *exec.Cmd) (*RunResult, error)
+
 	// StartCmd starts a cmd of exec.Cmd type.
 	// This func in non-blocking, use WaitCmd to block until complete.
 	// Not all implementors are guaranteed to handle all the properties of cmd.
@@ -78,6 +83,66 @@ type Runner interface {
 	Remove(assets.CopyableFile) error
 }
 
+// contextRunner is a Runner whose commands are all run with a context
+type contextRunner struct {
+	Runner
+	ctx context.Context
+}
+
+// WithContext returns a Runner which runs every command of r with RunCmdContext, so that anything using it,
+// such as a container runtime or a bootstrapper, stops once ctx is done.
+func WithContext(ctx context.Context, r Runner) Runner {
+	if cr, ok := r.(*contextRunner); ok {
+		r = cr.Runner
+	}
+	return &contextRunner{Runner: r, ctx: ctx}
+}
+
+// RunCmd runs cmd with the context of the runner
+func (c *contextRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
+	return c.Runner.RunCmdContext(c.ctx, cmd)
+}
+
+// StartCmd starts cmd, unless the context of the runner is done
+func (c *contextRunner) StartCmd(cmd *exec.Cmd) (*StartedCmd, error) {
+	if err := c.ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.Runner.StartCmd(cmd)
+}
+
+// Copy copies a file, unless the context of the runner is done
+func (c *contextRunner) Copy(f assets.CopyableFile) error {
+	if err := c.ctx.Err(); err != nil {
+		return err
+	}
+	return c.Runner.Copy(f)
+}
+
+// runContext runs an already configured local command, killing it once ctx is done
+func runContext(ctx context.Context, cmd *exec.Cmd) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if err := cmd.Process.Kill(); err != nil {
+			klog.Warningf("unable to kill %v: %v", cmd.Args, err)
+		}
+		<-done
+		return ctx.Err()
+	}
+}
+
 // Command returns a human readable command string that does not induce eye fatigue
 func (rr RunResult) Command() string {
 	var sb strings.Builder
@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package command
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := WithContext(ctx, NewExecRunner(false))
+
+	if _, err := r.RunCmd(exec.Command("true")); err != nil {
+		t.Fatalf("RunCmd: %v", err)
+	}
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+	start := time.Now()
+	_, err := r.RunCmd(exec.Command("sleep", "30"))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RunCmd error = %v, want context.Canceled", err)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("RunCmd returned after %s, want it to return once cancelled", d)
+	}
+
+	if _, err := r.RunCmd(exec.Command("true")); !errors.Is(err, context.Canceled) {
+		t.Errorf("RunCmd after cancel error = %v, want context.Canceled", err)
+	}
+}
@@ -18,6 +18,7 @@ package command
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -48,6 +49,11 @@ func NewExecRunner(sudo bool) Runner {
 
 // RunCmd implements the Command Runner interface to run a exec.Cmd object
 func (e *execRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
+	return e.RunCmdContext(context.Background(), cmd)
+}
+
+// RunCmdContext implements the Command Runner interface to run a exec.Cmd object until ctx is done
+func (e *execRunner) RunCmdContext(ctx context.Context, cmd *exec.Cmd) (*RunResult, error) {
 	rr := &RunResult{Args: cmd.Args}
 	klog.Infof("Run: %v", rr.Command())
 
@@ -74,7 +80,7 @@ func (e *execRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
 	cmd.Stderr = errb
 
 	start := time.Now()
-	err := cmd.Run()
+	err := runContext(ctx, cmd)
 	elapsed := time.Since(start)
 
 	if exitError, ok := err.(*exec.ExitError); ok {
@@ -87,6 +93,9 @@ func (e *execRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
 	if err == nil {
 		return rr, nil
 	}
+	if ctx.Err() != nil {
+		return rr, errors.Wrap(ctx.Err(), rr.Command())
+	}
 
 	return rr, fmt.Errorf("%s: %v\nstdout:\n%s\nstderr:\n%s", rr.Command(), err, rr.Stdout.String(), rr.Stderr.String())
 }
@@ -18,6 +18,7 @@ package command
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os/exec"
@@ -90,6 +91,14 @@ func (f *FakeCommandRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
 	return rr, nil
 }
 
+// RunCmdContext implements the Command Runner interface to run a exec.Cmd object, unless ctx is done
+func (f *FakeCommandRunner) RunCmdContext(ctx context.Context, cmd *exec.Cmd) (*RunResult, error) {
+	if err := ctx.Err(); err != nil {
+		return &RunResult{Args: cmd.Args}, err
+	}
+	return f.RunCmd(cmd)
+}
+
 // StartCmd implements the Command Runner interface to start a exec.Cmd object
 func (f *FakeCommandRunner) StartCmd(cmd *exec.Cmd) (*StartedCmd, error) {
 	rr := &RunResult{Args: cmd.Args}
@@ -18,6 +18,7 @@ package command
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -53,6 +54,11 @@ func NewKICRunner(containerNameOrID string, oci string) Runner {
 }
 
 func (k *kicRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
+	return k.RunCmdContext(context.Background(), cmd)
+}
+
+// RunCmdContext runs cmd inside the container, and stops waiting for it once ctx is done
+func (k *kicRunner) RunCmdContext(ctx context.Context, cmd *exec.Cmd) (*RunResult, error) {
 	args := []string{
 		"exec",
 		// run with privileges so we can remount etc..
@@ -116,7 +122,7 @@ func (k *kicRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
 
 	start := time.Now()
 
-	err := oc.Run()
+	err := runContext(ctx, oc)
 	elapsed := time.Since(start)
 	if err == nil {
 		// Reduce log spam
@@ -125,6 +131,9 @@ func (k *kicRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
 		}
 		return rr, nil
 	}
+	if ctx.Err() != nil {
+		return rr, errors.Wrap(ctx.Err(), rr.Command())
+	}
 	if exitError, ok := err.(*exec.ExitError); ok {
 		rr.ExitCode = exitError.ExitCode()
 	}
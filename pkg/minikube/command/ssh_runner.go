@@ -18,6 +18,7 @@ package command
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os/exec"
@@ -141,6 +142,11 @@ func teeSSH(s *ssh.Session, cmd string, outB io.Writer, errB io.Writer) error {
 
 // RunCmd implements the Command Runner interface to run a exec.Cmd object
 func (s *SSHRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
+	return s.RunCmdContext(context.Background(), cmd)
+}
+
+// RunCmdContext implements the Command Runner interface to run a exec.Cmd object, closing its session once ctx is done
+func (s *SSHRunner) RunCmdContext(ctx context.Context, cmd *exec.Cmd) (*RunResult, error) {
 	if cmd.Stdin != nil {
 		return nil, fmt.Errorf("SSHRunner does not support stdin - you could be the first to add it")
 	}
@@ -165,6 +171,9 @@ func (s *SSHRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
 		errb = io.MultiWriter(cmd.Stderr, &rr.Stderr)
 	}
 
+	if err := ctx.Err(); err != nil {
+		return rr, errors.Wrap(err, rr.Command())
+	}
 	sess, err := s.session()
 	if err != nil {
 		return rr, errors.Wrap(err, "NewSession")
@@ -178,8 +187,25 @@ func (s *SSHRunner) RunCmd(cmd *exec.Cmd) (*RunResult, error) {
 		}
 	}()
 
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			klog.Infof("%s: %v, closing the session", rr.Command(), ctx.Err())
+			if err := sess.Signal(ssh.SIGKILL); err != nil {
+				klog.Infof("signal: %v", err)
+			}
+			sess.Close()
+		case <-done:
+		}
+	}()
+
 	err = teeSSH(sess, shellquote.Join(cmd.Args...), outb, errb)
 	elapsed := time.Since(start)
+	if err != nil && ctx.Err() != nil {
+		return rr, errors.Wrap(ctx.Err(), rr.Command())
+	}
 
 	if exitError, ok := err.(*exec.ExitError); ok {
 		rr.ExitCode = exitError.ExitCode()
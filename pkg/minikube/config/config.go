@@ -54,6 +54,8 @@ const (
 	AddonImages = "addon-images"
 	// AddonRegistries stores custom addon images config
 	AddonRegistries = "addon-registries"
+	// MaxAuditFileSizeInMB is the key for the size above which the audit log is rotated
+	MaxAuditFileSizeInMB = "MaxAuditFileSizeInMB"
 )
 
 var (
@@ -233,6 +233,30 @@ func TestMachineName(t *testing.T) {
 			},
 			Want: "p2-m2",
 		},
+
+		{
+			ClusterConfig: ClusterConfig{Name: "ha",
+				Nodes: []Node{
+					{
+						Name:              "",
+						IP:                "192.168.49.2",
+						Port:              8443,
+						KubernetesVersion: "v1.20.2",
+						ControlPlane:      true,
+						Worker:            true,
+					},
+					{
+						Name:              "m02",
+						IP:                "192.168.49.3",
+						Port:              8443,
+						KubernetesVersion: "v1.20.2",
+						ControlPlane:      true,
+						Worker:            true,
+					},
+				},
+			},
+			Want: "ha-m02",
+		},
 	}
 
 	for _, tc := range testsCases {
@@ -242,3 +266,33 @@ func TestMachineName(t *testing.T) {
 		}
 	}
 }
+
+func TestControlPlanes(t *testing.T) {
+	cc := ClusterConfig{
+		Name: "ha",
+		Nodes: []Node{
+			{Name: "", ControlPlane: true, Worker: true},
+			{Name: "m02", ControlPlane: true, Worker: true},
+			{Name: "m03", ControlPlane: false, Worker: true},
+		},
+	}
+
+	cps := ControlPlanes(cc)
+	if len(cps) != 2 || cps[0].Name != "" || cps[1].Name != "m02" {
+		t.Errorf("ControlPlanes() = %+v, want the first two nodes", cps)
+	}
+
+	for i, want := range []bool{true, false, false} {
+		if got := IsPrimaryControlPlane(cc, cc.Nodes[i]); got != want {
+			t.Errorf("IsPrimaryControlPlane(%q) = %t, want %t", cc.Nodes[i].Name, got, want)
+		}
+	}
+
+	if IsHA(cc) {
+		t.Errorf("IsHA() = true without a virtual IP")
+	}
+	cc.KubernetesConfig.APIServerHAVIP = "192.168.49.254"
+	if !IsHA(cc) {
+		t.Errorf("IsHA() = false with a virtual IP")
+	}
+}
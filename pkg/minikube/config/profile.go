@@ -328,8 +328,38 @@ func ProfileFolderPath(profile string, miniHome ...string) string {
 // MachineName returns the name of the machine, as seen by the hypervisor given the cluster and node names
 func MachineName(cc ClusterConfig, n Node) string {
 	// For single node cluster, default to back to old naming
-	if (len(cc.Nodes) == 1 && cc.Nodes[0].Name == n.Name) || n.ControlPlane {
+	if (len(cc.Nodes) == 1 && cc.Nodes[0].Name == n.Name) || IsPrimaryControlPlane(cc, n) {
 		return cc.Name
 	}
 	return fmt.Sprintf("%s-%s", cc.Name, n.Name)
 }
+
+// IsPrimaryControlPlane returns whether n is the first created control plane of the cluster
+func IsPrimaryControlPlane(cc ClusterConfig, n Node) bool {
+	if !n.ControlPlane {
+		return false
+	}
+	for _, cp := range cc.Nodes {
+		if cp.ControlPlane {
+			return cp.Name == n.Name
+		}
+	}
+	// legacy configs have no nodes at all
+	return true
+}
+
+// ControlPlanes returns all the control plane nodes of the cluster
+func ControlPlanes(cc ClusterConfig) []Node {
+	cps := []Node{}
+	for _, n := range cc.Nodes {
+		if n.ControlPlane {
+			cps = append(cps, n)
+		}
+	}
+	return cps
+}
+
+// IsHA returns whether the cluster runs several control planes behind a virtual IP
+func IsHA(cc ClusterConfig) bool {
+	return cc.KubernetesConfig.APIServerHAVIP != ""
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ClusterSpecAPIVersion is the only supported apiVersion of a cluster spec document
+	ClusterSpecAPIVersion = "minikube.sigs.k8s.io/v1alpha1"
+	// ClusterSpecKind is the kind of a cluster spec document
+	ClusterSpecKind = "ClusterConfig"
+)
+
+// ClusterSpec is a versioned document describing a whole ClusterConfig, as used by `minikube start --file`
+type ClusterSpec struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Spec       ClusterConfig `json:"spec"`
+
+	// fields holds the lowercased, dot separated paths of every field present in the document,
+	// with the index of list elements as a path element
+	fields map[string]bool
+}
+
+// NewClusterSpec returns a cluster spec document wrapping cc
+func NewClusterSpec(cc *ClusterConfig) *ClusterSpec {
+	return &ClusterSpec{
+		APIVersion: ClusterSpecAPIVersion,
+		Kind:       ClusterSpecKind,
+		Spec:       *cc,
+	}
+}
+
+// ReadClusterSpec reads and parses a YAML or JSON cluster spec file
+func ReadClusterSpec(path string) (*ClusterSpec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read")
+	}
+	s, err := ParseClusterSpec(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %s", path)
+	}
+	return s, nil
+}
+
+// ParseClusterSpec parses a YAML or JSON cluster spec document
+func ParseClusterSpec(data []byte) (*ClusterSpec, error) {
+	// JSON is a subset of YAML, so a single conversion handles both formats
+	j, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "yaml to json")
+	}
+
+	var s ClusterSpec
+	if err := json.Unmarshal(j, &s); err != nil {
+		return nil, errors.Wrap(err, "unmarshal")
+	}
+	if s.APIVersion != ClusterSpecAPIVersion {
+		return nil, fmt.Errorf("unsupported apiVersion %q, expected %q", s.APIVersion, ClusterSpecAPIVersion)
+	}
+	if s.Kind != ClusterSpecKind {
+		return nil, fmt.Errorf("unsupported kind %q, expected %q", s.Kind, ClusterSpecKind)
+	}
+
+	raw := struct {
+		Spec map[string]interface{} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(j, &raw); err != nil {
+		return nil, errors.Wrap(err, "unmarshal fields")
+	}
+	s.fields = map[string]bool{}
+	collectFields("", raw.Spec, s.fields)
+	return &s, nil
+}
+
+// collectFields records the path of every key in m, descending into nested objects and lists of objects
+func collectFields(prefix string, m map[string]interface{}, fields map[string]bool) {
+	for k, v := range m {
+		path := strings.ToLower(k)
+		if prefix != "" {
+			path = prefix + "." + path
+		}
+		fields[path] = true
+		switch nested := v.(type) {
+		case map[string]interface{}:
+			collectFields(path, nested, fields)
+		case []interface{}:
+			for i, e := range nested {
+				if o, ok := e.(map[string]interface{}); ok {
+					collectFields(fmt.Sprintf("%s.%d", path, i), o, fields)
+				}
+			}
+		}
+	}
+}
+
+// IsSet returns whether a field was present in the parsed document.
+// Paths are dot separated ClusterConfig field names, for example "KubernetesConfig.CNI" or "Nodes.1.Worker".
+func (s *ClusterSpec) IsSet(path string) bool {
+	return s.fields[strings.ToLower(path)]
+}
+
+// Marshal encodes the spec document in the requested format, either "yaml" or "json"
+func (s *ClusterSpec) Marshal(format string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case "json":
+		return json.MarshalIndent(s, "", "    ")
+	case "yaml", "":
+		return yaml.Marshal(s)
+	default:
+		return nil, fmt.Errorf("invalid output format: %s. Valid values: 'yaml', 'json'", format)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+var yamlSpec = `apiVersion: minikube.sigs.k8s.io/v1alpha1
+kind: ClusterConfig
+spec:
+  driver: docker
+  memory: 4096
+  cpus: 4
+  registryMirror:
+  - https://mirror.example.com
+  addons:
+    ingress: true
+  nodes:
+  - name: ""
+    controlPlane: true
+    worker: true
+  - name: m02
+    worker: true
+  kubernetesConfig:
+    kubernetesVersion: v1.20.2
+    containerRuntime: containerd
+    cni: calico
+    extraOptions:
+    - component: kubelet
+      key: max-pods
+      value: "100"
+`
+
+func TestParseClusterSpec(t *testing.T) {
+	s, err := ParseClusterSpec([]byte(yamlSpec))
+	if err != nil {
+		t.Fatalf("ParseClusterSpec: %v", err)
+	}
+
+	cc := s.Spec
+	if cc.Driver != "docker" || cc.Memory != 4096 || cc.CPUs != 4 {
+		t.Errorf("unexpected machine settings: driver=%q memory=%d cpus=%d", cc.Driver, cc.Memory, cc.CPUs)
+	}
+	if len(cc.Nodes) != 2 || !cc.Nodes[0].ControlPlane || cc.Nodes[1].Name != "m02" {
+		t.Errorf("unexpected nodes: %+v", cc.Nodes)
+	}
+	if !cc.Addons["ingress"] {
+		t.Errorf("expected ingress addon to be enabled, got %v", cc.Addons)
+	}
+	if cc.KubernetesConfig.CNI != "calico" || cc.KubernetesConfig.ContainerRuntime != "containerd" {
+		t.Errorf("unexpected kubernetes config: %+v", cc.KubernetesConfig)
+	}
+	expectedOpts := ExtraOptionSlice{{Component: "kubelet", Key: "max-pods", Value: "100"}}
+	if !reflect.DeepEqual(cc.KubernetesConfig.ExtraOptions, expectedOpts) {
+		t.Errorf("ExtraOptions = %v, want %v", cc.KubernetesConfig.ExtraOptions, expectedOpts)
+	}
+
+	for _, tc := range []struct {
+		path string
+		set  bool
+	}{
+		{"Driver", true},
+		{"CPUs", true},
+		{"DiskSize", false},
+		{"KubernetesConfig.CNI", true},
+		{"KubernetesConfig.kubernetesversion", true},
+		{"KubernetesConfig.ServiceCIDR", false},
+		{"Nodes.1.Worker", true},
+		{"Nodes.1.ControlPlane", false},
+	} {
+		if got := s.IsSet(tc.path); got != tc.set {
+			t.Errorf("IsSet(%q) = %t, want %t", tc.path, got, tc.set)
+		}
+	}
+}
+
+func TestParseClusterSpecInvalid(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		data        string
+	}{
+		{"missing apiVersion", "kind: ClusterConfig\nspec:\n  driver: docker\n"},
+		{"wrong apiVersion", "apiVersion: minikube.sigs.k8s.io/v9\nkind: ClusterConfig\n"},
+		{"wrong kind", "apiVersion: minikube.sigs.k8s.io/v1alpha1\nkind: Pod\n"},
+		{"wrong type", "apiVersion: minikube.sigs.k8s.io/v1alpha1\nkind: ClusterConfig\nspec:\n  cpus: many\n"},
+	} {
+		t.Run(tc.description, func(t *testing.T) {
+			if _, err := ParseClusterSpec([]byte(tc.data)); err == nil {
+				t.Errorf("expected an error parsing %q", tc.data)
+			}
+		})
+	}
+}
+
+func TestClusterSpecRoundTrip(t *testing.T) {
+	cc := &ClusterConfig{
+		Name:   "p1",
+		Driver: "kvm2",
+		CPUs:   2,
+		Nodes:  []Node{{Name: "", ControlPlane: true, Worker: true, KubernetesVersion: "v1.20.2"}},
+		KubernetesConfig: KubernetesConfig{
+			KubernetesVersion: "v1.20.2",
+			ContainerRuntime:  "docker",
+		},
+	}
+
+	for _, format := range []string{"yaml", "json"} {
+		t.Run(format, func(t *testing.T) {
+			data, err := NewClusterSpec(cc).Marshal(format)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			s, err := ParseClusterSpec(data)
+			if err != nil {
+				t.Fatalf("ParseClusterSpec: %v\n%s", err, data)
+			}
+			if !reflect.DeepEqual(&s.Spec, cc) {
+				t.Errorf("round trip mismatch: got %+v, want %+v", s.Spec, *cc)
+			}
+		})
+	}
+
+	if _, err := NewClusterSpec(cc).Marshal("toml"); err == nil {
+		t.Errorf("expected an error for an unknown format")
+	}
+}
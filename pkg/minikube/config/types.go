@@ -37,6 +37,7 @@ type ClusterConfig struct {
 	EmbedCerts              bool   // used by kubeconfig.Setup
 	MinikubeISO             string // ISO used for VM-drivers.
 	KicBaseImage            string // base-image used for docker/podman drivers.
+	Rootless                bool   // Only used by container drivers, the docker/podman daemon runs without root privileges
 	Memory                  int
 	CPUs                    int
 	DiskSize                int
@@ -79,6 +80,11 @@ type ClusterConfig struct {
 	ExposedPorts            []string // Only used by the docker and podman driver
 	Network                 string   // only used by docker driver
 	MultiNodeRequested      bool
+	AutoPauseInterval       time.Duration     // Only used by the auto-pause addon
+	AutoPauseNamespaces     []string          // Only used by the auto-pause addon, empty for all namespaces
+	Schedules               []ScheduledAction // recurring and idle-triggered actions, managed by `minikube schedule`
+	OfflineImages           []string          // images from a bundle which are loaded into the nodes, as they can not be pulled
+	Mounts                  []Mount           // host directories mounted on every start, managed by `minikube mount --persistent`
 }
 
 // KubernetesConfig contains the parameters used to configure the VM Kubernetes.
@@ -99,12 +105,24 @@ type KubernetesConfig struct {
 	LoadBalancerStartIP string // currently only used by MetalLB addon
 	LoadBalancerEndIP   string // currently only used by MetalLB addon
 	CustomIngressCert   string // used by Ingress addon
+	APIServerHAVIP      string // virtual IP in front of the API servers of a multi-control-plane cluster
 	ExtraOptions        ExtraOptionSlice
+	KubeadmPatches      []KubeadmPatch // patches applied on top of the generated kubeadm config
+	AuditPolicy         string         // host path of the API server audit policy, or empty to disable auditing
+	AuditLogMaxAge      int            // days to retain old audit log files, or 0 for the API server default
+	AuditLogMaxBackup   int            // number of old audit log files to retain, or 0 for the API server default
+	AuditLogMaxSize     int            // size in megabytes at which the audit log is rotated, or 0 for the API server default
+	OIDCIssuerURL       string         // URL of the OpenID Connect issuer, or empty to disable OIDC authentication
+	OIDCClientID        string         // client ID that OIDC tokens must be issued for
+	OIDCCAFile          string         // host path of the CA that signed the certificate of the OIDC issuer, if not a public CA
+	OIDCUsernameClaim   string         // JWT claim used as the user name, or empty for the API server default
+	OIDCGroupsClaim     string         // JWT claim used as the user's groups
 
 	ShouldLoadCachedImages bool
 
 	EnableDefaultCNI bool   // deprecated in preference to CNI
 	CNI              string // CNI to use
+	CNIMTU           int    // MTU of the pod network, or 0 for the default of the CNI
 
 	// We need to keep these in the short term for backwards compatibility
 	NodeIP   string
@@ -112,6 +130,13 @@ type KubernetesConfig struct {
 	NodeName string
 }
 
+// KubeadmPatch is a patch to one of the documents of the generated kubeadm config
+type KubeadmPatch struct {
+	Target string // kind of the document to patch, e.g. ClusterConfiguration
+	Type   string // "strategic" or "json"
+	Patch  string // the patch, as YAML
+}
+
 // Node contains information about specific nodes in a cluster
 type Node struct {
 	Name              string
@@ -120,6 +145,10 @@ type Node struct {
 	KubernetesVersion string
 	ControlPlane      bool
 	Worker            bool
+	SSHIPAddress      string // Only used by ssh driver, for nodes on a machine other than the one of the cluster
+	SSHUser           string // Only used by ssh driver
+	SSHKey            string // Only used by ssh driver
+	SSHPort           int    // Only used by ssh driver
 }
 
 // VersionedExtraOption holds information on flags to apply to a specific range
@@ -153,3 +182,24 @@ type ScheduledStopConfig struct {
 	InitiationTime int64
 	Duration       time.Duration
 }
+
+// Mount is a host directory which is mounted into the control plane node on every start
+type Mount struct {
+	HostPath  string
+	GuestPath string
+	Type      string   // 9p, sshfs or nfs
+	UID       string   // user name or id which owns the files within the node
+	GID       string   // group name or id which owns the files within the node
+	Mode      uint32   // permissions of the mount point
+	Options   []string // extra mount options, such as cache=fscache
+}
+
+// ScheduledAction is a lifecycle action which runs every time its cron expression matches,
+// or once the API server has been idle for a while. Exactly one of Cron or Idle is set.
+type ScheduledAction struct {
+	Name   string
+	Action string        // stop, pause, unpause or snapshot
+	Cron   string        // standard five field cron expression, in the host's UTC offset
+	Idle   time.Duration // how long the API server must receive no requests from the host
+	Keep   int           // how many snapshots of a snapshot schedule to keep, or 0 to keep them all
+}
@@ -43,6 +43,8 @@ const (
 	APIServerPort = 8443
 	// AutoPauseProxyPort is the port to be used as a reverse proxy for apiserver port
 	AutoPauseProxyPort = 32443
+	// AutoPausePort is the port the auto-pause service listens on inside the node
+	AutoPausePort = 8080
 
 	// SSHPort is the SSH serviceport on the node vm and container
 	SSHPort = 22
@@ -82,8 +84,19 @@ const (
 	// MinikubeActivePodmanEnv holds the podman service that the user's shell is pointing at
 	// value would be profile or empty if pointing to the user's host.
 	MinikubeActivePodmanEnv = "MINIKUBE_ACTIVE_PODMAN"
+	// ContainerdAddressEnv is used for nerdctl and ctr settings
+	ContainerdAddressEnv = "CONTAINERD_ADDRESS"
+	// ContainerdNamespaceEnv is used for nerdctl and ctr settings
+	ContainerdNamespaceEnv = "CONTAINERD_NAMESPACE"
+	// ContainerRuntimeEndpointEnv is used for crictl settings
+	ContainerRuntimeEndpointEnv = "CONTAINER_RUNTIME_ENDPOINT"
+	// MinikubeActiveContainerdEnv holds the containerd service that the user's shell is pointing at
+	// value would be profile or empty if pointing to the user's host.
+	MinikubeActiveContainerdEnv = "MINIKUBE_ACTIVE_CONTAINERD"
 	// MinikubeForceSystemdEnv is used to force systemd as cgroup manager for the container runtime
 	MinikubeForceSystemdEnv = "MINIKUBE_FORCE_SYSTEMD"
+	// MinikubeRootlessEnv opts into running podman as the current user rather than through sudo
+	MinikubeRootlessEnv = "MINIKUBE_ROOTLESS"
 	// TestDiskUsedEnv is used in integration tests for insufficient storage with 'minikube status'
 	TestDiskUsedEnv = "MINIKUBE_TEST_STORAGE_CAPACITY"
 
@@ -23,11 +23,13 @@ import (
 	"fmt"
 	"os/exec"
 	"path"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
 
 	"github.com/blang/semver"
+	"github.com/docker/distribution/reference"
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/assets"
@@ -95,13 +97,15 @@ oom_score = 0
       conf_dir = "/etc/cni/net.d"
       conf_template = ""
     [plugins.cri.registry]
+      {{ if .HostsDir -}}
+      config_path = "{{ .HostsDir }}"
+      {{ else -}}
       [plugins.cri.registry.mirrors]
-        [plugins.cri.registry.mirrors."docker.io"]
-          endpoint = ["https://registry-1.docker.io"]
-        {{ range .InsecureRegistry -}}
-        [plugins.cri.registry.mirrors."{{. -}}"]
-          endpoint = ["http://{{. -}}"]
+        {{ range .Mirrors -}}
+        [plugins.cri.registry.mirrors."{{ .Registry }}"]
+          endpoint = [{{ range $i, $e := .Endpoints }}{{ if $i }}, {{ end }}"{{ $e }}"{{ end }}]
         {{ end -}}
+      {{ end -}}
   [plugins.diff-service]
     default = ["walking"]
   [plugins.linux]
@@ -117,8 +121,19 @@ oom_score = 0
     schedule_delay = "0s"
     startup_delay = "100ms"
 `
+	// containerdHostsDir holds a hosts.toml per registry, used instead of the mirrors table when a registry mirror is set and containerd reads it
+	containerdHostsDir = "/etc/containerd/certs.d"
 )
 
+// containerdHostsDirVersion is the first containerd release reading registry hosts from config_path
+var containerdHostsDirVersion = semver.MustParse("1.5.0")
+
+// containerdMirror is an entry of the mirrors table, its endpoints are tried in order
+type containerdMirror struct {
+	Registry  string
+	Endpoints []string
+}
+
 // Containerd contains containerd runtime state
 type Containerd struct {
 	Socket            string
@@ -127,6 +142,7 @@ type Containerd struct {
 	KubernetesVersion semver.Version
 	Init              sysinit.Manager
 	InsecureRegistry  []string
+	RegistryMirrors   map[string]string
 }
 
 // Name is a human readable name for containerd
@@ -176,8 +192,24 @@ func (r *Containerd) Available() error {
 	return nil
 }
 
-// generateContainerdConfig sets up /etc/containerd/config.toml
-func generateContainerdConfig(cr CommandRunner, imageRepository string, kv semver.Version, forceSystemd bool, insecureRegistry []string) error {
+// hostsDirSupported returns whether containerd reads registry hosts from config_path, older versions ignore it
+func (r *Containerd) hostsDirSupported() bool {
+	v, err := r.Version()
+	if err != nil {
+		klog.Warningf("unable to get containerd version, using the mirrors table: %v", err)
+		return false
+	}
+	sv, err := semver.ParseTolerant(v)
+	if err != nil {
+		klog.Warningf("unable to parse containerd version %q, using the mirrors table: %v", v, err)
+		return false
+	}
+	return sv.GTE(containerdHostsDirVersion)
+}
+
+// generateContainerdConfig sets up /etc/containerd/config.toml. Registry mirrors go to a
+// hosts.toml per registry when hostsDir is set, and to the mirrors table otherwise.
+func generateContainerdConfig(cr CommandRunner, imageRepository string, kv semver.Version, forceSystemd bool, insecureRegistry []string, mirrors map[string]string, hostsDir bool) error {
 	cPath := containerdConfigFile
 	t, err := template.New("containerd.config.toml").Parse(containerdConfigTemplate)
 	if err != nil {
@@ -187,11 +219,15 @@ func generateContainerdConfig(cr CommandRunner, imageRepository string, kv semve
 	opts := struct {
 		PodInfraContainerImage string
 		SystemdCgroup          bool
-		InsecureRegistry       []string
+		Mirrors                []containerdMirror
+		HostsDir               string
 	}{
 		PodInfraContainerImage: pauseImage,
 		SystemdCgroup:          forceSystemd,
-		InsecureRegistry:       insecureRegistry,
+		Mirrors:                containerdMirrors(insecureRegistry, mirrors),
+	}
+	if len(mirrors) > 0 && hostsDir {
+		opts.HostsDir = containerdHostsDir
 	}
 	var b bytes.Buffer
 	if err := t.Execute(&b, opts); err != nil {
@@ -201,9 +237,70 @@ func generateContainerdConfig(cr CommandRunner, imageRepository string, kv semve
 	if _, err := cr.RunCmd(c); err != nil {
 		return errors.Wrap(err, "generate containerd cfg.")
 	}
+	if opts.HostsDir == "" {
+		return nil
+	}
+
+	// the mirrors table can't be combined with config_path, so insecure registries need a hosts.toml too
+	hosts := map[string]string{}
+	for _, reg := range insecureRegistry {
+		hosts[reg] = containerdHostsTOML("http://"+reg, "")
+	}
+	for reg, mirror := range mirrors {
+		server := "https://" + reg
+		if reg == "docker.io" {
+			server = "https://registry-1.docker.io"
+		}
+		hosts[reg] = containerdHostsTOML(server, "http://"+mirror)
+	}
+	for reg, toml := range hosts {
+		hPath := path.Join(containerdHostsDir, reg, "hosts.toml")
+		c := exec.Command("/bin/bash", "-c", fmt.Sprintf("sudo mkdir -p %s && printf %%s \"%s\" | base64 -d | sudo tee %s", path.Dir(hPath), base64.StdEncoding.EncodeToString([]byte(toml)), hPath))
+		if _, err := cr.RunCmd(c); err != nil {
+			return errors.Wrapf(err, "generate %s", hPath)
+		}
+	}
 	return nil
 }
 
+// containerdMirrors returns the mirrors table: docker.io first, then the other registries sorted.
+// Mirrored registries pull from their mirror before falling back to the registry itself.
+func containerdMirrors(insecureRegistry []string, mirrors map[string]string) []containerdMirror {
+	endpoints := map[string][]string{"docker.io": {"https://registry-1.docker.io"}}
+	for _, reg := range insecureRegistry {
+		endpoints[reg] = []string{"http://" + reg}
+	}
+	for reg, mirror := range mirrors {
+		server := "https://" + reg
+		if reg == "docker.io" {
+			server = "https://registry-1.docker.io"
+		}
+		endpoints[reg] = []string{"http://" + mirror, server}
+	}
+
+	regs := []string{}
+	for reg := range endpoints {
+		if reg != "docker.io" {
+			regs = append(regs, reg)
+		}
+	}
+	sort.Strings(regs)
+	table := []containerdMirror{{Registry: "docker.io", Endpoints: endpoints["docker.io"]}}
+	for _, reg := range regs {
+		table = append(table, containerdMirror{Registry: reg, Endpoints: endpoints[reg]})
+	}
+	return table
+}
+
+// containerdHostsTOML returns a hosts.toml which pulls from mirror, if set, before falling back to server
+func containerdHostsTOML(server string, mirror string) string {
+	toml := fmt.Sprintf("server = %q\n", server)
+	if mirror != "" {
+		toml += fmt.Sprintf("\n[host.%q]\n  capabilities = [\"pull\", \"resolve\"]\n", mirror)
+	}
+	return toml
+}
+
 // Enable idempotently enables containerd on a host
 func (r *Containerd) Enable(disOthers, forceSystemd bool) error {
 	if disOthers {
@@ -214,7 +311,8 @@ func (r *Containerd) Enable(disOthers, forceSystemd bool) error {
 	if err := populateCRIConfig(r.Runner, r.SocketPath()); err != nil {
 		return err
 	}
-	if err := generateContainerdConfig(r.Runner, r.ImageRepository, r.KubernetesVersion, forceSystemd, r.InsecureRegistry); err != nil {
+	hostsDir := len(r.RegistryMirrors) > 0 && r.hostsDirSupported()
+	if err := generateContainerdConfig(r.Runner, r.ImageRepository, r.KubernetesVersion, forceSystemd, r.InsecureRegistry, r.RegistryMirrors, hostsDir); err != nil {
 		return err
 	}
 	if err := enableIPForwarding(r.Runner); err != nil {
@@ -249,6 +347,92 @@ func (r *Containerd) LoadImage(path string) error {
 	return nil
 }
 
+// ListImages returns the images present in the runtime
+func (r *Containerd) ListImages() ([]ListImage, error) {
+	return listCRIImages(r.Runner)
+}
+
+// RemoveImage removes an image from the runtime
+func (r *Containerd) RemoveImage(name string) error {
+	return removeCRIImage(r.Runner, name)
+}
+
+// PullImage pulls an image into the runtime from a registry
+func (r *Containerd) PullImage(name string) error {
+	return pullCRIImage(r.Runner, name)
+}
+
+// TagImage adds a tag to an existing image
+func (r *Containerd) TagImage(source string, target string) error {
+	klog.Infof("Tagging image %s as %s", source, target)
+	src, err := containerdImageName(source)
+	if err != nil {
+		return err
+	}
+	dst, err := containerdImageName(target)
+	if err != nil {
+		return err
+	}
+	c := exec.Command("sudo", "ctr", "-n=k8s.io", "images", "tag", "--force", src, dst)
+	if _, err := r.Runner.RunCmd(c); err != nil {
+		return errors.Wrapf(err, "ctr images tag")
+	}
+	return nil
+}
+
+// SaveImage saves an image to a tarball on the host
+func (r *Containerd) SaveImage(name string, path string) error {
+	klog.Infof("Saving image %s to: %s", name, path)
+	ref, err := containerdImageName(name)
+	if err != nil {
+		return err
+	}
+	c := exec.Command("sudo", "ctr", "-n=k8s.io", "images", "export", path, ref)
+	if _, err := r.Runner.RunCmd(c); err != nil {
+		return errors.Wrapf(err, "ctr images export")
+	}
+	return nil
+}
+
+// BuildImage builds an image from a context directory on the host, using buildkit
+func (r *Containerd) BuildImage(src string, file string, tag string) error {
+	klog.Infof("Building image: %s", src)
+	file = buildFile(src, file)
+	if err := r.Init.Start("buildkit"); err != nil {
+		// nodes created by older minikube versions have the buildkit binaries, but no service
+		return errors.Wrap(err, "start buildkit, which requires a node created by this version of minikube")
+	}
+	args := []string{"buildctl", "build",
+		"--frontend", "dockerfile.v0",
+		"--local", fmt.Sprintf("context=%s", src),
+		"--local", fmt.Sprintf("dockerfile=%s", path.Dir(file)),
+		"--opt", fmt.Sprintf("filename=%s", path.Base(file)),
+	}
+	if tag != "" {
+		ref, err := containerdImageName(tag)
+		if err != nil {
+			return err
+		}
+		// buildkitd uses the containerd worker in the k8s.io namespace, so the unpacked image is visible to kubelet
+		args = append(args, "--output", fmt.Sprintf("type=image,name=%s,unpack=true", ref))
+	}
+	c := exec.Command("sudo", args...)
+	if _, err := r.Runner.RunCmd(c); err != nil {
+		return errors.Wrap(err, "buildctl build")
+	}
+	return nil
+}
+
+// containerdImageName returns the fully qualified name ctr expects for an image,
+// for example "busybox" becomes "docker.io/library/busybox:latest"
+func containerdImageName(name string) (string, error) {
+	ref, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return "", errors.Wrapf(err, "parse image name %q", name)
+	}
+	return reference.TagNameOnly(ref).String(), nil
+}
+
 // CGroupDriver returns cgroup driver ("cgroupfs" or "systemd")
 func (r *Containerd) CGroupDriver() (string, error) {
 	info, err := getCRIInfo(r.Runner)
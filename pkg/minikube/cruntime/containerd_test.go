@@ -18,6 +18,8 @@ package cruntime
 
 import (
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestAddRepoTagToImageName(t *testing.T) {
@@ -38,3 +40,41 @@ func TestAddRepoTagToImageName(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerdHostsTOML(t *testing.T) {
+	var tests = []struct {
+		server string
+		mirror string
+		want   string
+	}{
+		{"http://registry.local:5000", "", "server = \"http://registry.local:5000\"\n"},
+		{"https://registry-1.docker.io", "http://host.minikube.internal:5050", `server = "https://registry-1.docker.io"
+
+[host."http://host.minikube.internal:5050"]
+  capabilities = ["pull", "resolve"]
+`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.server, func(t *testing.T) {
+			got := containerdHostsTOML(tc.server, tc.mirror)
+			if got != tc.want {
+				t.Errorf("containerdHostsTOML(%q, %q) = %q, want %q", tc.server, tc.mirror, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestContainerdMirrors(t *testing.T) {
+	got := containerdMirrors([]string{"registry.local:5000", "quay.io"}, map[string]string{
+		"docker.io": "host.minikube.internal:5050",
+		"quay.io":   "host.minikube.internal:5051",
+	})
+	want := []containerdMirror{
+		{Registry: "docker.io", Endpoints: []string{"http://host.minikube.internal:5050", "https://registry-1.docker.io"}},
+		{Registry: "quay.io", Endpoints: []string{"http://host.minikube.internal:5051", "https://quay.io"}},
+		{Registry: "registry.local:5000", Endpoints: []string{"http://registry.local:5000"}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("containerdMirrors() mismatch (-want +got):\n%s", diff)
+	}
+}
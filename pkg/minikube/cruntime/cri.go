@@ -203,6 +203,46 @@ func stopCRIContainers(cr CommandRunner, ids []string) error {
 	return nil
 }
 
+// listCRIImages lists the images known to the CRI image service using crictl
+func listCRIImages(cr CommandRunner) ([]ListImage, error) {
+	crictl := getCrictlPath(cr)
+	rr, err := cr.RunCmd(exec.Command("sudo", crictl, "images", "--output", "json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "crictl images")
+	}
+	jsonImages := struct {
+		Images []ListImage `json:"images"`
+	}{}
+	if err := json.Unmarshal(rr.Stdout.Bytes(), &jsonImages); err != nil {
+		return nil, errors.Wrap(err, "unmarshal images")
+	}
+	return jsonImages.Images, nil
+}
+
+// removeCRIImage removes an image using crictl
+func removeCRIImage(cr CommandRunner, name string) error {
+	klog.Infof("Removing image: %s", name)
+
+	crictl := getCrictlPath(cr)
+	c := exec.Command("sudo", crictl, "rmi", name)
+	if _, err := cr.RunCmd(c); err != nil {
+		return errors.Wrap(err, "crictl")
+	}
+	return nil
+}
+
+// pullCRIImage pulls an image using crictl
+func pullCRIImage(cr CommandRunner, name string) error {
+	klog.Infof("Pulling image: %s", name)
+
+	crictl := getCrictlPath(cr)
+	c := exec.Command("sudo", crictl, "pull", name)
+	if _, err := cr.RunCmd(c); err != nil {
+		return errors.Wrap(err, "crictl")
+	}
+	return nil
+}
+
 // populateCRIConfig sets up /etc/crictl.yaml
 func populateCRIConfig(cr CommandRunner, socket string) error {
 	cPath := "/etc/crictl.yaml"
@@ -17,11 +17,13 @@ limitations under the License.
 package cruntime
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"net"
 	"os/exec"
 	"path"
+	"sort"
 	"strings"
 	"time"
 
@@ -40,6 +42,8 @@ import (
 const (
 	// CRIOConfFile is the path to the CRI-O configuration
 	crioConfigFile = "/etc/crio/crio.conf"
+	// crioMirrorsFile is the registries.conf drop-in which points CRI-O at the registry mirrors
+	crioMirrorsFile = "/etc/containers/registries.conf.d/02-minikube-mirrors.conf"
 )
 
 // CRIO contains CRIO runtime state
@@ -49,6 +53,7 @@ type CRIO struct {
 	ImageRepository   string
 	KubernetesVersion semver.Version
 	Init              sysinit.Manager
+	RegistryMirrors   map[string]string
 }
 
 // generateCRIOConfig sets up /etc/crio/crio.conf
@@ -63,6 +68,39 @@ func generateCRIOConfig(cr CommandRunner, imageRepository string, kv semver.Vers
 	return nil
 }
 
+// generateCRIOMirrors sets up the registries.conf drop-in for mirrors, or removes it if there are none
+func generateCRIOMirrors(cr CommandRunner, mirrors map[string]string) error {
+	if len(mirrors) == 0 {
+		if _, err := cr.RunCmd(exec.Command("sudo", "rm", "-f", crioMirrorsFile)); err != nil {
+			return errors.Wrap(err, "remove crio mirrors")
+		}
+		return nil
+	}
+
+	conf := crioRegistriesConf(mirrors)
+	c := exec.Command("/bin/bash", "-c", fmt.Sprintf("sudo mkdir -p %s && printf %%s \"%s\" | base64 -d | sudo tee %s", path.Dir(crioMirrorsFile), base64.StdEncoding.EncodeToString([]byte(conf)), crioMirrorsFile))
+	if _, err := cr.RunCmd(c); err != nil {
+		return errors.Wrap(err, "generate crio mirrors")
+	}
+	return nil
+}
+
+// crioRegistriesConf returns a registries.conf which pulls from the mirror of each registry before the registry itself
+func crioRegistriesConf(mirrors map[string]string) string {
+	regs := []string{}
+	for reg := range mirrors {
+		regs = append(regs, reg)
+	}
+	sort.Strings(regs)
+
+	var b strings.Builder
+	for _, reg := range regs {
+		fmt.Fprintf(&b, "[[registry]]\nprefix = %q\nlocation = %q\n\n", reg, reg)
+		fmt.Fprintf(&b, "[[registry.mirror]]\nlocation = %q\ninsecure = true\n\n", mirrors[reg])
+	}
+	return b.String()
+}
+
 // Name is a human readable name for CRIO
 func (r *CRIO) Name() string {
 	return "CRI-O"
@@ -142,6 +180,9 @@ func (r *CRIO) Enable(disOthers, _ bool) error {
 	if err := generateCRIOConfig(r.Runner, r.ImageRepository, r.KubernetesVersion); err != nil {
 		return err
 	}
+	if err := generateCRIOMirrors(r.Runner, r.RegistryMirrors); err != nil {
+		return err
+	}
 	if err := enableIPForwarding(r.Runner); err != nil {
 		return err
 	}
@@ -177,6 +218,56 @@ func (r *CRIO) LoadImage(path string) error {
 	return nil
 }
 
+// ListImages returns the images present in the runtime
+func (r *CRIO) ListImages() ([]ListImage, error) {
+	return listCRIImages(r.Runner)
+}
+
+// RemoveImage removes an image from the runtime
+func (r *CRIO) RemoveImage(name string) error {
+	return removeCRIImage(r.Runner, name)
+}
+
+// PullImage pulls an image into the runtime from a registry
+func (r *CRIO) PullImage(name string) error {
+	return pullCRIImage(r.Runner, name)
+}
+
+// TagImage adds a tag to an existing image
+func (r *CRIO) TagImage(source string, target string) error {
+	klog.Infof("Tagging image %s as %s", source, target)
+	c := exec.Command("sudo", "podman", "tag", source, target)
+	if _, err := r.Runner.RunCmd(c); err != nil {
+		return errors.Wrap(err, "crio tag image")
+	}
+	return nil
+}
+
+// SaveImage saves an image to a tarball on the host
+func (r *CRIO) SaveImage(name string, path string) error {
+	klog.Infof("Saving image %s to: %s", name, path)
+	c := exec.Command("sudo", "podman", "save", "-o", path, name)
+	if _, err := r.Runner.RunCmd(c); err != nil {
+		return errors.Wrap(err, "crio save image")
+	}
+	return nil
+}
+
+// BuildImage builds an image from a context directory on the host
+func (r *CRIO) BuildImage(src string, file string, tag string) error {
+	klog.Infof("Building image: %s", src)
+	args := []string{"podman", "build"}
+	if tag != "" {
+		args = append(args, "-t", tag)
+	}
+	args = append(args, "-f", buildFile(src, file), src)
+	c := exec.Command("sudo", args...)
+	if _, err := r.Runner.RunCmd(c); err != nil {
+		return errors.Wrap(err, "crio build image")
+	}
+	return nil
+}
+
 // CGroupDriver returns cgroup driver ("cgroupfs" or "systemd")
 func (r *CRIO) CGroupDriver() (string, error) {
 	c := exec.Command("crio", "config")
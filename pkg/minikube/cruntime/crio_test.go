@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cruntime
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+)
+
+func TestCRIORegistriesConf(t *testing.T) {
+	mirrors := map[string]string{
+		"quay.io":   "host.minikube.internal:5053",
+		"docker.io": "host.minikube.internal:5050",
+	}
+	want := `[[registry]]
+prefix = "docker.io"
+location = "docker.io"
+
+[[registry.mirror]]
+location = "host.minikube.internal:5050"
+insecure = true
+
+[[registry]]
+prefix = "quay.io"
+location = "quay.io"
+
+[[registry.mirror]]
+location = "host.minikube.internal:5053"
+insecure = true
+
+`
+	if got := crioRegistriesConf(mirrors); got != want {
+		t.Errorf("crioRegistriesConf() = %q, want %q", got, want)
+	}
+}
+
+// TestCRIORegistriesConfVersion checks that the mirrors drop-in can be loaded alongside the ISO's registries.conf,
+// as containers/image refuses to mix the v1 and v2 formats
+func TestCRIORegistriesConfVersion(t *testing.T) {
+	iso := map[string]interface{}{}
+	if _, err := toml.DecodeFile("../../../deploy/iso/minikube-iso/package/crio-bin/registries.conf", &iso); err != nil {
+		t.Fatalf("decode ISO registries.conf: %v", err)
+	}
+	dropIn := map[string]interface{}{}
+	if _, err := toml.Decode(crioRegistriesConf(map[string]string{"docker.io": "host.minikube.internal:5050"}), &dropIn); err != nil {
+		t.Fatalf("decode mirrors drop-in: %v", err)
+	}
+
+	for name, conf := range map[string]map[string]interface{}{"ISO registries.conf": iso, "mirrors drop-in": dropIn} {
+		if _, ok := conf["registries"]; ok {
+			t.Errorf("%s uses the v1 [registries.*] tables, which can not be mixed with the v2 format", name)
+		}
+	}
+	if _, ok := dropIn["registry"]; !ok {
+		t.Errorf("mirrors drop-in has no [[registry]] tables: %v", dropIn)
+	}
+}
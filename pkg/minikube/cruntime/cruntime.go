@@ -18,8 +18,10 @@ limitations under the License.
 package cruntime
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
+	"path"
 
 	"github.com/blang/semver"
 	"k8s.io/klog/v2"
@@ -56,6 +58,8 @@ type CommandRunner interface {
 	// RunCmd is a blocking method that runs a command
 	// Use this if you don't need to stream stdout and stderr in real-time
 	RunCmd(cmd *exec.Cmd) (*command.RunResult, error)
+	// RunCmdContext is RunCmd, which stops the command once ctx is done
+	RunCmdContext(ctx context.Context, cmd *exec.Cmd) (*command.RunResult, error)
 	// StartCmd is a non-blocking method that starts a command
 	// Use WaitCmd to block until the command is complete
 	// Use this if you need to stream stdout and/or stderr in real-time
@@ -97,6 +101,18 @@ type Manager interface {
 
 	// ImageExists takes image name and image sha checks if an it exists
 	ImageExists(string, string) bool
+	// ListImages returns the images present in the runtime
+	ListImages() ([]ListImage, error)
+	// RemoveImage removes an image from the runtime
+	RemoveImage(string) error
+	// PullImage pulls an image into the runtime from a registry
+	PullImage(string) error
+	// TagImage adds a tag (second argument) to an existing image (first argument)
+	TagImage(string, string) error
+	// SaveImage saves an image (first argument) to a tarball on the host (second argument)
+	SaveImage(string, string) error
+	// BuildImage builds an image from a context directory, with an optional Dockerfile path, relative to the context directory, and tag
+	BuildImage(string, string, string) error
 
 	// ListContainers returns a list of managed by this container runtime
 	ListContainers(ListOptions) ([]string, error)
@@ -132,6 +148,17 @@ type Config struct {
 	KubernetesVersion semver.Version
 	// InsecureRegistry list of insecure registries
 	InsecureRegistry []string
+	// RegistryMirrors maps registries, such as docker.io, to the host:port of a plain HTTP mirror to pull through
+	RegistryMirrors map[string]string
+}
+
+// ListImage is an image known to a container runtime
+type ListImage struct {
+	ID          string   `json:"id"`
+	RepoDigests []string `json:"repoDigests"`
+	RepoTags    []string `json:"repoTags"`
+	// Size is the image size in bytes
+	Size string `json:"size"`
 }
 
 // ListOptions are the options to use for listing containers
@@ -165,6 +192,7 @@ func New(c Config) (Manager, error) {
 			ImageRepository:   c.ImageRepository,
 			KubernetesVersion: c.KubernetesVersion,
 			Init:              sm,
+			RegistryMirrors:   c.RegistryMirrors,
 		}, nil
 	case "containerd":
 		return &Containerd{
@@ -174,12 +202,24 @@ func New(c Config) (Manager, error) {
 			KubernetesVersion: c.KubernetesVersion,
 			Init:              sm,
 			InsecureRegistry:  c.InsecureRegistry,
+			RegistryMirrors:   c.RegistryMirrors,
 		}, nil
 	default:
 		return nil, fmt.Errorf("unknown runtime type: %q", c.Type)
 	}
 }
 
+// buildFile returns the path of the Dockerfile of a build, relative paths being relative to the context directory src
+func buildFile(src string, file string) string {
+	if file == "" {
+		file = "Dockerfile"
+	}
+	if path.IsAbs(file) {
+		return file
+	}
+	return path.Join(src, file)
+}
+
 // ContainerStatusCommand works across container runtimes with good formatting
 func ContainerStatusCommand() string {
 	// Fallback to 'docker ps' if it fails (none driver)
@@ -18,6 +18,7 @@ package cruntime
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -185,6 +186,14 @@ func buffer(s string, err error) (*command.RunResult, error) {
 	return rr, err
 }
 
+// RunCmdContext runs a fake command, unless ctx is done
+func (f *FakeRunner) RunCmdContext(ctx context.Context, cmd *exec.Cmd) (*command.RunResult, error) {
+	if err := ctx.Err(); err != nil {
+		return &command.RunResult{Args: cmd.Args}, err
+	}
+	return f.RunCmd(cmd)
+}
+
 // Run a fake command!
 func (f *FakeRunner) RunCmd(cmd *exec.Cmd) (*command.RunResult, error) {
 	xargs := cmd.Args
@@ -311,6 +320,11 @@ func (f *FakeRunner) docker(args []string, _ bool) (string, error) {
 	case "inspect":
 		return f.dockerInspect(args)
 
+	case "images":
+		return "sha256:aaa k8s.gcr.io/pause 3.2 sha256:bbb 683kB\n" +
+			"sha256:aaa registry.local/pause 3.2 <none> 683kB\n" +
+			"sha256:ccc <none> <none> <none> 1.2MB\n", nil
+
 	case "info":
 
 		if args[1] == "--format" && args[2] == "{{.CgroupDriver}}" {
@@ -374,6 +388,27 @@ func (f *FakeRunner) crictl(args []string, _ bool) (string, error) {
 		  },
 		  "golang": "go1.11.13"
 		}`, nil
+	case "images":
+		return `{
+		  "images": [
+		    {
+		      "id": "sha256:aaa",
+		      "repoTags": ["k8s.gcr.io/pause:3.2", "registry.local/pause:3.2"],
+		      "repoDigests": ["k8s.gcr.io/pause@sha256:bbb"],
+		      "size": "683000",
+		      "uid": null,
+		      "username": ""
+		    },
+		    {
+		      "id": "sha256:ccc",
+		      "repoTags": [],
+		      "repoDigests": [],
+		      "size": "1200000",
+		      "uid": null,
+		      "username": ""
+		    }
+		  ]
+		}`, nil
 	case "ps":
 		fmt.Printf("args %d: %v\n", len(args), args)
 		if len(args) != 4 {
@@ -712,3 +747,89 @@ func TestContainerFunctions(t *testing.T) {
 		})
 	}
 }
+
+func TestListImages(t *testing.T) {
+	want := []ListImage{
+		{
+			ID:          "sha256:aaa",
+			RepoTags:    []string{"k8s.gcr.io/pause:3.2", "registry.local/pause:3.2"},
+			RepoDigests: []string{"k8s.gcr.io/pause@sha256:bbb"},
+			Size:        "683000",
+		},
+		{
+			ID:          "sha256:ccc",
+			RepoTags:    []string{},
+			RepoDigests: []string{},
+			Size:        "1200000",
+		},
+	}
+	for _, runtime := range []string{"docker", "crio", "containerd"} {
+		t.Run(runtime, func(t *testing.T) {
+			cr, err := New(Config{Type: runtime, Runner: NewFakeRunner(t)})
+			if err != nil {
+				t.Fatalf("New(%s): %v", runtime, err)
+			}
+			got, err := cr.ListImages()
+			if err != nil {
+				t.Fatalf("ListImages: %v", err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("ListImages(%s) diff (-want +got):\n%s", runtime, diff)
+			}
+		})
+	}
+}
+
+func TestImageCommands(t *testing.T) {
+	var tests = []struct {
+		runtime string
+		op      string
+		want    []string
+	}{
+		{"docker", "rm", []string{"docker", "rmi", "busybox"}},
+		{"docker", "pull", []string{"docker", "pull", "busybox"}},
+		{"docker", "tag", []string{"docker", "tag", "busybox", "mybox:1"}},
+		{"docker", "save", []string{"sudo", "docker", "save", "-o", "/tmp/busybox.tar", "busybox"}},
+		{"docker", "build", []string{"docker", "build", "-t", "mybox:1", "-f", "/tmp/ctx/Dockerfile.dev", "/tmp/ctx"}},
+		{"crio", "rm", []string{"which", "crictl", "sudo", "/usr/bin/crictl", "rmi", "busybox"}},
+		{"crio", "pull", []string{"which", "crictl", "sudo", "/usr/bin/crictl", "pull", "busybox"}},
+		{"crio", "tag", []string{"sudo", "podman", "tag", "busybox", "mybox:1"}},
+		{"crio", "save", []string{"sudo", "podman", "save", "-o", "/tmp/busybox.tar", "busybox"}},
+		{"crio", "build", []string{"sudo", "podman", "build", "-t", "mybox:1", "-f", "/tmp/ctx/Dockerfile.dev", "/tmp/ctx"}},
+		{"containerd", "rm", []string{"which", "crictl", "sudo", "/usr/bin/crictl", "rmi", "busybox"}},
+		{"containerd", "pull", []string{"which", "crictl", "sudo", "/usr/bin/crictl", "pull", "busybox"}},
+		{"containerd", "tag", []string{"sudo", "ctr", "-n=k8s.io", "images", "tag", "--force", "docker.io/library/busybox:latest", "docker.io/library/mybox:1"}},
+		{"containerd", "save", []string{"sudo", "ctr", "-n=k8s.io", "images", "export", "/tmp/busybox.tar", "docker.io/library/busybox:latest"}},
+		{"containerd", "build", []string{"sudo", "systemctl", "daemon-reload", "sudo", "systemctl", "start", "buildkit",
+			"sudo", "buildctl", "build", "--frontend", "dockerfile.v0", "--local", "context=/tmp/ctx", "--local", "dockerfile=/tmp/ctx", "--opt", "filename=Dockerfile.dev",
+			"--output", "type=image,name=docker.io/library/mybox:1,unpack=true"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.runtime+"-"+tc.op, func(t *testing.T) {
+			runner := NewFakeRunner(t)
+			runner.services["buildkit"] = SvcExited
+			cr, err := New(Config{Type: tc.runtime, Runner: runner})
+			if err != nil {
+				t.Fatalf("New(%s): %v", tc.runtime, err)
+			}
+			switch tc.op {
+			case "rm":
+				err = cr.RemoveImage("busybox")
+			case "pull":
+				err = cr.PullImage("busybox")
+			case "tag":
+				err = cr.TagImage("busybox", "mybox:1")
+			case "save":
+				err = cr.SaveImage("busybox", "/tmp/busybox.tar")
+			case "build":
+				err = cr.BuildImage("/tmp/ctx", "Dockerfile.dev", "mybox:1")
+			}
+			if err != nil {
+				t.Fatalf("%s %s: %v", tc.runtime, tc.op, err)
+			}
+			if diff := cmp.Diff(tc.want, runner.cmds); diff != "" {
+				t.Errorf("%s %s commands diff (-want +got):\n%s", tc.runtime, tc.op, diff)
+			}
+		})
+	}
+}
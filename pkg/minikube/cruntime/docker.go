@@ -20,10 +20,12 @@ import (
 	"fmt"
 	"os/exec"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/blang/semver"
+	units "github.com/docker/go-units"
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/assets"
@@ -172,6 +174,108 @@ func (r *Docker) LoadImage(path string) error {
 	return nil
 }
 
+// ListImages returns the images present in the runtime
+func (r *Docker) ListImages() ([]ListImage, error) {
+	c := exec.Command("docker", "images", "--no-trunc", "--format", "{{.ID}} {{.Repository}} {{.Tag}} {{.Digest}} {{.Size}}")
+	rr, err := r.Runner.RunCmd(c)
+	if err != nil {
+		return nil, errors.Wrap(err, "docker images")
+	}
+	return parseDockerImages(rr.Stdout.String())
+}
+
+// parseDockerImages parses the output of 'docker images', which has one line per tag, into a list of images
+func parseDockerImages(output string) ([]ListImage, error) {
+	result := []ListImage{}
+	byID := map[string]int{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("unexpected docker images output: %q", line)
+		}
+		id, repo, tag, digest := fields[0], fields[1], fields[2], fields[3]
+		i, ok := byID[id]
+		if !ok {
+			size, err := units.FromHumanSize(fields[4])
+			if err != nil {
+				return nil, errors.Wrapf(err, "size of %s", id)
+			}
+			result = append(result, ListImage{ID: id, RepoTags: []string{}, RepoDigests: []string{}, Size: strconv.FormatInt(size, 10)})
+			i = len(result) - 1
+			byID[id] = i
+		}
+		if repo == "<none>" {
+			continue
+		}
+		if tag != "<none>" {
+			result[i].RepoTags = append(result[i].RepoTags, repo+":"+tag)
+		}
+		if digest != "<none>" {
+			result[i].RepoDigests = append(result[i].RepoDigests, repo+"@"+digest)
+		}
+	}
+	return result, nil
+}
+
+// RemoveImage removes an image from the runtime
+func (r *Docker) RemoveImage(name string) error {
+	klog.Infof("Removing image: %s", name)
+	c := exec.Command("docker", "rmi", name)
+	if _, err := r.Runner.RunCmd(c); err != nil {
+		return errors.Wrap(err, "remove image docker.")
+	}
+	return nil
+}
+
+// PullImage pulls an image into the runtime from a registry
+func (r *Docker) PullImage(name string) error {
+	klog.Infof("Pulling image: %s", name)
+	c := exec.Command("docker", "pull", name)
+	if _, err := r.Runner.RunCmd(c); err != nil {
+		return errors.Wrap(err, "pull image docker.")
+	}
+	return nil
+}
+
+// TagImage adds a tag to an existing image
+func (r *Docker) TagImage(source string, target string) error {
+	klog.Infof("Tagging image %s as %s", source, target)
+	c := exec.Command("docker", "tag", source, target)
+	if _, err := r.Runner.RunCmd(c); err != nil {
+		return errors.Wrap(err, "tag image docker.")
+	}
+	return nil
+}
+
+// SaveImage saves an image to a tarball on the host
+func (r *Docker) SaveImage(name string, path string) error {
+	klog.Infof("Saving image %s to: %s", name, path)
+	// the docker client writes the tarball, so it needs root to write outside of its home directory
+	c := exec.Command("sudo", "docker", "save", "-o", path, name)
+	if _, err := r.Runner.RunCmd(c); err != nil {
+		return errors.Wrap(err, "save image docker.")
+	}
+	return nil
+}
+
+// BuildImage builds an image from a context directory on the host
+func (r *Docker) BuildImage(src string, file string, tag string) error {
+	klog.Infof("Building image: %s", src)
+	args := []string{"build"}
+	if tag != "" {
+		args = append(args, "-t", tag)
+	}
+	args = append(args, "-f", buildFile(src, file), src)
+	c := exec.Command("docker", args...)
+	if _, err := r.Runner.RunCmd(c); err != nil {
+		return errors.Wrap(err, "build image docker.")
+	}
+	return nil
+}
+
 // CGroupDriver returns cgroup driver ("cgroupfs" or "systemd")
 func (r *Docker) CGroupDriver() (string, error) {
 	// Note: the server daemon has to be running, for this call to return successfully
@@ -31,15 +31,32 @@ import (
 
 var (
 	mockMode = false
+	offline  = false
 )
 
+// ErrOffline is returned when a file has to be downloaded, but minikube may not use the network
+var ErrOffline = errors.New("not in the cache, and downloads are disabled")
+
 // EnableMock allows tests to selectively enable if downloads are mocked
 func EnableMock(b bool) {
 	mockMode = b
 }
 
+// SetOffline disables all downloads, everything has to be found in the cache
+func SetOffline(b bool) {
+	offline = b
+}
+
+// Offline returns whether downloads are disabled
+func Offline() bool {
+	return offline
+}
+
 // download is a well-configured atomic download function
 func download(src string, dst string) error {
+	if offline {
+		return errors.Wrapf(ErrOffline, "download %s", src)
+	}
 	progress := getter.WithProgress(DefaultProgressBar)
 	if out.JSON {
 		progress = getter.WithProgress(DefaultJSONOutput)
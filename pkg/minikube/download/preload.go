@@ -41,7 +41,7 @@ const (
 	// PreloadVersion is the current version of the preloaded tarball
 	//
 	// NOTE: You may need to bump this version up when upgrading auxiliary docker images
-	PreloadVersion = "v9"
+	PreloadVersion = "v10"
 	// PreloadBucket is the name of the GCS bucket where preloaded volume tarballs exist
 	PreloadBucket = "minikube-preloaded-volume-tarballs"
 )
@@ -106,6 +106,11 @@ func PreloadExists(k8sVersion, containerRuntime string, forcePreload ...bool) bo
 		return true
 	}
 
+	if offline {
+		klog.Infof("Downloads are disabled, not checking for a remote preload")
+		return false
+	}
+
 	url := remoteTarballURL(k8sVersion, containerRuntime)
 	resp, err := http.Head(url)
 	if err != nil {
@@ -0,0 +1,99 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"fmt"
+	"runtime"
+
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/registry"
+)
+
+const (
+	// the minimums minikube start enforces, a container runtime offering less can not run Kubernetes
+	minDaemonCPUs      = 2
+	minDaemonMemoryMiB = 1800
+)
+
+// KICChecks runs the checks shared by the drivers running Kubernetes in a container
+func KICChecks(ociBin string) []registry.Check {
+	doc := fmt.Sprintf("https://minikube.sigs.k8s.io/docs/drivers/%s/", ociBin)
+	info := registry.Check{Name: fmt.Sprintf("%s daemon info", ociBin), Doc: doc}
+	si, err := oci.DaemonInfo(ociBin)
+	if err != nil {
+		info.Error = err
+		info.Fix = fmt.Sprintf("Make sure the %s service is running and reachable", ociBin)
+		// every other check needs the daemon
+		return []registry.Check{info}
+	}
+	if len(si.Errors) > 0 {
+		info.Error = fmt.Errorf("%s reports errors: %v", ociBin, si.Errors)
+	}
+	checks := []registry.Check{info}
+
+	cpus := registry.Check{Name: fmt.Sprintf("%d CPUs available to %s", si.CPUs, ociBin), Doc: doc}
+	if si.CPUs < minDaemonCPUs {
+		cpus.Error = fmt.Errorf("%s has %d CPUs, Kubernetes needs at least %d", ociBin, si.CPUs, minDaemonCPUs)
+		cpus.Fix = fmt.Sprintf("Give %s more CPUs", ociBin)
+	}
+	checks = append(checks, cpus)
+
+	mem := si.TotalMemory / 1024 / 1024
+	memory := registry.Check{Name: fmt.Sprintf("%dMiB of memory available to %s", mem, ociBin), Doc: doc}
+	if mem < minDaemonMemoryMiB {
+		memory.Error = fmt.Errorf("%s has %dMiB of memory, Kubernetes needs at least %dMiB", ociBin, mem, minDaemonMemoryMiB)
+		memory.Fix = fmt.Sprintf("Give %s more memory", ociBin)
+	}
+	checks = append(checks, memory)
+
+	if runtime.GOOS == "linux" && !oci.IsExternalDaemonHost(ociBin) {
+		cg := registry.Check{Name: "memory cgroup", Doc: "https://docs.docker.com/engine/install/linux-postinstall/#your-kernel-does-not-support-cgroup-swap-limit-capabilities"}
+		if !oci.HasMemoryCgroup() {
+			cg.Error = fmt.Errorf("the kernel does not support memory limits, --memory will be ignored")
+			cg.Warning = true
+			cg.Fix = "Enable the memory cgroup, for example by adding 'cgroup_enable=memory swapaccount=1' to the kernel command line"
+		}
+		checks = append(checks, cg)
+
+		if v2, err := oci.IsCgroup2UnifiedMode(); err == nil {
+			mode := "cgroup v1"
+			if v2 {
+				mode = "cgroup v2"
+			}
+			checks = append(checks, registry.Check{Name: fmt.Sprintf("cgroup hierarchy: %s", mode)})
+		}
+	}
+
+	if si.Rootless {
+		rootless := registry.Check{Name: fmt.Sprintf("rootless %s", ociBin), Doc: "https://rootlesscontaine.rs/getting-started/common/cgroup2/"}
+		if si.CgroupVersion != "2" {
+			rootless.Error = fmt.Errorf("rootless %s needs cgroup v2, the host uses cgroup v%s", ociBin, si.CgroupVersion)
+			rootless.Fix = "Boot the host with systemd.unified_cgroup_hierarchy=1"
+		}
+		checks = append(checks, rootless)
+	}
+
+	storage := registry.Check{Name: fmt.Sprintf("%s storage driver: %s", ociBin, si.StorageDriver)}
+	if si.StorageDriver != "" && si.StorageDriver != "overlay2" && si.StorageDriver != "overlay" {
+		storage.Error = fmt.Errorf("the %s storage driver is slow, and may not support running Kubernetes", si.StorageDriver)
+		storage.Warning = true
+		storage.Fix = fmt.Sprintf("Configure %s to use the overlay2 storage driver", ociBin)
+	}
+	checks = append(checks, storage)
+	return checks
+}
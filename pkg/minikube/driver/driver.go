@@ -93,7 +93,8 @@ func Supported(name string) bool {
 			return true
 		}
 	}
-	return false
+	// plugins are only found on hosts they were installed on
+	return registry.Driver(name).Plugin != ""
 }
 
 // MachineType returns appropriate machine name for the driver
@@ -142,6 +143,9 @@ func IsMock(name string) bool {
 
 // IsVM checks if the driver is a VM
 func IsVM(name string) bool {
+	if k := registry.Driver(name).Kind; k != "" {
+		return k == registry.KindVM
+	}
 	if IsKIC(name) || BareMetal(name) {
 		return false
 	}
@@ -150,7 +154,7 @@ func IsVM(name string) bool {
 
 // BareMetal returns if this driver is unisolated
 func BareMetal(name string) bool {
-	return name == None || name == Mock
+	return name == None || name == Mock || registry.Driver(name).Kind == registry.KindBareMetal
 }
 
 // IsSSH checks if the driver is ssh
@@ -167,7 +171,12 @@ func NeedsPortForward(name string) bool {
 		return true
 	}
 	// Docker for Desktop
-	return runtime.GOOS == "darwin" || runtime.GOOS == "windows" || IsMicrosoftWSL()
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" || IsMicrosoftWSL() {
+		return true
+	}
+	// the network namespace of a rootless daemon is not reachable from the host, see rootlesskit
+	si, err := oci.CachedDaemonInfo(name)
+	return err == nil && si.Rootless
 }
 
 // IsMicrosoftWSL will return true if process is running in WSL in windows
@@ -286,7 +295,14 @@ func Suggest(options []registry.DriverState) (registry.DriverState, []registry.D
 				continue
 			}
 
-			ds.Rejection = fmt.Sprintf("%s is preferred", pick.Name)
+			switch {
+			case !ds.Default:
+				ds.Rejection = fmt.Sprintf("Not used unless asked for with --driver=%s", ds.Name)
+			case ds.Priority <= registry.Discouraged:
+				ds.Rejection = fmt.Sprintf("Not recommended: %s", ds.Priority)
+			default:
+				ds.Rejection = fmt.Sprintf("%s is preferred", pick.Name)
+			}
 			alternates = append(alternates, ds)
 		}
 	}
@@ -310,3 +310,28 @@ func TestIndexFromMachineNameClusterConfig(t *testing.T) {
 
 	}
 }
+
+func TestSuggestRejection(t *testing.T) {
+	healthy := registry.State{Installed: true, Healthy: true}
+	options := []registry.DriverState{
+		{Name: "preferred", Default: true, Priority: registry.Preferred, State: healthy},
+		{Name: "default", Default: true, Priority: registry.Default, State: healthy},
+		{Name: "optin", Default: false, Priority: registry.Default, State: healthy},
+		{Name: "discouraged", Default: true, Priority: registry.Discouraged, State: healthy},
+		{Name: "missing", Default: true, Priority: registry.Default, State: registry.State{Error: fmt.Errorf("not found")}},
+	}
+	_, alts, rejects := Suggest(options)
+	got := map[string]string{}
+	for _, ds := range append(alts, rejects...) {
+		got[ds.Name] = ds.Rejection
+	}
+	want := map[string]string{
+		"default":     "preferred is preferred",
+		"optin":       "Not used unless asked for with --driver=optin",
+		"discouraged": "Not recommended: discouraged",
+		"missing":     "Not installed: not found",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("rejections mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -27,9 +27,32 @@ import (
 )
 
 // ControlPlaneEndpoint returns the location where callers can reach this cluster
+// For multi-control-plane clusters this is the virtual IP in front of the API servers, if the host can reach it.
 func ControlPlaneEndpoint(cc *config.ClusterConfig, cp *config.Node, driverName string) (string, net.IP, int, error) {
+	if config.IsHA(*cc) && !NeedsPortForward(driverName) {
+		vip := cc.KubernetesConfig.APIServerHAVIP
+		hostname := vip
+		if cc.KubernetesConfig.APIServerName != constants.APIServerName {
+			hostname = cc.KubernetesConfig.APIServerName
+		}
+		ip := net.ParseIP(vip)
+		if ip == nil {
+			return hostname, ip, cp.Port, fmt.Errorf("failed to parse ip for %q", vip)
+		}
+		return hostname, ip, cp.Port, nil
+	}
+	return endpoint(cc, cp, driverName, cc.Name)
+}
+
+// NodeEndpoint returns the location of the API server running on the control plane node n, bypassing any virtual IP
+func NodeEndpoint(cc *config.ClusterConfig, n *config.Node, driverName string) (string, net.IP, int, error) {
+	return endpoint(cc, n, driverName, config.MachineName(*cc, *n))
+}
+
+// endpoint returns the location of the API server of the control plane running on machine
+func endpoint(cc *config.ClusterConfig, cp *config.Node, driverName string, machine string) (string, net.IP, int, error) {
 	if NeedsPortForward(driverName) {
-		port, err := oci.ForwardedPort(cc.Driver, cc.Name, cp.Port)
+		port, err := oci.ForwardedPort(cc.Driver, machine, cp.Port)
 		if err != nil {
 			klog.Warningf("failed to get forwarded control plane port %v", err)
 		}
@@ -22,6 +22,7 @@ import (
 	"runtime"
 
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/audit"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/reason"
 	"k8s.io/minikube/pkg/minikube/style"
@@ -54,6 +55,7 @@ func Message(r reason.Kind, format string, args ...out.V) {
 		out.Error(r, "Exiting due to {{.fatal_code}}: {{.fatal_msg}}", args...)
 	}
 
+	audit.LogExit(r.ExitCode)
 	os.Exit(r.ExitCode)
 }
 
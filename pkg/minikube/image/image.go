@@ -37,6 +37,7 @@ import (
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/download"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/localpath"
 )
@@ -138,6 +139,9 @@ func Tag(img string) string {
 // WriteImageToDaemon write img to the local docker daemon
 func WriteImageToDaemon(img string) error {
 	klog.Infof("Writing %s to local daemon", img)
+	if download.Offline() {
+		return errors.Wrapf(download.ErrOffline, "pull %s", img)
+	}
 	ref, err := name.ParseReference(img)
 	if err != nil {
 		return errors.Wrap(err, "parsing reference")
@@ -176,6 +180,10 @@ func retrieveImage(ref name.Reference) (v1.Image, error) {
 		klog.Infof("daemon lookup for %+v: %v", ref, err)
 	}
 
+	if download.Offline() {
+		return nil, errors.Wrapf(download.ErrOffline, "pull %s", ref.Name())
+	}
+
 	platform := defaultPlatform
 	img, err = remote.Image(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithPlatform(platform))
 	if err == nil {
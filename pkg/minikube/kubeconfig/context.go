@@ -17,6 +17,8 @@ limitations under the License.
 package kubeconfig
 
 import (
+	"strings"
+
 	"github.com/pkg/errors"
 	"k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/klog/v2"
@@ -79,6 +81,20 @@ func DeleteContext(machineName string, configPath ...string) error {
 	delete(kcfg.AuthInfos, machineName)
 	delete(kcfg.Contexts, machineName)
 
+	// contexts of the users added with AddUser
+	for name, ctx := range kcfg.Contexts {
+		if !strings.HasSuffix(name, "@"+machineName) {
+			continue
+		}
+		delete(kcfg.Contexts, name)
+		if !authInfoInUse(kcfg, ctx.AuthInfo) {
+			delete(kcfg.AuthInfos, ctx.AuthInfo)
+		}
+		if kcfg.CurrentContext == name {
+			kcfg.CurrentContext = ""
+		}
+	}
+
 	if kcfg.CurrentContext == machineName {
 		kcfg.CurrentContext = ""
 	}
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+// OIDCAuthInfo returns a user that gets its tokens from the OIDC issuer with the kubectl oidc-login plugin
+func OIDCAuthInfo(issuerURL, clientID, clientSecret, caFile string) *api.AuthInfo {
+	args := []string{
+		"oidc-login",
+		"get-token",
+		"--oidc-issuer-url=" + issuerURL,
+		"--oidc-client-id=" + clientID,
+	}
+	if clientSecret != "" {
+		args = append(args, "--oidc-client-secret="+clientSecret)
+	}
+	if caFile != "" {
+		args = append(args, "--certificate-authority="+caFile)
+	}
+	user := api.NewAuthInfo()
+	user.Exec = &api.ExecConfig{
+		APIVersion: "client.authentication.k8s.io/v1beta1",
+		Command:    "kubectl",
+		Args:       args,
+	}
+	return user
+}
+
+// AddUser adds a user, and a context for it on the cluster of the given context, both named user@contextName.
+// It returns the name of the new context, which is not made the current context. Existing entries are kept.
+func AddUser(contextName string, user string, authInfo *api.AuthInfo, configPath ...string) (string, error) {
+	fPath := PathFromEnv()
+	if configPath != nil {
+		fPath = configPath[0]
+	}
+	kcfg, err := readOrNew(fPath)
+	if err != nil {
+		return "", errors.Wrap(err, "Error getting kubeconfig status")
+	}
+
+	name, err := addUser(kcfg, contextName, user, authInfo)
+	if err != nil {
+		return "", errors.Wrapf(err, "adding user to %s", fPath)
+	}
+
+	if err := writeToFile(kcfg, fPath); err != nil {
+		return "", errors.Wrap(err, "writing kubeconfig")
+	}
+	return name, nil
+}
+
+// addUser adds the user and its context to kcfg
+func addUser(kcfg *api.Config, contextName string, user string, authInfo *api.AuthInfo) (string, error) {
+	ctx, ok := kcfg.Contexts[contextName]
+	if !ok {
+		return "", fmt.Errorf("context %q does not exist", contextName)
+	}
+
+	// named after the cluster too, so that the same user of another profile, or one the kubeconfig came with, is left alone
+	name := fmt.Sprintf("%s@%s", user, contextName)
+	if _, ok := kcfg.AuthInfos[name]; ok {
+		return "", fmt.Errorf("user %q already exists", name)
+	}
+	if _, ok := kcfg.Contexts[name]; ok {
+		return "", fmt.Errorf("context %q already exists", name)
+	}
+	kcfg.AuthInfos[name] = authInfo
+	context := api.NewContext()
+	context.Cluster = ctx.Cluster
+	context.AuthInfo = name
+	context.Namespace = ctx.Namespace
+	kcfg.Contexts[name] = context
+	return name, nil
+}
+
+// authInfoInUse returns whether any context uses the user
+func authInfoInUse(kcfg *api.Config, user string) bool {
+	for _, ctx := range kcfg.Contexts {
+		if ctx.AuthInfo == user {
+			return true
+		}
+	}
+	return false
+}
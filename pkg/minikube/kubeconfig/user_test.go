@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeconfig
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+var kubeConfigWithOIDCUser = []byte(`
+apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority: /home/la-croix/apiserver.crt
+    server: 192.168.1.1:8080
+  name: la-croix
+contexts:
+- context:
+    cluster: la-croix
+    user: la-croix
+  name: la-croix
+- context:
+    cluster: la-croix
+    user: jane@la-croix
+  name: jane@la-croix
+current-context: jane@la-croix
+kind: Config
+preferences: {}
+users:
+- name: la-croix
+  user:
+    client-certificate: /home/la-croix/apiserver.crt
+    client-key: /home/la-croix/apiserver.key
+- name: jane@la-croix
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: kubectl
+      args: ["oidc-login", "get-token"]
+`)
+
+func TestAddUser(t *testing.T) {
+	kcfg, err := decode(kubeConfigWithoutHTTPS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	user := OIDCAuthInfo("https://dex.example.com", "kubernetes", "", "/home/la-croix/dex-ca.crt")
+	name, err := addUser(kcfg, "la-croix", "jane", user)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if name != "jane@la-croix" {
+		t.Errorf("expected context jane@la-croix, got %s", name)
+	}
+
+	ctx, ok := kcfg.Contexts[name]
+	if !ok {
+		t.Fatalf("context %s was not added: %v", name, kcfg.Contexts)
+	}
+	if ctx.Cluster != "la-croix" || ctx.AuthInfo != "jane@la-croix" {
+		t.Errorf("unexpected context: %+v", ctx)
+	}
+	if kcfg.CurrentContext != "la-croix" {
+		t.Errorf("current context changed to %s", kcfg.CurrentContext)
+	}
+	exec := kcfg.AuthInfos["jane@la-croix"].Exec
+	if exec == nil {
+		t.Fatalf("user jane@la-croix has no exec plugin")
+	}
+	expected := []string{"oidc-login", "get-token", "--oidc-issuer-url=https://dex.example.com", "--oidc-client-id=kubernetes", "--certificate-authority=/home/la-croix/dex-ca.crt"}
+	if diff := cmp.Diff(expected, exec.Args); diff != "" {
+		t.Errorf("exec args mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := addUser(kcfg, "not-a-context", "jane", user); err == nil {
+		t.Errorf("expected an error for a missing context")
+	}
+	if _, err := addUser(kcfg, "la-croix", "jane", api.NewAuthInfo()); err == nil {
+		t.Errorf("expected an error for an existing user")
+	}
+	if kcfg.AuthInfos["jane@la-croix"].Exec == nil {
+		t.Errorf("existing user jane@la-croix was overwritten")
+	}
+}
+
+func TestDeleteContextWithUsers(t *testing.T) {
+	fn := tempFile(t, kubeConfigWithOIDCUser)
+	defer os.Remove(fn)
+	if err := DeleteContext("la-croix", fn); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := readOrNew(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Contexts) != 0 || len(cfg.AuthInfos) != 0 {
+		t.Errorf("expected the added user and context to be deleted, got %v and %v", cfg.Contexts, cfg.AuthInfos)
+	}
+	if cfg.CurrentContext != "" {
+		t.Errorf("expected no current context, got %s", cfg.CurrentContext)
+	}
+}
@@ -99,11 +99,21 @@ func ClientCert(name string) string {
 	return new
 }
 
+// Snapshots returns the path to the directory holding the snapshots of a profile
+func Snapshots(profile string) string {
+	return filepath.Join(MiniPath(), "snapshots", profile)
+}
+
 // PID returns the path to the pid file used by profile for scheduled stop
 func PID(profile string) string {
 	return path.Join(Profile(profile), "pid")
 }
 
+// SchedulePID returns the path to the pid file of the process taking scheduled snapshots of a profile
+func SchedulePID(profile string) string {
+	return path.Join(Profile(profile), "schedule-pid")
+}
+
 // ClientKey returns client certificate path, used by kubeconfig
 func ClientKey(name string) string {
 	new := filepath.Join(Profile(name), "client.key")
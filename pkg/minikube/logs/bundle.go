@@ -0,0 +1,335 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/audit"
+	"k8s.io/minikube/pkg/minikube/bootstrapper"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+// BundleLines is the number of lines collected from each log when bundling, unless specified
+const BundleLines = 5000
+
+// bundleResources are the resources dumped with kubectl get into a bundle
+var bundleResources = []string{
+	"nodes",
+	"namespaces",
+	"pods",
+	"services",
+	"endpoints",
+	"deployments",
+	"daemonsets",
+	"statefulsets",
+	"replicasets",
+	"jobs",
+	"events",
+	"persistentvolumes",
+	"persistentvolumeclaims",
+	"storageclasses",
+}
+
+// redactions match secrets and certificates, and replace them with the value REDACTED
+var redactions = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`(?s)-----BEGIN ([A-Z ]+)-----.*?-----END ([A-Z ]+)-----`), "-----BEGIN $1-----\nREDACTED\n-----END $2-----"},
+	{regexp.MustCompile(`(?i)("[\w-]*(?:password|passwd|secret|token|certificate-data|key-data)[\w-]*"\s*:\s*)"[^"]*"`), `$1"REDACTED"`},
+	{regexp.MustCompile(`(?im)^(\s*[\w-]*(?:password|passwd|secret|token|certificate-data|key-data)[\w-]*:[ \t]*)\S.*$`), "${1}REDACTED"},
+	{regexp.MustCompile(`(?i)(\b[\w-]*(?:password|passwd|secret|token)[\w-]*=)[^\s"',]+`), "${1}REDACTED"},
+	{regexp.MustCompile(`(?i)(authorization:\s*(?:bearer|basic)\s+)\S+`), "${1}REDACTED"},
+	{regexp.MustCompile(`(?i)(\bbearer\s+)[\w.~+/-]{16,}=*`), "${1}REDACTED"},
+	{regexp.MustCompile(`(\b[a-zA-Z][a-zA-Z0-9+.-]*://)[^/\s:@"']+:[^/\s@"']*@`), "${1}REDACTED@"},
+}
+
+// Redact replaces secrets and certificates in logs with the value REDACTED
+func Redact(b []byte) []byte {
+	for _, r := range redactions {
+		b = r.re.ReplaceAll(b, []byte(r.repl))
+	}
+	return b
+}
+
+// BundleNode is a running node to collect logs from
+type BundleNode struct {
+	Name         string
+	ControlPlane bool
+	Runtime      cruntime.Manager
+	Bootstrapper bootstrapper.Bootstrapper
+	Runner       command.Runner
+}
+
+// bundle is a gzipped tar archive with redacted contents
+type bundle struct {
+	dir    string
+	tw     *tar.Writer
+	failed []string
+}
+
+// add writes a redacted file to the bundle
+func (b *bundle) add(name string, data []byte) error {
+	data = Redact(data)
+	h := &tar.Header{
+		Name:    path.Join(b.dir, name),
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := b.tw.WriteHeader(h); err != nil {
+		return errors.Wrapf(err, "write header for %s", name)
+	}
+	if _, err := b.tw.Write(data); err != nil {
+		return errors.Wrapf(err, "write %s", name)
+	}
+	return nil
+}
+
+// run adds the output of a command run on a node to the bundle, recording it as failed on error
+func (b *bundle) run(runner command.Runner, name string, c *exec.Cmd) error {
+	var out bytes.Buffer
+	c.Stdout = &out
+	c.Stderr = &out
+	rr, err := runner.RunCmd(c)
+	if err != nil {
+		klog.Errorf("command %s failed with error: %v output: %q", rr.Command(), err, rr.Output())
+		b.failed = append(b.failed, name)
+		fmt.Fprintf(&out, "\n%s failed: %v\n", rr.Command(), err)
+	}
+	return b.add(name, out.Bytes())
+}
+
+// Bundle writes the logs of every node, cluster state and host diagnostics into a gzipped tar archive
+func Bundle(cfg config.ClusterConfig, nodes []BundleNode, lines int, file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return errors.Wrap(err, "create bundle")
+	}
+	defer f.Close()
+	gw := gzip.NewWriter(f)
+	b := &bundle{
+		dir: fmt.Sprintf("minikube-logs-%s-%s", cfg.Name, time.Now().Format("20060102-150405")),
+		tw:  tar.NewWriter(gw),
+	}
+
+	clusterState := false
+	for _, n := range nodes {
+		klog.Infof("Bundling logs for node %s ...", n.Name)
+		cmds := logCommands(n.Runtime, n.Bootstrapper, cfg, lines, false)
+		cmds["kernel"] = "uptime && uname -a && grep PRETTY /etc/os-release"
+		names := []string{}
+		for k := range cmds {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			c := exec.Command("/bin/bash", "-c", cmds[name])
+			if err := b.run(n.Runner, path.Join(n.Name, fileName(name)+".log"), c); err != nil {
+				return err
+			}
+		}
+		if n.ControlPlane && !clusterState {
+			if err := b.addClusterState(cfg, n.Runner); err != nil {
+				return err
+			}
+			clusterState = true
+		}
+	}
+
+	if err := b.addHostInfo(cfg, nodes); err != nil {
+		return err
+	}
+
+	if err := b.tw.Close(); err != nil {
+		return errors.Wrap(err, "close tar")
+	}
+	if err := gw.Close(); err != nil {
+		return errors.Wrap(err, "close gzip")
+	}
+	if len(b.failed) > 0 {
+		return fmt.Errorf("unable to fetch logs for: %s", strings.Join(b.failed, ", "))
+	}
+	return nil
+}
+
+// addClusterState adds kubectl dumps and describes pods that are not ready, from the first control plane
+func (b *bundle) addClusterState(cfg config.ClusterConfig, runner command.Runner) error {
+	for _, r := range bundleResources {
+		if err := b.run(runner, path.Join("kubectl", "get-"+r+".txt"), kubectl(cfg, "get", r, "-A", "-o", "wide")); err != nil {
+			return err
+		}
+	}
+
+	var out bytes.Buffer
+	c := kubectl(cfg, "get", "pods", "-A", "-o", "json")
+	c.Stdout = &out
+	if rr, err := runner.RunCmd(c); err != nil {
+		klog.Errorf("command %s failed with error: %v", rr.Command(), err)
+		b.failed = append(b.failed, "pods")
+		return nil
+	}
+	pods, err := notReadyPods(out.Bytes())
+	if err != nil {
+		klog.Errorf("failed to find pods that are not ready: %v", err)
+		b.failed = append(b.failed, "pods")
+		return nil
+	}
+	for _, p := range pods {
+		name := path.Join("kubectl", fmt.Sprintf("describe-pod-%s-%s.txt", p[0], p[1]))
+		if err := b.run(runner, name, kubectl(cfg, "describe", "pod", "-n", p[0], p[1])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addHostInfo adds the last start log, audit rows, profile config and driver info from the host
+func (b *bundle) addHostInfo(cfg config.ClusterConfig, nodes []BundleNode) error {
+	if ls, err := ioutil.ReadFile(localpath.LastStartLog()); err == nil {
+		if err := b.add("last-start.log", ls); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		klog.Errorf("failed to read last start log: %v", err)
+		b.failed = append(b.failed, "last start")
+	}
+
+	r, err := audit.Query(audit.Filter{Profile: cfg.Name})
+	if err == nil {
+		var a []byte
+		a, err = r.JSON()
+		if err == nil {
+			err = b.add("audit.json", a)
+		}
+	}
+	if err != nil {
+		klog.Errorf("failed to bundle audit logs: %v", err)
+		b.failed = append(b.failed, "audit")
+	}
+
+	c, err := json.MarshalIndent(cfg, "", "    ")
+	if err != nil {
+		return errors.Wrap(err, "marshal config")
+	}
+	if err := b.add("config.json", c); err != nil {
+		return err
+	}
+
+	switch {
+	case driver.IsKIC(cfg.Driver):
+		info, err := oci.DaemonInfo(cfg.Driver)
+		if err != nil {
+			klog.Errorf("failed to get %s info: %v", cfg.Driver, err)
+			b.failed = append(b.failed, cfg.Driver)
+			return nil
+		}
+		d, err := json.MarshalIndent(info, "", "    ")
+		if err != nil {
+			return errors.Wrap(err, "marshal driver info")
+		}
+		return b.add(path.Join("driver", cfg.Driver+".json"), d)
+	case cfg.Driver == driver.KVM2:
+		for _, n := range nodes {
+			x, err := exec.Command("virsh", "-c", cfg.KVMQemuURI, "dumpxml", n.Name).CombinedOutput()
+			if err != nil {
+				klog.Errorf("failed to dump domain xml of %s: %v: %s", n.Name, err, x)
+				b.failed = append(b.failed, "domain xml "+n.Name)
+				continue
+			}
+			if err := b.add(path.Join("driver", n.Name+".xml"), x); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// kubectl returns a kubectl command run on a control plane
+func kubectl(cfg config.ClusterConfig, args ...string) *exec.Cmd {
+	k := kapi.KubectlBinaryPath(cfg.KubernetesConfig.KubernetesVersion)
+	args = append([]string{k, fmt.Sprintf("--kubeconfig=%s", path.Join(vmpath.GuestPersistentDir, "kubeconfig"))}, args...)
+	return exec.Command("sudo", args...)
+}
+
+// notReadyPods returns the namespace and name of pods in kubectl json output that are not ready
+func notReadyPods(podList []byte) ([][2]string, error) {
+	var l struct {
+		Items []struct {
+			Metadata struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+			Status struct {
+				Phase      string `json:"phase"`
+				Conditions []struct {
+					Type   string `json:"type"`
+					Status string `json:"status"`
+				} `json:"conditions"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(podList, &l); err != nil {
+		return nil, errors.Wrap(err, "unmarshal pods")
+	}
+	pods := [][2]string{}
+	for _, p := range l.Items {
+		if p.Status.Phase == "Succeeded" {
+			continue
+		}
+		ready := false
+		for _, c := range p.Status.Conditions {
+			if c.Type == "Ready" && c.Status == "True" {
+				ready = true
+			}
+		}
+		if !ready {
+			pods = append(pods, [2]string{p.Metadata.Namespace, p.Metadata.Name})
+		}
+	}
+	return pods, nil
+}
+
+// fileNameRe matches characters that are replaced in bundle file names
+var fileNameRe = regexp.MustCompile(`[^\w.-]+`)
+
+// fileName converts a log name, such as "kube-apiserver [0123abcd]", into a file name
+func fileName(name string) string {
+	return strings.Trim(fileNameRe.ReplaceAllString(name, "-"), "-")
+}
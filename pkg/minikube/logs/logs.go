@@ -25,6 +25,7 @@ import (
 	"os/exec"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -37,6 +38,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/minikube/vmpath"
 )
 
 // rootCauses are regular expressions that match known failures
@@ -109,6 +111,25 @@ func Follow(r cruntime.Manager, bs bootstrapper.Bootstrapper, cfg config.Cluster
 	return nil
 }
 
+// AuditLog outputs the last lines of the API server audit log, and continuously prints new entries if follow is set
+func AuditLog(cfg config.ClusterConfig, cr logRunner, lines int, follow bool) error {
+	if cfg.KubernetesConfig.AuditPolicy == "" {
+		return fmt.Errorf("auditing is not enabled, start the cluster with --audit-policy to enable it")
+	}
+
+	args := []string{"tail", "-n", strconv.Itoa(lines)}
+	if follow {
+		args = append(args, "-F")
+	}
+	cmd := exec.Command("sudo", append(args, vmpath.GuestAuditLog)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if _, err := cr.RunCmd(cmd); err != nil {
+		return errors.Wrap(err, "audit log")
+	}
+	return nil
+}
+
 // IsProblem returns whether this line matches a known problem
 func IsProblem(line string) bool {
 	return rootCauseRe.MatchString(line) && !ignoreCauseRe.MatchString(line)
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/vmpath"
+)
+
+// buildRoot is where build contexts are copied to within the guest VM
+var buildRoot = path.Join(vmpath.GuestPersistentDir, "build")
+
+// BuildImage builds an image from a local context directory on every running node of the profiles.
+// file is the path of the Dockerfile relative to the context directory, and may be empty.
+func BuildImage(src string, file string, tag string, profiles []*config.Profile) error {
+	fi, err := os.Stat(src)
+	if err != nil {
+		return errors.Wrap(err, "context")
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("build context %s is not a directory", src)
+	}
+
+	tmp, err := ioutil.TempFile("", "minikube-build-*.tar")
+	if err != nil {
+		return errors.Wrap(err, "tempfile")
+	}
+	defer os.Remove(tmp.Name())
+	if err := tarDir(src, tmp); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "archiving %s", src)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, "close")
+	}
+
+	name := fmt.Sprintf("build.%d", time.Now().UnixNano())
+	return forEachRunningNode(profiles, func(m string, cr cruntime.Manager, runner command.Runner) error {
+		return transferAndBuildImage(runner, cr, tmp.Name(), name, file, tag)
+	})
+}
+
+// transferAndBuildImage copies an archived build context to a node, and builds it there
+func transferAndBuildImage(runner command.Runner, cr cruntime.Manager, archive string, name string, file string, tag string) error {
+	f, err := assets.NewFileAsset(archive, buildRoot, name+".tar", "0644")
+	if err != nil {
+		return errors.Wrap(err, "creating copyable file asset")
+	}
+	if err := runner.Copy(f); err != nil {
+		return errors.Wrap(err, "transferring build context")
+	}
+
+	dir := path.Join(buildRoot, name)
+	dst := path.Join(buildRoot, name+".tar")
+	defer func() {
+		if _, err := runner.RunCmd(exec.Command("sudo", "rm", "-rf", dir, dst)); err != nil {
+			klog.Warningf("failed to remove build context %s: %v", dir, err)
+		}
+	}()
+	if _, err := runner.RunCmd(exec.Command("sudo", "mkdir", "-p", dir)); err != nil {
+		return errors.Wrap(err, "mkdir")
+	}
+	if _, err := runner.RunCmd(exec.Command("sudo", "tar", "-C", dir, "-xf", dst)); err != nil {
+		return errors.Wrap(err, "extracting build context")
+	}
+
+	if err := cr.BuildImage(dir, file, tag); err != nil {
+		return errors.Wrapf(err, "%s build %s", cr.Name(), dir)
+	}
+	klog.Infof("Built %s from %s", tag, dir)
+	return nil
+}
+
+// tarDir writes the contents of dir to w as a tar archive, with paths relative to dir
+func tarDir(dir string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		link := ""
+		if fi.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(fi, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !fi.Mode().IsRegular() {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
@@ -19,6 +19,7 @@ package machine
 import (
 	"fmt"
 	"os"
+	"os/exec"
 	"path"
 	"path/filepath"
 	"strings"
@@ -259,3 +260,185 @@ func transferAndLoadImage(cr command.Runner, k8s config.KubernetesConfig, imgNam
 	klog.Infof("Transferred and loaded %s from cache", src)
 	return nil
 }
+
+// forEachRunningNode runs fn against the container runtime of every running node of the given profiles
+func forEachRunningNode(profiles []*config.Profile, fn func(m string, cr cruntime.Manager, runner command.Runner) error) error {
+	api, err := NewAPIClient()
+	if err != nil {
+		return errors.Wrap(err, "api")
+	}
+	defer api.Close()
+
+	succeeded := []string{}
+	failed := []string{}
+
+	for _, p := range profiles {
+		c, err := config.Load(p.Name)
+		if err != nil {
+			klog.Errorf("Failed to load profile %q: %v", p.Name, err)
+			failed = append(failed, p.Name)
+			continue
+		}
+
+		for _, n := range c.Nodes {
+			m := config.MachineName(*c, n)
+
+			status, err := Status(api, m)
+			if err != nil {
+				klog.Warningf("error getting status for %s: %v", m, err)
+				failed = append(failed, m)
+				continue
+			}
+			if status != state.Running.String() {
+				klog.Infof("skipping %s: status is %s", m, status)
+				continue
+			}
+
+			h, err := api.Load(m)
+			if err != nil {
+				klog.Warningf("Failed to load machine %q: %v", m, err)
+				failed = append(failed, m)
+				continue
+			}
+			runner, err := CommandRunner(h)
+			if err != nil {
+				return err
+			}
+			cr, err := cruntime.New(cruntime.Config{Type: c.KubernetesConfig.ContainerRuntime, Runner: runner})
+			if err != nil {
+				return errors.Wrap(err, "runtime")
+			}
+			if err := fn(m, cr, runner); err != nil {
+				klog.Warningf("%s: %v", m, err)
+				failed = append(failed, m)
+				continue
+			}
+			succeeded = append(succeeded, m)
+		}
+	}
+
+	klog.Infof("succeeded on: %s", strings.Join(succeeded, " "))
+	if len(failed) > 0 {
+		return fmt.Errorf("failed on: %s", strings.Join(failed, " "))
+	}
+	if len(succeeded) == 0 {
+		return fmt.Errorf("no running nodes found")
+	}
+	return nil
+}
+
+// ListImages returns the images present on any running node of the profiles, merged by image ID
+func ListImages(profiles []*config.Profile) ([]cruntime.ListImage, error) {
+	images := []cruntime.ListImage{}
+	byID := map[string]int{}
+
+	err := forEachRunningNode(profiles, func(m string, cr cruntime.Manager, _ command.Runner) error {
+		list, err := cr.ListImages()
+		if err != nil {
+			return err
+		}
+		for _, img := range list {
+			i, ok := byID[img.ID]
+			if !ok {
+				images = append(images, img)
+				byID[img.ID] = len(images) - 1
+				continue
+			}
+			images[i].RepoTags = mergeStrings(images[i].RepoTags, img.RepoTags)
+			images[i].RepoDigests = mergeStrings(images[i].RepoDigests, img.RepoDigests)
+		}
+		return nil
+	})
+	return images, err
+}
+
+// mergeStrings appends the entries of b which are not already in a
+func mergeStrings(a []string, b []string) []string {
+	for _, s := range b {
+		found := false
+		for _, existing := range a {
+			if s == existing {
+				found = true
+				break
+			}
+		}
+		if !found {
+			a = append(a, s)
+		}
+	}
+	return a
+}
+
+// RemoveImages removes images from every running node of the profiles
+func RemoveImages(images []string, profiles []*config.Profile) error {
+	return forEachRunningNode(profiles, func(m string, cr cruntime.Manager, _ command.Runner) error {
+		for _, img := range images {
+			if err := cr.RemoveImage(img); err != nil {
+				return errors.Wrapf(err, "remove %s", img)
+			}
+		}
+		return nil
+	})
+}
+
+// PullImages pulls images on every running node of the profiles
+func PullImages(images []string, profiles []*config.Profile) error {
+	return forEachRunningNode(profiles, func(m string, cr cruntime.Manager, _ command.Runner) error {
+		for _, img := range images {
+			if err := cr.PullImage(img); err != nil {
+				return errors.Wrapf(err, "pull %s", img)
+			}
+		}
+		return nil
+	})
+}
+
+// TagImage tags an image on every running node of the profiles
+func TagImage(source string, target string, profiles []*config.Profile) error {
+	return forEachRunningNode(profiles, func(m string, cr cruntime.Manager, _ command.Runner) error {
+		return cr.TagImage(source, target)
+	})
+}
+
+// SaveImage saves an image from the first running node of the profile into a tarball on the host
+func SaveImage(img string, dst string, profile *config.Profile) error {
+	saved := false
+	src := path.Join(loadRoot, "save-"+filepath.Base(localpath.SanitizeCacheDir(img))+".tar")
+	err := forEachRunningNode([]*config.Profile{profile}, func(m string, cr cruntime.Manager, runner command.Runner) error {
+		if saved {
+			return nil
+		}
+		if _, err := runner.RunCmd(exec.Command("sudo", "mkdir", "-p", loadRoot)); err != nil {
+			return errors.Wrap(err, "mkdir")
+		}
+		defer func() {
+			if _, err := runner.RunCmd(exec.Command("sudo", "rm", "-f", src)); err != nil {
+				klog.Warningf("failed to remove %s: %v", src, err)
+			}
+		}()
+		if err := cr.SaveImage(img, src); err != nil {
+			return err
+		}
+
+		f, err := os.Create(dst)
+		if err != nil {
+			return errors.Wrap(err, "create")
+		}
+		defer f.Close()
+		c := exec.Command("sudo", "cat", src)
+		c.Stdout = f
+		if _, err := runner.RunCmd(c); err != nil {
+			return errors.Wrapf(err, "copy %s from %s", src, m)
+		}
+		klog.Infof("Saved %s from %s to %s", img, m, dst)
+		saved = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !saved {
+		return fmt.Errorf("no running nodes found")
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package machine
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"testing"
@@ -85,7 +86,7 @@ func TestCreateHost(t *testing.T) {
 		t.Fatal("Machine already exists.")
 	}
 
-	_, err := createHost(api, &defaultClusterConfig, &config.Node{Name: "minikube"})
+	_, err := createHost(context.Background(), api, &defaultClusterConfig, &config.Node{Name: "minikube"})
 	if err != nil {
 		t.Fatalf("Error creating host: %v", err)
 	}
@@ -129,7 +130,7 @@ func TestStartHostExists(t *testing.T) {
 	api := tests.NewMockAPI(t)
 
 	// Create an initial host.
-	ih, err := createHost(api, &defaultClusterConfig, &config.Node{Name: "minikube"})
+	ih, err := createHost(context.Background(), api, &defaultClusterConfig, &config.Node{Name: "minikube"})
 	if err != nil {
 		t.Fatalf("Error creating host: %v", err)
 	}
@@ -147,7 +148,7 @@ func TestStartHostExists(t *testing.T) {
 	mc.Name = ih.Name
 
 	// This should pass without calling Create because the host exists already.
-	h, _, err := StartHost(api, &mc, &(mc.Nodes[0]))
+	h, _, err := StartHost(context.Background(), api, &mc, &(mc.Nodes[0]))
 	if err != nil {
 		t.Fatalf("Error starting host: %v", err)
 	}
@@ -169,7 +170,7 @@ func TestStartHostErrMachineNotExist(t *testing.T) {
 	api := tests.NewMockAPI(t)
 	// Create an incomplete host with machine does not exist error(i.e. User Interrupt Cancel)
 	api.NotExistError = true
-	h, err := createHost(api, &defaultClusterConfig, &config.Node{Name: "minikube"})
+	h, err := createHost(context.Background(), api, &defaultClusterConfig, &config.Node{Name: "minikube"})
 	if err != nil {
 		t.Fatalf("Error creating host: %v", err)
 	}
@@ -183,7 +184,7 @@ func TestStartHostErrMachineNotExist(t *testing.T) {
 	n := config.Node{Name: h.Name}
 
 	// This should pass with creating host, while machine does not exist.
-	h, _, err = StartHost(api, &mc, &n)
+	h, _, err = StartHost(context.Background(), api, &mc, &n)
 	if err != nil {
 		if err != constants.ErrMachineMissing {
 			t.Fatalf("Error starting host: %v", err)
@@ -196,7 +197,7 @@ func TestStartHostErrMachineNotExist(t *testing.T) {
 	n.Name = h.Name
 
 	// Second call. This should pass without calling Create because the host exists already.
-	h, _, err = StartHost(api, &mc, &n)
+	h, _, err = StartHost(context.Background(), api, &mc, &n)
 	if err != nil {
 		t.Fatalf("Error starting host: %v", err)
 	}
@@ -218,7 +219,7 @@ func TestStartStoppedHost(t *testing.T) {
 	RegisterMockDriver(t)
 	api := tests.NewMockAPI(t)
 	// Create an initial host.
-	h, err := createHost(api, &defaultClusterConfig, &config.Node{Name: "minikube"})
+	h, err := createHost(context.Background(), api, &defaultClusterConfig, &config.Node{Name: "minikube"})
 	if err != nil {
 		t.Fatalf("Error creating host: %v", err)
 	}
@@ -231,7 +232,7 @@ func TestStartStoppedHost(t *testing.T) {
 	mc := defaultClusterConfig
 	mc.Name = h.Name
 	n := config.Node{Name: h.Name}
-	h, _, err = StartHost(api, &mc, &n)
+	h, _, err = StartHost(context.Background(), api, &mc, &n)
 	if err != nil {
 		t.Fatal("Error starting host.")
 	}
@@ -261,7 +262,7 @@ func TestStartHost(t *testing.T) {
 	md := &tests.MockDetector{Provisioner: &tests.MockProvisioner{}}
 	provision.SetDetector(md)
 
-	h, _, err := StartHost(api, &defaultClusterConfig, &config.Node{Name: "minikube"})
+	h, _, err := StartHost(context.Background(), api, &defaultClusterConfig, &config.Node{Name: "minikube"})
 	if err != nil {
 		t.Fatal("Error starting host.")
 	}
@@ -300,7 +301,7 @@ func TestStartHostConfig(t *testing.T) {
 		DockerOpt: []string{"param=value"},
 	}
 
-	h, _, err := StartHost(api, &cfg, &config.Node{Name: "minikube"})
+	h, _, err := StartHost(context.Background(), api, &cfg, &config.Node{Name: "minikube"})
 	if err != nil {
 		t.Fatal("Error starting host.")
 	}
@@ -333,7 +334,7 @@ func TestStopHost(t *testing.T) {
 
 	RegisterMockDriver(t)
 	api := tests.NewMockAPI(t)
-	h, err := createHost(api, &defaultClusterConfig, &config.Node{Name: "minikube"})
+	h, err := createHost(context.Background(), api, &defaultClusterConfig, &config.Node{Name: "minikube"})
 	if err != nil {
 		t.Errorf("createHost failed: %v", err)
 	}
@@ -355,7 +356,7 @@ func TestDeleteHost(t *testing.T) {
 
 	RegisterMockDriver(t)
 	api := tests.NewMockAPI(t)
-	if _, err := createHost(api, &defaultClusterConfig, &config.Node{Name: "minikube"}); err != nil {
+	if _, err := createHost(context.Background(), api, &defaultClusterConfig, &config.Node{Name: "minikube"}); err != nil {
 		t.Errorf("createHost failed: %v", err)
 	}
 
@@ -373,7 +374,7 @@ func TestDeleteHostErrorDeletingVM(t *testing.T) {
 
 	RegisterMockDriver(t)
 	api := tests.NewMockAPI(t)
-	h, err := createHost(api, &defaultClusterConfig, &config.Node{Name: "minikube"})
+	h, err := createHost(context.Background(), api, &defaultClusterConfig, &config.Node{Name: "minikube"})
 	if err != nil {
 		t.Errorf("createHost failed: %v", err)
 	}
@@ -393,7 +394,7 @@ func TestDeleteHostErrorDeletingFiles(t *testing.T) {
 	RegisterMockDriver(t)
 	api := tests.NewMockAPI(t)
 	api.RemoveError = true
-	if _, err := createHost(api, &defaultClusterConfig, &config.Node{Name: "minikube"}); err != nil {
+	if _, err := createHost(context.Background(), api, &defaultClusterConfig, &config.Node{Name: "minikube"}); err != nil {
 		t.Errorf("createHost failed: %v", err)
 	}
 
@@ -410,7 +411,7 @@ func TestDeleteHostErrMachineNotExist(t *testing.T) {
 	api := tests.NewMockAPI(t)
 	// Create an incomplete host with machine does not exist error(i.e. User Interrupt Cancel)
 	api.NotExistError = true
-	_, err := createHost(api, &defaultClusterConfig, &config.Node{Name: "minikube"})
+	_, err := createHost(context.Background(), api, &defaultClusterConfig, &config.Node{Name: "minikube"})
 	if err != nil {
 		t.Errorf("createHost failed: %v", err)
 	}
@@ -444,7 +445,7 @@ func TestStatus(t *testing.T) {
 
 	checkState(state.None.String(), m)
 
-	if _, err := createHost(api, &cc, &config.Node{Name: "minikube"}); err != nil {
+	if _, err := createHost(context.Background(), api, &cc, &config.Node{Name: "minikube"}); err != nil {
 		t.Errorf("createHost failed: %v", err)
 	}
 
@@ -27,6 +27,7 @@ import (
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/assets"
 	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/vmpath"
 )
@@ -40,13 +41,19 @@ var guaranteed = map[string]bool{
 	"/tmp": true,
 }
 
-// syncLocalAssets syncs files from MINIKUBE_HOME into the cluster
-func syncLocalAssets(cr command.Runner) error {
+// syncLocalAssets syncs files from MINIKUBE_HOME and the audit policy into the cluster
+func syncLocalAssets(cr command.Runner, cc config.ClusterConfig) error {
 	fs, err := localAssets()
 	if err != nil {
 		return err
 	}
 
+	policy, err := auditPolicyAssets(cc)
+	if err != nil {
+		return err
+	}
+	fs = append(fs, policy...)
+
 	if len(fs) == 0 {
 		return nil
 	}
@@ -96,6 +103,21 @@ func localAssets() ([]assets.CopyableFile, error) {
 	return fs, nil
 }
 
+// auditPolicyAssets returns the API server audit policy of the cluster, if it has one
+func auditPolicyAssets(cc config.ClusterConfig) ([]assets.CopyableFile, error) {
+	src := cc.KubernetesConfig.AuditPolicy
+	if src == "" {
+		return nil, nil
+	}
+
+	klog.Infof("audit policy: %s -> %s", src, vmpath.GuestAuditPolicy)
+	f, err := assets.NewFileAsset(src, path.Dir(vmpath.GuestAuditPolicy), path.Base(vmpath.GuestAuditPolicy), "0644")
+	if err != nil {
+		return nil, errors.Wrapf(err, "audit policy %s", src)
+	}
+	return []assets.CopyableFile{f}, nil
+}
+
 // syncDest returns the path within a VM for a local asset
 func syncDest(localRoot string, localPath string, destRoot string, flatten bool) (string, error) {
 	rel, err := filepath.Rel(localRoot, localPath)
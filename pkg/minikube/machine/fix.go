@@ -17,6 +17,7 @@ limitations under the License.
 package machine
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"os"
@@ -35,6 +36,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/util/retry"
 )
 
 // hostRunner is a minimal host.Host based interface for running commands
@@ -49,7 +51,7 @@ const (
 )
 
 // fixHost fixes up a previously configured VM so that it is ready to run Kubernetes
-func fixHost(api libmachine.API, cc *config.ClusterConfig, n *config.Node) (*host.Host, error) {
+func fixHost(ctx context.Context, api libmachine.API, cc *config.ClusterConfig, n *config.Node) (*host.Host, error) {
 	start := time.Now()
 	klog.Infof("fixHost starting: %s", n.Name)
 	defer func() {
@@ -67,7 +69,7 @@ func fixHost(api libmachine.API, cc *config.ClusterConfig, n *config.Node) (*hos
 	// check if need to re-run docker-env
 	maybeWarnAboutEvalEnv(driverName, cc.Name)
 
-	h, err = recreateIfNeeded(api, cc, n, h)
+	h, err = recreateIfNeeded(ctx, api, cc, n, h)
 	if err != nil {
 		return h, err
 	}
@@ -76,6 +78,9 @@ func fixHost(api libmachine.API, cc *config.ClusterConfig, n *config.Node) (*hos
 	if !driver.BareMetal(h.Driver.DriverName()) {
 		e := engineOptions(*cc)
 		h.HostOptions.EngineOptions.Env = e.Env
+		// the registry cache may have been started or stopped since the host was created
+		h.HostOptions.EngineOptions.InsecureRegistry = e.InsecureRegistry
+		h.HostOptions.EngineOptions.RegistryMirror = e.RegistryMirror
 		err = provisionDockerMachine(h)
 		if err != nil {
 			return h, errors.Wrap(err, "provision")
@@ -98,7 +103,7 @@ func fixHost(api libmachine.API, cc *config.ClusterConfig, n *config.Node) (*hos
 	return h, ensureSyncedGuestClock(h, driverName)
 }
 
-func recreateIfNeeded(api libmachine.API, cc *config.ClusterConfig, n *config.Node, h *host.Host) (*host.Host, error) {
+func recreateIfNeeded(ctx context.Context, api libmachine.API, cc *config.ClusterConfig, n *config.Node, h *host.Host) (*host.Host, error) {
 	machineName := config.MachineName(*cc, *n)
 	machineType := driver.MachineType(cc.Driver)
 	recreated := false
@@ -117,9 +122,11 @@ func recreateIfNeeded(api libmachine.API, cc *config.ClusterConfig, n *config.No
 			demolish(api, *cc, *n, h)
 
 			klog.Infof("Sleeping 1 second for extra luck!")
-			time.Sleep(1 * time.Second)
+			if err := retry.Sleep(ctx, 1*time.Second); err != nil {
+				return nil, errors.Wrap(err, "recreate")
+			}
 
-			h, err = createHost(api, cc, n)
+			h, err = createHost(ctx, api, cc, n)
 			if err != nil {
 				return nil, errors.Wrap(err, "recreate")
 			}
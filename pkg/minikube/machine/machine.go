@@ -105,7 +105,7 @@ func fastDetectProvisioner(h *host.Host) (libprovision.Provisioner, error) {
 	case driver.IsKIC(d):
 		return provision.NewUbuntuProvisioner(h.Driver), nil
 	case driver.BareMetal(d), driver.IsSSH(d):
-		return libprovision.DetectProvisioner(h.Driver)
+		return provision.DetectProvisioner(h.Driver)
 	default:
 		return provision.NewBuildrootProvisioner(h.Driver), nil
 	}
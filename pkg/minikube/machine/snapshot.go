@@ -0,0 +1,379 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/drivers/kic"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+const (
+	// snapshotMetadataFile describes a snapshot, and is written once all the machines have been saved
+	snapshotMetadataFile = "snapshot.json"
+	// snapshotConfigFile is the copy of the profile's config.json
+	snapshotConfigFile = "config.json"
+)
+
+// imageID and removeImage inspect and remove the images of KIC snapshots, replaceable by tests
+var (
+	imageID     = oci.ImageID
+	removeImage = oci.RemoveImage
+)
+
+// validSnapshotName matches names which are valid image tags
+var validSnapshotName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,127}$`)
+
+// Snapshot is a saved copy of the machines and configuration of a cluster
+type Snapshot struct {
+	Name     string
+	Profile  string
+	Driver   string
+	Machines []string
+	// ImageDigests holds the id of the image each KIC machine was committed to
+	ImageDigests map[string]string `json:",omitempty"`
+	Created      time.Time
+}
+
+// SnapshotSupported returns whether clusters using the driver can be snapshotted.
+// The kvm2 driver is not supported, as libvirt can only take internal snapshots of qcow2 disks, and its disks are raw.
+func SnapshotSupported(name string) bool {
+	return driver.IsKIC(name)
+}
+
+// ListSnapshots returns the snapshots of a profile, oldest first
+func ListSnapshots(profile string) ([]Snapshot, error) {
+	entries, err := ioutil.ReadDir(localpath.Snapshots(profile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "read snapshots dir")
+	}
+
+	snapshots := []Snapshot{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		s, err := loadSnapshot(profile, e.Name())
+		if err != nil {
+			// an interrupted save leaves a directory without metadata behind
+			klog.Warningf("skipping snapshot %q: %v", e.Name(), err)
+			continue
+		}
+		snapshots = append(snapshots, *s)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Created.Before(snapshots[j].Created)
+	})
+	return snapshots, nil
+}
+
+// SaveSnapshot saves the machines and configuration of a cluster as the snapshot name, replacing any existing snapshot with the same name.
+// The cluster is expected to be stopped or paused, so that etcd and the container runtime are not writing to disk.
+func SaveSnapshot(api libmachine.API, cc *config.ClusterConfig, name string) (*Snapshot, error) {
+	if err := validateSnapshot(cc.Driver, name); err != nil {
+		return nil, err
+	}
+
+	dir := snapshotDir(cc.Name, name)
+	// drop the metadata first, so that a failed save does not leave a stale snapshot behind
+	if err := os.Remove(filepath.Join(dir, snapshotMetadataFile)); err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "remove metadata")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "create snapshot dir")
+	}
+
+	s := &Snapshot{
+		Name:    name,
+		Profile: cc.Name,
+		Driver:  cc.Driver,
+		Created: time.Now(),
+	}
+	for _, n := range cc.Nodes {
+		m := config.MachineName(*cc, n)
+		exists, err := api.Exists(m)
+		if err != nil {
+			return nil, errors.Wrapf(err, "checking %s", m)
+		}
+		if !exists {
+			return nil, fmt.Errorf("machine %s does not exist", m)
+		}
+
+		klog.Infof("saving %s to snapshot %q", m, name)
+		digest, err := saveKICSnapshot(cc.Driver, m, name, dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "saving %s", m)
+		}
+		if s.ImageDigests == nil {
+			s.ImageDigests = map[string]string{}
+		}
+		s.ImageDigests[m] = digest
+		s.Machines = append(s.Machines, m)
+	}
+
+	if err := writeJSON(filepath.Join(dir, snapshotConfigFile), cc); err != nil {
+		return nil, errors.Wrap(err, "write config")
+	}
+	if err := writeJSON(filepath.Join(dir, snapshotMetadataFile), s); err != nil {
+		return nil, errors.Wrap(err, "write metadata")
+	}
+	return s, nil
+}
+
+// RestoreSnapshot stops the machines of a cluster and restores them, along with the cluster configuration, from the snapshot name.
+// The restored cluster is left stopped.
+func RestoreSnapshot(api libmachine.API, cc *config.ClusterConfig, name string) (*config.ClusterConfig, error) {
+	if err := validateSnapshot(cc.Driver, name); err != nil {
+		return nil, err
+	}
+
+	s, err := loadSnapshot(cc.Name, name)
+	if err != nil {
+		return nil, err
+	}
+	if s.Driver != cc.Driver {
+		return nil, fmt.Errorf("snapshot %q was taken with the %s driver, but the cluster uses the %s driver", name, s.Driver, cc.Driver)
+	}
+
+	dir := snapshotDir(cc.Name, name)
+	var saved config.ClusterConfig
+	data, err := ioutil.ReadFile(filepath.Join(dir, snapshotConfigFile))
+	if err != nil {
+		return nil, errors.Wrap(err, "read config")
+	}
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, errors.Wrap(err, "unmarshal config")
+	}
+
+	for _, m := range s.Machines {
+		exists, err := api.Exists(m)
+		if err != nil {
+			return nil, errors.Wrapf(err, "checking %s", m)
+		}
+		if !exists {
+			return nil, fmt.Errorf("machine %s from snapshot %q no longer exists", m, name)
+		}
+		if err := checkKICSnapshot(s, m); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, m := range s.Machines {
+		if err := StopHost(api, m); err != nil {
+			return nil, errors.Wrapf(err, "stopping %s", m)
+		}
+
+		klog.Infof("restoring %s from snapshot %q", m, name)
+		if err := restoreKICSnapshot(api, cc.Driver, m, s.ImageDigests[m], dir); err != nil {
+			return nil, errors.Wrapf(err, "restoring %s", m)
+		}
+	}
+
+	if err := config.SaveProfile(cc.Name, &saved); err != nil {
+		return nil, errors.Wrap(err, "save config")
+	}
+	return &saved, nil
+}
+
+// DeleteSnapshots removes the snapshots of a cluster, along with the images holding its machines
+func DeleteSnapshots(cc *config.ClusterConfig) error {
+	entries, err := ioutil.ReadDir(localpath.Snapshots(cc.Name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "read snapshots dir")
+	}
+
+	if SnapshotSupported(cc.Driver) {
+		for _, e := range entries {
+			if !e.IsDir() {
+				continue
+			}
+			if err := removeSnapshotImages(cc, e.Name()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := os.RemoveAll(localpath.Snapshots(cc.Name)); err != nil {
+		return errors.Wrap(err, "remove snapshots dir")
+	}
+	return nil
+}
+
+// DeleteSnapshot removes the snapshot name of a cluster, along with the images holding its machines
+func DeleteSnapshot(cc *config.ClusterConfig, name string) error {
+	if err := validateSnapshot(cc.Driver, name); err != nil {
+		return err
+	}
+	if err := removeSnapshotImages(cc, name); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(snapshotDir(cc.Name, name)); err != nil {
+		return errors.Wrap(err, "remove snapshot dir")
+	}
+	return nil
+}
+
+// removeSnapshotImages removes the images of the snapshot name, for the machines it saved as well as those of the cluster
+func removeSnapshotImages(cc *config.ClusterConfig, name string) error {
+	// an interrupted save has no metadata, but may have saved some of the machines already
+	machines := map[string]bool{}
+	for _, n := range cc.Nodes {
+		machines[config.MachineName(*cc, n)] = true
+	}
+	if s, err := loadSnapshot(cc.Name, name); err == nil {
+		for _, m := range s.Machines {
+			machines[m] = true
+		}
+	}
+
+	for m := range machines {
+		klog.Infof("deleting snapshot %q of %s", name, m)
+		if err := removeImage(cc.Driver, snapshotImage(m, name)); err != nil {
+			return errors.Wrapf(err, "deleting snapshot %q of %s", name, m)
+		}
+	}
+	return nil
+}
+
+// validateSnapshot checks that the driver supports snapshots, and that name is a valid snapshot name
+func validateSnapshot(driverName string, name string) error {
+	if !SnapshotSupported(driverName) {
+		return fmt.Errorf("the %s driver does not support snapshots", driverName)
+	}
+	if !validSnapshotName.MatchString(name) {
+		return fmt.Errorf("invalid snapshot name %q: only letters, digits, '_', '.' and '-' are allowed", name)
+	}
+	return nil
+}
+
+// snapshotDir returns the directory holding the snapshot name of a profile
+func snapshotDir(profile string, name string) string {
+	return filepath.Join(localpath.Snapshots(profile), name)
+}
+
+// loadSnapshot reads the metadata of the snapshot name of a profile
+func loadSnapshot(profile string, name string) (*Snapshot, error) {
+	data, err := ioutil.ReadFile(filepath.Join(snapshotDir(profile, name), snapshotMetadataFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("snapshot %q of profile %q does not exist", name, profile)
+		}
+		return nil, errors.Wrap(err, "read metadata")
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, errors.Wrap(err, "unmarshal metadata")
+	}
+	return &s, nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// snapshotImage returns the name of the image a KIC node container is committed to
+func snapshotImage(machineName string, name string) string {
+	return fmt.Sprintf("minikube-snapshot/%s:%s", strings.ToLower(machineName), name)
+}
+
+// snapshotVolumeTarball returns the path of the archive of a KIC node volume
+func snapshotVolumeTarball(dir string, machineName string) string {
+	return filepath.Join(dir, machineName+".tar.lz4")
+}
+
+// saveKICSnapshot commits the node container and archives its volume, which holds /var, returning the id of the committed image
+func saveKICSnapshot(ociBin string, machineName string, name string, dir string) (string, error) {
+	image := snapshotImage(machineName, name)
+	if err := oci.CommitContainer(ociBin, machineName, image); err != nil {
+		return "", err
+	}
+	digest, err := imageID(ociBin, image)
+	if err != nil {
+		return "", err
+	}
+	return digest, oci.SaveVolumeToTarball(ociBin, machineName, snapshotVolumeTarball(dir, machineName), digest)
+}
+
+// checkKICSnapshot checks that the image of a KIC machine is still the one committed by the snapshot
+func checkKICSnapshot(s *Snapshot, machineName string) error {
+	image := snapshotImage(machineName, s.Name)
+	want := s.ImageDigests[machineName]
+	if want == "" {
+		return fmt.Errorf("snapshot %q has no image recorded for %s, please save it again", s.Name, machineName)
+	}
+	got, err := imageID(s.Driver, image)
+	if err != nil {
+		return errors.Wrapf(err, "image of %s from snapshot %q", machineName, s.Name)
+	}
+	if got != want {
+		return fmt.Errorf("image %s is %s, but snapshot %q was saved as %s", image, got, s.Name, want)
+	}
+	return nil
+}
+
+// restoreKICSnapshot recreates the node container from its committed image, then replaces the contents of its volume.
+// The image is saved as the one of the machine, so that the node keeps it when recreated later on.
+func restoreKICSnapshot(api libmachine.API, ociBin string, machineName string, digest string, dir string) error {
+	h, err := api.Load(machineName)
+	if err != nil {
+		return errors.Wrap(err, "load")
+	}
+	d, ok := h.Driver.(*kic.Driver)
+	if !ok {
+		return fmt.Errorf("unexpected driver type %T", h.Driver)
+	}
+
+	d.NodeConfig.ImageDigest = digest
+	// the volume is replaced by the snapshot, so extracting the preload into it would be wasted
+	if err := d.CreateWithoutPreload(); err != nil {
+		return errors.Wrap(err, "recreate container")
+	}
+	if err := api.Save(h); err != nil {
+		return errors.Wrap(err, "save")
+	}
+	if err := oci.ShutDown(ociBin, machineName); err != nil {
+		return errors.Wrap(err, "shutdown")
+	}
+	return oci.RestoreVolumeFromTarball(ociBin, snapshotVolumeTarball(dir, machineName), machineName, digest)
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+func TestListSnapshots(t *testing.T) {
+	oldMinikubeHome := os.Getenv(localpath.MinikubeHome)
+	defer os.Setenv(localpath.MinikubeHome, oldMinikubeHome)
+
+	minikubeHome, err := ioutil.TempDir("", "snapshots")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(minikubeHome)
+	os.Setenv(localpath.MinikubeHome, minikubeHome)
+
+	if got, err := ListSnapshots("p1"); err != nil || len(got) != 0 {
+		t.Fatalf("ListSnapshots without snapshots = %v, %v, want none", got, err)
+	}
+
+	now := time.Now()
+	for _, s := range []Snapshot{
+		{Name: "newer", Profile: "p1", Driver: "docker", Machines: []string{"p1"}, Created: now},
+		{Name: "older", Profile: "p1", Driver: "docker", Machines: []string{"p1"}, Created: now.Add(-time.Hour)},
+	} {
+		dir := snapshotDir("p1", s.Name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := writeJSON(filepath.Join(dir, snapshotMetadataFile), s); err != nil {
+			t.Fatalf("write metadata: %v", err)
+		}
+	}
+	// an interrupted save has no metadata
+	if err := os.MkdirAll(snapshotDir("p1", "partial"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	got, err := ListSnapshots("p1")
+	if err != nil {
+		t.Fatalf("ListSnapshots: %v", err)
+	}
+	if len(got) != 2 || got[0].Name != "older" || got[1].Name != "newer" {
+		t.Errorf("ListSnapshots = %+v, want [older newer]", got)
+	}
+}
+
+func TestValidateSnapshot(t *testing.T) {
+	tests := []struct {
+		driver string
+		name   string
+		valid  bool
+	}{
+		{"docker", "known-good", true},
+		{"podman", "v1.20.2_base", true},
+		{"kvm2", "known-good", false},
+		{"docker", "", false},
+		{"docker", "-leading-dash", false},
+		{"docker", "with/slash", false},
+		{"virtualbox", "known-good", false},
+	}
+	for _, tc := range tests {
+		err := validateSnapshot(tc.driver, tc.name)
+		if (err == nil) != tc.valid {
+			t.Errorf("validateSnapshot(%q, %q) = %v, want valid=%t", tc.driver, tc.name, err, tc.valid)
+		}
+	}
+}
+
+func TestDeleteSnapshots(t *testing.T) {
+	oldMinikubeHome := os.Getenv(localpath.MinikubeHome)
+	defer os.Setenv(localpath.MinikubeHome, oldMinikubeHome)
+
+	minikubeHome, err := ioutil.TempDir("", "snapshots")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(minikubeHome)
+	os.Setenv(localpath.MinikubeHome, minikubeHome)
+
+	oldRemoveImage := removeImage
+	defer func() { removeImage = oldRemoveImage }()
+	removed := map[string]bool{}
+	removeImage = func(ociBin string, imageName string) error {
+		removed[imageName] = true
+		return nil
+	}
+
+	cc := &config.ClusterConfig{Name: "p1", Driver: "docker", Nodes: []config.Node{{Name: "", ControlPlane: true}}}
+	if err := DeleteSnapshots(cc); err != nil {
+		t.Fatalf("DeleteSnapshots without snapshots: %v", err)
+	}
+
+	// the snapshot was taken before the second node was deleted
+	s := Snapshot{Name: "known-good", Profile: "p1", Driver: "docker", Machines: []string{"p1", "p1-m02"}, Created: time.Now()}
+	if err := os.MkdirAll(snapshotDir("p1", s.Name), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := writeJSON(filepath.Join(snapshotDir("p1", s.Name), snapshotMetadataFile), s); err != nil {
+		t.Fatalf("write metadata: %v", err)
+	}
+	if err := os.MkdirAll(snapshotDir("p1", "partial"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	if err := DeleteSnapshots(cc); err != nil {
+		t.Fatalf("DeleteSnapshots: %v", err)
+	}
+	for _, image := range []string{"minikube-snapshot/p1:known-good", "minikube-snapshot/p1-m02:known-good", "minikube-snapshot/p1:partial"} {
+		if !removed[image] {
+			t.Errorf("image %s was not removed, removed %v", image, removed)
+		}
+	}
+	if _, err := os.Stat(localpath.Snapshots("p1")); !os.IsNotExist(err) {
+		t.Errorf("snapshots dir still exists: %v", err)
+	}
+}
+
+func TestCheckKICSnapshot(t *testing.T) {
+	oldImageID := imageID
+	defer func() { imageID = oldImageID }()
+	imageID = func(ociBin string, imageName string) (string, error) {
+		if imageName != "minikube-snapshot/p1:known-good" {
+			t.Errorf("imageID(%q), want minikube-snapshot/p1:known-good", imageName)
+		}
+		return "sha256:abc", nil
+	}
+
+	tests := []struct {
+		digests map[string]string
+		valid   bool
+	}{
+		{map[string]string{"p1": "sha256:abc"}, true},
+		{map[string]string{"p1": "sha256:def"}, false},
+		// saved before the image ids were recorded
+		{nil, false},
+	}
+	for _, tc := range tests {
+		s := &Snapshot{Name: "known-good", Profile: "p1", Driver: "docker", Machines: []string{"p1"}, ImageDigests: tc.digests}
+		err := checkKICSnapshot(s, "p1")
+		if (err == nil) != tc.valid {
+			t.Errorf("checkKICSnapshot with %v = %v, want valid=%t", tc.digests, err, tc.valid)
+		}
+	}
+}
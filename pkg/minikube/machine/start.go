@@ -17,6 +17,7 @@ limitations under the License.
 package machine
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -42,6 +43,7 @@ import (
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/exit"
 	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/mirror"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/minikube/proxy"
@@ -66,8 +68,8 @@ var requiredDirectories = []string{
 	vmpath.GuestCertStoreDir,
 }
 
-// StartHost starts a host VM.
-func StartHost(api libmachine.API, cfg *config.ClusterConfig, n *config.Node) (*host.Host, bool, error) {
+// StartHost starts a host VM, giving up once ctx is done.
+func StartHost(ctx context.Context, api libmachine.API, cfg *config.ClusterConfig, n *config.Node) (*host.Host, bool, error) {
 	machineName := config.MachineName(*cfg, *n)
 
 	// Prevent machine-driver boot races, as well as our own certificate race
@@ -87,11 +89,11 @@ func StartHost(api libmachine.API, cfg *config.ClusterConfig, n *config.Node) (*
 	}
 	if !exists {
 		klog.Infof("Provisioning new machine with config: %+v %+v", cfg, n)
-		h, err := createHost(api, cfg, n)
+		h, err := createHost(ctx, api, cfg, n)
 		return h, exists, err
 	}
 	klog.Infoln("Skipping create...Using existing machine configuration")
-	h, err := fixHost(api, cfg, n)
+	h, err := fixHost(ctx, api, cfg, n)
 	return h, exists, err
 }
 
@@ -115,14 +117,20 @@ func engineOptions(cfg config.ClusterConfig) *engine.Options {
 	o := engine.Options{
 		Env:              uniqueEnvs,
 		InsecureRegistry: append([]string{constants.DefaultServiceCIDR}, cfg.InsecureRegistry...),
-		RegistryMirror:   cfg.RegistryMirror,
+		RegistryMirror:   append([]string{}, cfg.RegistryMirror...),
 		ArbitraryFlags:   cfg.DockerOpt,
 		InstallURL:       drivers.DefaultEngineInstallURL,
 	}
+
+	// dockerd can only mirror docker.io, so that is all it uses of the shared registry cache
+	if ep, ok := mirror.Endpoints()["docker.io"]; ok {
+		o.RegistryMirror = append(o.RegistryMirror, "http://"+ep)
+		o.InsecureRegistry = append(o.InsecureRegistry, ep)
+	}
 	return &o
 }
 
-func createHost(api libmachine.API, cfg *config.ClusterConfig, n *config.Node) (*host.Host, error) {
+func createHost(ctx context.Context, api libmachine.API, cfg *config.ClusterConfig, n *config.Node) (*host.Host, error) {
 	klog.Infof("createHost starting for %q (driver=%q)", n.Name, cfg.Driver)
 	start := time.Now()
 	defer func() {
@@ -162,7 +170,7 @@ func createHost(api libmachine.API, cfg *config.ClusterConfig, n *config.Node) (
 	if cfg.StartHostTimeout == 0 {
 		cfg.StartHostTimeout = 6 * time.Minute
 	}
-	if err := timedCreateHost(h, api, cfg.StartHostTimeout); err != nil {
+	if err := timedCreateHost(ctx, h, api, cfg.StartHostTimeout); err != nil {
 		return nil, errors.Wrap(err, "creating host")
 	}
 	klog.Infof("duration metric: libmachine.API.Create for %q took %s", cfg.Name, time.Since(cstart))
@@ -180,12 +188,9 @@ func createHost(api libmachine.API, cfg *config.ClusterConfig, n *config.Node) (
 	return h, nil
 }
 
-func timedCreateHost(h *host.Host, api libmachine.API, t time.Duration) error {
-	timeout := make(chan bool, 1)
-	go func() {
-		time.Sleep(t)
-		timeout <- true
-	}()
+func timedCreateHost(ctx context.Context, h *host.Host, api libmachine.API, t time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, t)
+	defer cancel()
 
 	createFinished := make(chan bool, 1)
 	var err error
@@ -202,8 +207,11 @@ func timedCreateHost(h *host.Host, api libmachine.API, t time.Duration) error {
 			return errors.Wrap(err, "create")
 		}
 		return nil
-	case <-timeout:
-		return fmt.Errorf("create host timed out in %f seconds", t.Seconds())
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("create host timed out in %f seconds", t.Seconds())
+		}
+		return errors.Wrap(ctx.Err(), "create")
 	}
 }
 
@@ -292,7 +300,7 @@ func postStartSetup(h *host.Host, mc config.ClusterConfig) error {
 	if driver.IsVM(mc.Driver) || driver.IsKIC(mc.Driver) || driver.IsSSH(mc.Driver) {
 		logRemoteOsRelease(r)
 	}
-	return syncLocalAssets(r)
+	return syncLocalAssets(r, mc)
 }
 
 // acquireMachinesLock protects against code that is not parallel-safe (libmachine, cert setup)
@@ -0,0 +1,195 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package mirror manages the pull-through registry cache which is shared by all profiles on a host
+package mirror
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/drivers/kic/oci"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+const (
+	// Image serves each cache, with the registry configured as a pull-through proxy
+	Image = "registry:2.7.1@sha256:d5459fcb27aecc752520df4b492b08358a1912fcdfa454f7d2101d4b09991daa"
+	// DefaultPort is the host port of the first cache, the others listening on the ports following it
+	DefaultPort = 5050
+
+	containerPrefix = "minikube-registry-cache-"
+)
+
+// containerStatus returns the state of a cache container, replaced in tests
+var containerStatus = oci.ContainerStatus
+
+// Upstream is a registry which is cached
+type Upstream struct {
+	// Registry is the name images are referenced by, such as docker.io
+	Registry string
+	// Remote is the URL the cache pulls through to
+	Remote string
+	// Port is the host port the cache listens on
+	Port int
+}
+
+// Upstreams are the registries which are cached, by default
+var Upstreams = []Upstream{
+	{Registry: "docker.io", Remote: "https://registry-1.docker.io"},
+	{Registry: "k8s.gcr.io", Remote: "https://k8s.gcr.io"},
+	{Registry: "gcr.io", Remote: "https://gcr.io"},
+	{Registry: "quay.io", Remote: "https://quay.io"},
+}
+
+// Config is the configuration of the running cache
+type Config struct {
+	// OCIBinary runs the cache containers, either docker or podman
+	OCIBinary string
+	Upstreams []Upstream
+}
+
+// Dir returns the directory holding the cache configuration and the cached images
+func Dir() string {
+	return localpath.MakeMiniPath("cache", "registry")
+}
+
+func configPath() string {
+	return filepath.Join(Dir(), "config.json")
+}
+
+func containerName(u Upstream) string {
+	return containerPrefix + strings.Replace(u.Registry, ".", "-", -1)
+}
+
+// Load returns the configuration of the running cache, or nil if it is not running
+func Load() (*Config, error) {
+	data, err := ioutil.ReadFile(configPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "read")
+	}
+	cfg := &Config{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal %s", configPath())
+	}
+	return cfg, nil
+}
+
+// Start starts a cache per upstream with ociBin, listening on consecutive host ports from port
+func Start(ociBin string, port int) (*Config, error) {
+	if old, err := Load(); err != nil {
+		klog.Warningf("unable to load registry cache config: %v", err)
+	} else if old != nil {
+		if err := Stop(); err != nil {
+			return nil, errors.Wrap(err, "stop")
+		}
+	}
+
+	cfg := &Config{OCIBinary: ociBin}
+	for i, u := range Upstreams {
+		u.Port = port + i
+		if err := run(ociBin, u); err != nil {
+			return nil, errors.Wrapf(err, "cache %s", u.Registry)
+		}
+		cfg.Upstreams = append(cfg.Upstreams, u)
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "    ")
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal")
+	}
+	if err := ioutil.WriteFile(configPath(), data, 0644); err != nil {
+		return nil, errors.Wrap(err, "write")
+	}
+	return cfg, nil
+}
+
+// run starts the cache container for u, keeping the cached images under Dir
+func run(ociBin string, u Upstream) error {
+	dir := filepath.Join(Dir(), u.Registry)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return errors.Wrapf(err, "mkdir %s", dir)
+	}
+
+	args := []string{"run", "-d", "--restart=unless-stopped", "--name", containerName(u)}
+	if ociBin == oci.Podman && runtime.GOOS == "linux" {
+		args = append(args, "--security-opt", "label=disable")
+	}
+	args = append(args, "-p", fmt.Sprintf("%d:5000", u.Port), "-v", fmt.Sprintf("%s:/var/lib/registry", dir),
+		"-e", "REGISTRY_PROXY_REMOTEURL="+u.Remote, Image)
+	c := oci.PrefixCmd(exec.Command(ociBin, args...))
+	klog.Infof("Run: %v", c.Args)
+	if out, err := c.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "%s: %s", strings.Join(c.Args, " "), out)
+	}
+	return nil
+}
+
+// Stop removes the cache containers, keeping the cached images for the next start
+func Stop() error {
+	cfg, err := Load()
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return nil
+	}
+
+	for _, u := range cfg.Upstreams {
+		c := oci.PrefixCmd(exec.Command(cfg.OCIBinary, "rm", "-f", containerName(u)))
+		klog.Infof("Run: %v", c.Args)
+		if out, err := c.CombinedOutput(); err != nil {
+			klog.Warningf("%s: %v: %s", strings.Join(c.Args, " "), err, out)
+		}
+	}
+	return os.Remove(configPath())
+}
+
+// Endpoints maps the cached registries to the address their cache is reachable at from within a guest.
+// Caches whose container is not running, e.g. after a host reboot without the OCI daemon, are left out.
+func Endpoints() map[string]string {
+	cfg, err := Load()
+	if err != nil {
+		klog.Warningf("unable to load registry cache config: %v", err)
+		return nil
+	}
+	if cfg == nil {
+		return nil
+	}
+
+	eps := map[string]string{}
+	for _, u := range cfg.Upstreams {
+		st, err := containerStatus(cfg.OCIBinary, containerName(u))
+		if err != nil || st != state.Running {
+			klog.Warningf("registry cache of %s is not running (state=%s, err=%v), not using it", u.Registry, st, err)
+			continue
+		}
+		eps[u.Registry] = fmt.Sprintf("%s:%d", constants.HostAlias, u.Port)
+	}
+	return eps
+}
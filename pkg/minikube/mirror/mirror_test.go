@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package mirror
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/docker/machine/libmachine/state"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+func TestEndpoints(t *testing.T) {
+	home, err := ioutil.TempDir("", "mirror")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(home)
+	defer os.Setenv(localpath.MinikubeHome, os.Getenv(localpath.MinikubeHome))
+	os.Setenv(localpath.MinikubeHome, home)
+
+	if got := Endpoints(); got != nil {
+		t.Errorf("Endpoints() without a running cache = %v, want nil", got)
+	}
+
+	cfg := Config{
+		OCIBinary: "docker",
+		Upstreams: []Upstream{
+			{Registry: "docker.io", Remote: "https://registry-1.docker.io", Port: 5050},
+			{Registry: "quay.io", Remote: "https://quay.io", Port: 5051},
+		},
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if err := os.MkdirAll(Dir(), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := ioutil.WriteFile(configPath(), data, 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	defer func(f func(string, string, ...bool) (state.State, error)) { containerStatus = f }(containerStatus)
+	containerStatus = func(_ string, name string, _ ...bool) (state.State, error) {
+		if name == "minikube-registry-cache-quay-io" {
+			return state.Stopped, nil
+		}
+		return state.Running, nil
+	}
+
+	want := map[string]string{
+		"docker.io": "host.minikube.internal:5050",
+	}
+	if diff := cmp.Diff(want, Endpoints()); diff != "" {
+		t.Errorf("Endpoints() diff (-want +got):\n%s", diff)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/docker/machine/libmachine/host"
 	"github.com/docker/machine/libmachine/state"
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/audit"
 	"k8s.io/minikube/pkg/minikube/bootstrapper/bsutil/kverify"
 	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/config"
@@ -175,5 +176,6 @@ func ExampleCmd(cname string, action string) string {
 func exitTip(action string, profile string, code int) {
 	command := ExampleCmd(profile, action)
 	out.Step(style.Workaround, `To start a cluster, run: "{{.command}}"`, out.V{"command": command})
+	audit.LogExit(code)
 	os.Exit(code)
 }
@@ -22,10 +22,15 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 
+	"github.com/docker/machine/libmachine/host"
+	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/command"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/cruntime"
@@ -52,7 +57,6 @@ func showVersionInfo(k8sVersion string, cr cruntime.Manager) {
 
 // configureMounts configures any requested filesystem mounts
 func configureMounts(wg *sync.WaitGroup) {
-	wg.Add(1)
 	defer wg.Done()
 
 	if !viper.GetBool(createMount) {
@@ -78,3 +82,60 @@ func configureMounts(wg *sync.WaitGroup) {
 		exit.Error(reason.HostMountPid, "Error writing mount pid", err)
 	}
 }
+
+// restoreMounts sets up the mounts saved in the profile by `minikube mount --persistent`
+func restoreMounts(wg *sync.WaitGroup, cc config.ClusterConfig, h *host.Host, r command.Runner) {
+	defer wg.Done()
+
+	for _, m := range cc.Mounts {
+		out.Step(style.Mounting, "Restoring mount {{.sourcePath}} into VM as {{.destinationPath}} ...", out.V{"sourcePath": m.HostPath, "destinationPath": m.GuestPath})
+		if err := StartMount(cc, h, r, m); err != nil {
+			out.FailureT("Unable to restore mount {{.path}}: {{.error}}", out.V{"path": m.GuestPath, "error": err})
+		}
+	}
+}
+
+// StartMount sets up a mount saved in the profile. NFS is mounted right away, while 9p and sshfs
+// are served by a minikube mount process in the background, unless one is already running.
+func StartMount(cc config.ClusterConfig, h *host.Host, r command.Runner, m config.Mount) error {
+	if m.Type == cluster.MountTypeNFS {
+		hostIP, err := cluster.HostIP(h, cc.Name)
+		if err != nil {
+			return errors.Wrap(err, "host ip")
+		}
+		nodeIP, err := h.Driver.GetIP()
+		if err != nil {
+			return errors.Wrap(err, "node ip")
+		}
+		mc := &cluster.MountConfig{Type: m.Type, Mode: os.FileMode(m.Mode), Options: cluster.MountOptions(m.Options)}
+		return cluster.MountNFS(r, cc.Name, hostIP.String(), nodeIP, m.HostPath, m.GuestPath, mc)
+	}
+
+	pid, err := cluster.MountPid(cc.Name, m.GuestPath)
+	if err != nil {
+		klog.Warningf("unable to find the mount process of %s: %v", m.GuestPath, err)
+	} else if pid != 0 {
+		klog.Infof("%s is already served by pid %d", m.GuestPath, pid)
+		return nil
+	}
+
+	args := []string{"mount", "-p", cc.Name, "--type", m.Type, "--uid", m.UID, "--gid", m.GID, fmt.Sprintf("--mode=0%o", m.Mode)}
+	if len(m.Options) > 0 {
+		args = append(args, "--options", strings.Join(m.Options, ","))
+	}
+	if klog.V(8).Enabled() {
+		args = append(args, "--v=1")
+	}
+	args = append(args, fmt.Sprintf("%s:%s", m.HostPath, m.GuestPath))
+	mountCmd := exec.Command(os.Args[0], args...)
+	mountCmd.Env = append(os.Environ(), constants.IsMinikubeChildProcess+"=true")
+	if klog.V(8).Enabled() {
+		mountCmd.Stdout = os.Stdout
+		mountCmd.Stderr = os.Stderr
+	}
+	klog.Infof("Starting %v", mountCmd.Args)
+	if err := mountCmd.Start(); err != nil {
+		return errors.Wrap(err, "start mount process")
+	}
+	return cluster.WriteMountPid(cc.Name, m.GuestPath, mountCmd.Process.Pid)
+}
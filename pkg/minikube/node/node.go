@@ -17,14 +17,18 @@ limitations under the License.
 package node
 
 import (
+	"context"
 	"fmt"
 	"os/exec"
 
+	"github.com/docker/machine/libmachine"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 
 	"k8s.io/klog/v2"
+	cmdcfg "k8s.io/minikube/cmd/minikube/cmd/config"
 	"k8s.io/minikube/pkg/kapi"
+	"k8s.io/minikube/pkg/minikube/cluster"
 	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/driver"
 	"k8s.io/minikube/pkg/minikube/machine"
@@ -37,7 +41,7 @@ const (
 )
 
 // Add adds a new node config to an existing cluster.
-func Add(cc *config.ClusterConfig, n config.Node, delOnFail bool) error {
+func Add(ctx context.Context, cc *config.ClusterConfig, n config.Node, delOnFail bool) error {
 	profiles, err := config.ListValidProfiles()
 	if err != nil {
 		return err
@@ -60,7 +64,7 @@ func Add(cc *config.ClusterConfig, n config.Node, delOnFail bool) error {
 		return errors.Wrap(err, "save node")
 	}
 
-	r, p, m, h, err := Provision(cc, &n, false, delOnFail)
+	r, p, m, h, err := Provision(ctx, cc, &n, false, delOnFail)
 	if err != nil {
 		return err
 	}
@@ -74,7 +78,7 @@ func Add(cc *config.ClusterConfig, n config.Node, delOnFail bool) error {
 		ExistingAddons: nil,
 	}
 
-	_, err = Start(s, false)
+	_, err = Start(ctx, s, false)
 	return err
 }
 
@@ -85,6 +89,10 @@ func Delete(cc config.ClusterConfig, name string) (*config.Node, error) {
 		return n, errors.Wrap(err, "retrieve")
 	}
 
+	if config.IsPrimaryControlPlane(cc, *n) {
+		return n, errors.Errorf("cannot delete the primary control plane node %s", n.Name)
+	}
+
 	m := config.MachineName(cc, *n)
 	api, err := machine.NewAPIClient()
 	if err != nil {
@@ -122,6 +130,14 @@ func Delete(cc config.ClusterConfig, name string) (*config.Node, error) {
 		return n, err
 	}
 
+	// kubeadm reset removes the etcd member of a control plane node,
+	// and stops the kubelet of a machine of the ssh driver, which outlives the node
+	if n.ControlPlane || driver.IsSSH(cc.Driver) {
+		if err := resetNode(api, cc, m); err != nil {
+			klog.Warningf("unable to reset node %s: %v", m, err)
+		}
+	}
+
 	err = machine.DeleteHost(api, m)
 	if err != nil {
 		return n, err
@@ -131,6 +147,25 @@ func Delete(cc config.ClusterConfig, name string) (*config.Node, error) {
 	return n, config.SaveProfile(viper.GetString(config.ProfileName), &cc)
 }
 
+// resetNode runs kubeadm reset on the node running on machine
+func resetNode(api libmachine.API, cc config.ClusterConfig, machineName string) error {
+	h, err := machine.LoadHost(api, machineName)
+	if err != nil {
+		return errors.Wrap(err, "load host")
+	}
+
+	r, err := machine.CommandRunner(h)
+	if err != nil {
+		return errors.Wrap(err, "command runner")
+	}
+
+	bs, err := cluster.Bootstrapper(api, viper.GetString(cmdcfg.Bootstrapper), cc, r)
+	if err != nil {
+		return errors.Wrap(err, "bootstrapper")
+	}
+	return bs.DeleteCluster(cc.KubernetesConfig)
+}
+
 // Retrieve finds the node by name in the given cluster
 func Retrieve(cc config.ClusterConfig, name string) (*config.Node, int, error) {
 	if driver.BareMetal(cc.Driver) {
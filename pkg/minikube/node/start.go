@@ -17,6 +17,7 @@ limitations under the License.
 package node
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
@@ -51,12 +52,14 @@ import (
 	"k8s.io/minikube/pkg/minikube/localpath"
 	"k8s.io/minikube/pkg/minikube/logs"
 	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/mirror"
 	"k8s.io/minikube/pkg/minikube/mustload"
 	"k8s.io/minikube/pkg/minikube/out"
 	"k8s.io/minikube/pkg/minikube/out/register"
 	"k8s.io/minikube/pkg/minikube/proxy"
 	"k8s.io/minikube/pkg/minikube/reason"
 	"k8s.io/minikube/pkg/minikube/style"
+	"k8s.io/minikube/pkg/trace"
 	"k8s.io/minikube/pkg/util"
 	"k8s.io/minikube/pkg/util/retry"
 )
@@ -79,8 +82,13 @@ type Starter struct {
 	ExistingAddons map[string]bool
 }
 
-// Start spins up a guest and starts the Kubernetes node.
-func Start(starter Starter, apiServer bool) (*kubeconfig.Settings, error) {
+// Start spins up a guest and starts the Kubernetes node, giving up once ctx is done.
+func Start(ctx context.Context, starter Starter, apiServer bool) (*kubeconfig.Settings, error) {
+	defer trace.EndNodeSpan(config.MachineName(*starter.Cfg, *starter.Node))
+
+	// every command run against the node from here on is cancelled along with ctx
+	starter.Runner = command.WithContext(ctx, starter.Runner)
+
 	// wait for preloaded tarball to finish downloading before configuring runtimes
 	waitCacheRequiredImages(&cacheGroup)
 
@@ -90,7 +98,10 @@ func Start(starter Starter, apiServer bool) (*kubeconfig.Settings, error) {
 	}
 
 	// configure the runtime (docker, containerd, crio)
-	cr := configureRuntimes(starter.Runner, *starter.Cfg, sv)
+	cr, err := configureRuntimes(ctx, starter.Runner, *starter.Cfg, sv)
+	if err != nil {
+		return nil, err
+	}
 	showVersionInfo(starter.Node.KubernetesVersion, cr)
 
 	// Add "host.minikube.internal" DNS alias (intentionally non-fatal)
@@ -112,7 +123,7 @@ func Start(starter Starter, apiServer bool) (*kubeconfig.Settings, error) {
 
 		// setup kubeadm (must come after setupKubeconfig)
 		bs = setupKubeAdm(starter.MachineAPI, *starter.Cfg, *starter.Node, starter.Runner)
-		err = bs.StartCluster(*starter.Cfg)
+		err = bs.StartCluster(ctx, *starter.Cfg)
 		if err != nil {
 			ExitIfFatal(err)
 			out.LogEntries("Error starting cluster", err, logs.FindProblems(cr, bs, *starter.Cfg, starter.Runner))
@@ -140,8 +151,13 @@ func Start(starter Starter, apiServer bool) (*kubeconfig.Settings, error) {
 
 	var wg sync.WaitGroup
 	if !driver.IsKIC(starter.Cfg.Driver) {
+		wg.Add(1)
 		go configureMounts(&wg)
 	}
+	if apiServer && starter.Cfg.Driver != driver.None {
+		wg.Add(1)
+		go restoreMounts(&wg, *starter.Cfg, starter.Host, starter.Runner)
+	}
 
 	wg.Add(1)
 	go func() {
@@ -153,6 +169,11 @@ func Start(starter Starter, apiServer bool) (*kubeconfig.Settings, error) {
 		if err := CacheAndLoadImagesInConfig([]*config.Profile{profile}); err != nil {
 			out.FailureT("Unable to push cached images: {{.error}}", out.V{"error": err})
 		}
+		if len(starter.Cfg.OfflineImages) > 0 {
+			if err := machine.CacheAndLoadImages(starter.Cfg.OfflineImages, []*config.Profile{profile}); err != nil {
+				out.FailureT("Unable to load the bundled images: {{.error}}", out.V{"error": err})
+			}
+		}
 	}()
 
 	// enable addons, both old and new!
@@ -180,12 +201,12 @@ func Start(starter Starter, apiServer bool) (*kubeconfig.Settings, error) {
 			return nil, errors.Wrap(err, "getting control plane bootstrapper")
 		}
 
-		joinCmd, err := cpBs.GenerateToken(*starter.Cfg)
+		joinCmd, err := cpBs.GenerateToken(*starter.Cfg, *starter.Node)
 		if err != nil {
 			return nil, errors.Wrap(err, "generating join token")
 		}
 
-		if err = bs.JoinCluster(*starter.Cfg, *starter.Node, joinCmd); err != nil {
+		if err = bs.JoinCluster(ctx, *starter.Cfg, *starter.Node, joinCmd); err != nil {
 			return nil, errors.Wrap(err, "joining cluster")
 		}
 
@@ -200,7 +221,7 @@ func Start(starter Starter, apiServer bool) (*kubeconfig.Settings, error) {
 	}
 
 	klog.Infof("Will wait %s for node up to ", viper.GetDuration(waitTimeout))
-	if err := bs.WaitForNode(*starter.Cfg, *starter.Node, viper.GetDuration(waitTimeout)); err != nil {
+	if err := bs.WaitForNode(ctx, *starter.Cfg, *starter.Node, viper.GetDuration(waitTimeout)); err != nil {
 		return nil, errors.Wrapf(err, "wait %s for node", viper.GetDuration(waitTimeout))
 	}
 
@@ -212,10 +233,14 @@ func Start(starter Starter, apiServer bool) (*kubeconfig.Settings, error) {
 }
 
 // Provision provisions the machine/container for the node
-func Provision(cc *config.ClusterConfig, n *config.Node, apiServer bool, delOnFail bool) (command.Runner, bool, libmachine.API, *host.Host, error) {
-	register.Reg.SetStep(register.StartingNode)
+func Provision(ctx context.Context, cc *config.ClusterConfig, n *config.Node, apiServer bool, delOnFail bool) (command.Runner, bool, libmachine.API, *host.Host, error) {
 	name := config.MachineName(*cc, *n)
-	if apiServer {
+	trace.StartNodeSpan(name, map[string]string{
+		"minikube.control_plane":      strconv.FormatBool(apiServer || n.ControlPlane),
+		"minikube.kubernetes_version": n.KubernetesVersion,
+	})
+	register.Reg.SetStep(register.StartingNode)
+	if apiServer || n.ControlPlane {
 		out.Step(style.ThumbsUp, "Starting control plane node {{.name}} in cluster {{.cluster}}", out.V{"name": name, "cluster": cc.Name})
 	} else {
 		out.Step(style.ThumbsUp, "Starting node {{.name}} in cluster {{.cluster}}", out.V{"name": name, "cluster": cc.Name})
@@ -238,11 +263,12 @@ func Provision(cc *config.ClusterConfig, n *config.Node, apiServer bool, delOnFa
 	handleDownloadOnly(&cacheGroup, &kicGroup, n.KubernetesVersion)
 	waitDownloadKicBaseImage(&kicGroup)
 
-	return startMachine(cc, n, delOnFail)
+	return startMachine(ctx, cc, n, delOnFail)
 }
 
 // ConfigureRuntimes does what needs to happen to get a runtime going.
-func configureRuntimes(runner cruntime.CommandRunner, cc config.ClusterConfig, kv semver.Version) cruntime.Manager {
+// Failures are fatal, unless they were caused by ctx being done.
+func configureRuntimes(ctx context.Context, runner cruntime.CommandRunner, cc config.ClusterConfig, kv semver.Version) (cruntime.Manager, error) {
 	co := cruntime.Config{
 		Type:              cc.KubernetesConfig.ContainerRuntime,
 		Socket:            cc.KubernetesConfig.CRISocket,
@@ -250,6 +276,7 @@ func configureRuntimes(runner cruntime.CommandRunner, cc config.ClusterConfig, k
 		ImageRepository:   cc.KubernetesConfig.ImageRepository,
 		KubernetesVersion: kv,
 		InsecureRegistry:  cc.InsecureRegistry,
+		RegistryMirrors:   mirror.Endpoints(),
 	}
 	cr, err := cruntime.New(co)
 	if err != nil {
@@ -278,24 +305,38 @@ func configureRuntimes(runner cruntime.CommandRunner, cc config.ClusterConfig, k
 		}
 	}
 
-	err = cr.Enable(disableOthers, forceSystemd())
+	// the cgroup subtree delegated to a rootless node is owned by its systemd, and the distributions
+	// given to the ssh driver which only mount cgroup v2 expect runtimes to use the systemd cgroup driver
+	err = cr.Enable(disableOthers, forceSystemd() || cc.Rootless || (driver.IsSSH(cc.Driver) && usesCgroupV2(runner)))
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, errors.Wrap(err, "enable container runtime")
+		}
 		exit.Error(reason.RuntimeEnable, "Failed to enable container runtime", err)
 	}
 
 	// Wait for the CRI to be "live", before returning it
-	err = waitForCRISocket(runner, cr.SocketPath(), 60, 1)
+	err = waitForCRISocket(ctx, runner, cr.SocketPath(), 60, 1)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, errors.Wrap(err, "start container runtime")
+		}
 		exit.Error(reason.RuntimeEnable, "Failed to start container runtime", err)
 	}
 
-	return cr
+	return cr, nil
 }
 
 func forceSystemd() bool {
 	return viper.GetBool("force-systemd") || os.Getenv(constants.MinikubeForceSystemdEnv) == "true"
 }
 
+// usesCgroupV2 returns whether the node only mounts the unified cgroup hierarchy
+func usesCgroupV2(runner cruntime.CommandRunner) bool {
+	rr, err := runner.RunCmd(exec.Command("stat", "-fc", "%T", "/sys/fs/cgroup/"))
+	return err == nil && strings.TrimSpace(rr.Stdout.String()) == "cgroup2fs"
+}
+
 func pathExists(runner cruntime.CommandRunner, path string) (bool, error) {
 	_, err := runner.RunCmd(exec.Command("stat", path))
 	if err == nil {
@@ -307,7 +348,7 @@ func pathExists(runner cruntime.CommandRunner, path string) (bool, error) {
 	return false, err
 }
 
-func waitForCRISocket(runner cruntime.CommandRunner, socket string, wait int, interval int) error {
+func waitForCRISocket(ctx context.Context, runner cruntime.CommandRunner, socket string, wait int, interval int) error {
 
 	if socket == "" || socket == "/var/run/dockershim.sock" {
 		return nil
@@ -325,7 +366,7 @@ func waitForCRISocket(runner cruntime.CommandRunner, socket string, wait int, in
 		}
 		return nil
 	}
-	if err := retry.Expo(chkPath, time.Duration(interval)*time.Second, time.Duration(wait)*time.Second); err != nil {
+	if err := retry.ExpoContext(ctx, chkPath, time.Duration(interval)*time.Second, time.Duration(wait)*time.Second); err != nil {
 		return err
 	}
 
@@ -388,12 +429,12 @@ func apiServerURL(h host.Host, cc config.ClusterConfig, n config.Node) (string,
 }
 
 // StartMachine starts a VM
-func startMachine(cfg *config.ClusterConfig, node *config.Node, delOnFail bool) (runner command.Runner, preExists bool, machineAPI libmachine.API, host *host.Host, err error) {
+func startMachine(ctx context.Context, cfg *config.ClusterConfig, node *config.Node, delOnFail bool) (runner command.Runner, preExists bool, machineAPI libmachine.API, host *host.Host, err error) {
 	m, err := machine.NewAPIClient()
 	if err != nil {
 		return runner, preExists, m, host, errors.Wrap(err, "Failed to get machine client")
 	}
-	host, preExists, err = startHost(m, cfg, node, delOnFail)
+	host, preExists, err = startHost(ctx, m, cfg, node, delOnFail)
 	if err != nil {
 		return runner, preExists, m, host, errors.Wrap(err, "Failed to start host")
 	}
@@ -417,8 +458,8 @@ func startMachine(cfg *config.ClusterConfig, node *config.Node, delOnFail bool)
 }
 
 // startHost starts a new minikube host using a VM or None
-func startHost(api libmachine.API, cc *config.ClusterConfig, n *config.Node, delOnFail bool) (*host.Host, bool, error) {
-	host, exists, err := machine.StartHost(api, cc, n)
+func startHost(ctx context.Context, api libmachine.API, cc *config.ClusterConfig, n *config.Node, delOnFail bool) (*host.Host, bool, error) {
+	host, exists, err := machine.StartHost(ctx, api, cc, n)
 	if err == nil {
 		return host, exists, nil
 	}
@@ -436,10 +477,16 @@ func startHost(api libmachine.API, cc *config.ClusterConfig, n *config.Node, del
 		return host, exists, err
 	}
 
+	if ctx.Err() != nil {
+		return host, exists, err
+	}
+
 	out.ErrT(style.Embarrassed, "StartHost failed, but will try again: {{.error}}", out.V{"error": err})
 	klog.Info("Will try again in 5 seconds ...")
 	// Try again, but just once to avoid making the logs overly confusing
-	time.Sleep(5 * time.Second)
+	if err := retry.Sleep(ctx, 5*time.Second); err != nil {
+		return host, exists, err
+	}
 
 	if delOnFail {
 		klog.Info("Deleting existing host since delete-on-failure was set.")
@@ -450,7 +497,7 @@ func startHost(api libmachine.API, cc *config.ClusterConfig, n *config.Node, del
 		}
 	}
 
-	host, exists, err = machine.StartHost(api, cc, n)
+	host, exists, err = machine.StartHost(ctx, api, cc, n)
 	if err == nil {
 		return host, exists, nil
 	}
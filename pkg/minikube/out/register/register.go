@@ -144,3 +144,8 @@ func (r *Register) SetStep(s RegStep) {
 
 	r.current = s
 }
+
+// Step returns the step we are currently on
+func (r *Register) Step() RegStep {
+	return r.current
+}
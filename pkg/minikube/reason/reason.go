@@ -203,6 +203,8 @@ var (
 		Issues:   []int{9165},
 	}
 
+	HostAuditLog            = Kind{ID: "HOST_AUDIT_LOG", ExitCode: ExHostError}
+	HostBundle              = Kind{ID: "HOST_BUNDLE", ExitCode: ExHostError}
 	HostCurrentUser         = Kind{ID: "HOST_CURRENT_USER", ExitCode: ExHostConfig}
 	HostDelCache            = Kind{ID: "HOST_DEL_CACHE", ExitCode: ExHostError}
 	HostKillMountProc       = Kind{ID: "HOST_KILL_MOUNT_PROC", ExitCode: ExHostError}
@@ -214,6 +216,7 @@ var (
 	HostPathMissing         = Kind{ID: "HOST_PATH_MISSING", ExitCode: ExHostNotFound}
 	HostPathStat            = Kind{ID: "HOST_PATH_STAT", ExitCode: ExHostError}
 	HostPurge               = Kind{ID: "HOST_PURGE", ExitCode: ExHostError}
+	HostRegistryCache       = Kind{ID: "HOST_REGISTRY_CACHE", ExitCode: ExHostError}
 	HostSaveProfile         = Kind{ID: "HOST_SAVE_PROFILE", ExitCode: ExHostConfig}
 
 	ProviderNotFound    = Kind{ID: "PROVIDER_NOT_FOUND", ExitCode: ExProviderNotFound}
@@ -226,21 +229,30 @@ var (
 		ExitCode: ExDriverError,
 		Style:    style.Failure,
 	}
-	DrvPortForward        = Kind{ID: "DRV_PORT_FORWARD", ExitCode: ExDriverError}
-	DrvUnsupportedMulti   = Kind{ID: "DRV_UNSUPPORTED_MULTINODE", ExitCode: ExDriverConflict}
-	DrvUnsupportedOS      = Kind{ID: "DRV_UNSUPPORTED_OS", ExitCode: ExDriverUnsupported}
-	DrvUnsupportedProfile = Kind{ID: "DRV_UNSUPPORTED_PROFILE", ExitCode: ExDriverUnsupported}
-	DrvNotFound           = Kind{ID: "DRV_NOT_FOUND", ExitCode: ExDriverNotFound}
-	DrvNotDetected        = Kind{ID: "DRV_NOT_DETECTED", ExitCode: ExDriverNotFound}
-	DrvAsRoot             = Kind{ID: "DRV_AS_ROOT", ExitCode: ExDriverPermission}
-	DrvNeedsRoot          = Kind{ID: "DRV_NEEDS_ROOT", ExitCode: ExDriverPermission}
-	DrvNeedsAdministrator = Kind{ID: "DRV_NEEDS_ADMINISTRATOR", ExitCode: ExDriverPermission}
+	DrvPortForward         = Kind{ID: "DRV_PORT_FORWARD", ExitCode: ExDriverError}
+	DrvUnsupportedMulti    = Kind{ID: "DRV_UNSUPPORTED_MULTINODE", ExitCode: ExDriverConflict}
+	DrvUnsupportedOS       = Kind{ID: "DRV_UNSUPPORTED_OS", ExitCode: ExDriverUnsupported}
+	DrvUnsupportedProfile  = Kind{ID: "DRV_UNSUPPORTED_PROFILE", ExitCode: ExDriverUnsupported}
+	DrvUnsupportedRootless = Kind{ID: "DRV_UNSUPPORTED_ROOTLESS", ExitCode: ExDriverUnsupported, URL: "https://rootlesscontaine.rs/getting-started/common/cgroup2/"}
+	DrvNotFound            = Kind{ID: "DRV_NOT_FOUND", ExitCode: ExDriverNotFound}
+	DrvNotDetected         = Kind{ID: "DRV_NOT_DETECTED", ExitCode: ExDriverNotFound}
+	DrvAsRoot              = Kind{ID: "DRV_AS_ROOT", ExitCode: ExDriverPermission}
+	DrvNeedsRoot           = Kind{ID: "DRV_NEEDS_ROOT", ExitCode: ExDriverPermission}
+	DrvNeedsAdministrator  = Kind{ID: "DRV_NEEDS_ADMINISTRATOR", ExitCode: ExDriverPermission}
+	DrvUnhealthy           = Kind{ID: "DRV_UNHEALTHY", ExitCode: ExDriverUnavailable}
 
 	GuestCacheLoad        = Kind{ID: "GUEST_CACHE_LOAD", ExitCode: ExGuestError}
 	GuestCert             = Kind{ID: "GUEST_CERT", ExitCode: ExGuestError}
 	GuestCpConfig         = Kind{ID: "GUEST_CP_CONFIG", ExitCode: ExGuestConfig}
 	GuestDeletion         = Kind{ID: "GUEST_DELETION", ExitCode: ExGuestError}
+	GuestImageBuild       = Kind{ID: "GUEST_IMAGE_BUILD", ExitCode: ExGuestError}
+	GuestImageList        = Kind{ID: "GUEST_IMAGE_LIST", ExitCode: ExGuestError}
 	GuestImageLoad        = Kind{ID: "GUEST_IMAGE_LOAD", ExitCode: ExGuestError}
+	GuestImagePull        = Kind{ID: "GUEST_IMAGE_PULL", ExitCode: ExGuestError}
+	GuestImageRemove      = Kind{ID: "GUEST_IMAGE_REMOVE", ExitCode: ExGuestError}
+	GuestImageSave        = Kind{ID: "GUEST_IMAGE_SAVE", ExitCode: ExGuestError}
+	GuestImageTag         = Kind{ID: "GUEST_IMAGE_TAG", ExitCode: ExGuestError}
+	GuestKubeadmConfig    = Kind{ID: "GUEST_KUBEADM_CONFIG", ExitCode: ExGuestError}
 	GuestLoadHost         = Kind{ID: "GUEST_LOAD_HOST", ExitCode: ExGuestError}
 	GuestMount            = Kind{ID: "GUEST_MOUNT", ExitCode: ExGuestError}
 	GuestMountConflict    = Kind{ID: "GUEST_MOUNT_CONFLICT", ExitCode: ExGuestConflict}
@@ -252,7 +264,12 @@ var (
 	GuestPause            = Kind{ID: "GUEST_PAUSE", ExitCode: ExGuestError}
 	GuestProfileDeletion  = Kind{ID: "GUEST_PROFILE_DELETION", ExitCode: ExGuestError}
 	GuestProvision        = Kind{ID: "GUEST_PROVISION", ExitCode: ExGuestError}
+	GuestScheduleSync     = Kind{ID: "GUEST_SCHEDULE_SYNC", ExitCode: ExGuestError}
+	GuestSnapshotList     = Kind{ID: "GUEST_SNAPSHOT_LIST", ExitCode: ExGuestError}
+	GuestSnapshotRestore  = Kind{ID: "GUEST_SNAPSHOT_RESTORE", ExitCode: ExGuestError}
+	GuestSnapshotSave     = Kind{ID: "GUEST_SNAPSHOT_SAVE", ExitCode: ExGuestError}
 	GuestStart            = Kind{ID: "GUEST_START", ExitCode: ExGuestError}
+	GuestStartTimeout     = Kind{ID: "GUEST_START_TIMEOUT", ExitCode: ExGuestTimeout}
 	GuestStatus           = Kind{ID: "GUEST_STATUS", ExitCode: ExGuestError}
 	GuestStopTimeout      = Kind{ID: "GUEST_STOP_TIMEOUT", ExitCode: ExGuestTimeout}
 	GuestUnpause          = Kind{ID: "GUEST_UNPAUSE", ExitCode: ExGuestError}
@@ -265,6 +282,7 @@ var (
 	IfSSHClient = Kind{ID: "IF_SSH_CLIENT", ExitCode: ExLocalNetworkError}
 
 	InetCacheBinaries      = Kind{ID: "INET_CACHE_BINARIES", ExitCode: ExInternetError}
+	InetCacheBundle        = Kind{ID: "INET_CACHE_BUNDLE", ExitCode: ExInternetError}
 	InetCacheKubectl       = Kind{ID: "INET_CACHE_KUBECTL", ExitCode: ExInternetError}
 	InetCacheTar           = Kind{ID: "INET_CACHE_TAR", ExitCode: ExInternetError}
 	InetGetVersions        = Kind{ID: "INET_GET_VERSIONS", ExitCode: ExInternetError}
@@ -289,6 +307,7 @@ var (
 	EnvMultiConflict     = Kind{ID: "ENV_MULTINODE_CONFLICT", ExitCode: ExGuestConflict}
 	EnvDockerUnavailable = Kind{ID: "ENV_DOCKER_UNAVAILABLE", ExitCode: ExRuntimeUnavailable}
 	EnvPodmanUnavailable = Kind{ID: "ENV_PODMAN_UNAVAILABLE", ExitCode: ExRuntimeUnavailable}
+	EnvContainerdTunnel  = Kind{ID: "ENV_CONTAINERD_TUNNEL", ExitCode: ExLocalNetworkError}
 
 	AddonUnsupported = Kind{ID: "SVC_ADDON_UNSUPPORTED", ExitCode: ExSvcUnsupported}
 	AddonNotEnabled  = Kind{ID: "SVC_ADDON_NOT_ENABLED", ExitCode: ExProgramConflict}
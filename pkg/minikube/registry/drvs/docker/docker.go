@@ -46,6 +46,7 @@ func init() {
 		Config:   configure,
 		Init:     func() drivers.Driver { return kic.NewDriver(kic.Config{OCIBinary: oci.Docker}) },
 		Status:   status,
+		Doctor:   func() []registry.Check { return driver.KICChecks(oci.Docker) },
 		Default:  true,
 		Priority: registry.HighlyPreferred,
 	}); err != nil {
@@ -24,6 +24,7 @@ import (
 	_ "k8s.io/minikube/pkg/minikube/registry/drvs/kvm2"
 	_ "k8s.io/minikube/pkg/minikube/registry/drvs/none"
 	_ "k8s.io/minikube/pkg/minikube/registry/drvs/parallels"
+	_ "k8s.io/minikube/pkg/minikube/registry/drvs/plugin"
 	_ "k8s.io/minikube/pkg/minikube/registry/drvs/podman"
 	_ "k8s.io/minikube/pkg/minikube/registry/drvs/ssh"
 	_ "k8s.io/minikube/pkg/minikube/registry/drvs/virtualbox"
@@ -24,6 +24,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -46,6 +47,7 @@ func init() {
 		Alias:    []string{driver.AliasKVM},
 		Config:   configure,
 		Status:   status,
+		Doctor:   doctor,
 		Default:  true,
 		Priority: registry.Preferred,
 	}); err != nil {
@@ -140,3 +142,47 @@ func status() registry.State {
 	}
 	return registry.State{Installed: true, Healthy: true}
 }
+
+// doctor checks access to KVM and the libvirt daemon minikube connects to
+func doctor() []registry.Check {
+	uri := defaultURI()
+	kvm := registry.Check{Name: "/dev/kvm is accessible", Doc: docURL}
+	if f, err := os.OpenFile("/dev/kvm", os.O_RDWR, 0); err != nil {
+		kvm.Error = err
+		kvm.Fix = "Enable virtualization in the BIOS, load the kvm module, and make sure you are a member of the 'kvm' group"
+	} else {
+		f.Close()
+	}
+	checks := []registry.Check{kvm}
+
+	conn := registry.Check{Name: fmt.Sprintf("libvirt connection to %s", uri), Doc: docURL}
+	if out, err := virsh(uri, "version", "--daemon"); err != nil {
+		conn.Error = fmt.Errorf("%v: %s", err, out)
+		conn.Fix = "Check that libvirtd is running, and that you are a member of the 'libvirt' group"
+		// the network can not be checked without a connection
+		return append(checks, conn)
+	}
+	checks = append(checks, conn)
+
+	network := registry.Check{Name: "libvirt network 'default' is active", Doc: docURL}
+	out, err := virsh(uri, "net-info", "default")
+	switch {
+	case err != nil:
+		network.Error = fmt.Errorf("%v: %s", err, out)
+		network.Fix = "Create the 'default' network, or pick another with --kvm-network"
+	case !regexp.MustCompile(`Active:\s+yes`).MatchString(out):
+		network.Error = fmt.Errorf("the 'default' network is not active")
+		network.Fix = "Start it with 'virsh net-start default'"
+	}
+	return append(checks, network)
+}
+
+// virsh runs a virsh command against uri
+func virsh(uri string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "virsh", append([]string{"-c", uri}, args...)...)
+	out, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(out)), err
+}
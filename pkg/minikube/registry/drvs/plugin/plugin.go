@@ -0,0 +1,249 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/docker/machine/libmachine/drivers"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/download"
+	"k8s.io/minikube/pkg/minikube/localpath"
+	"k8s.io/minikube/pkg/minikube/registry"
+)
+
+const (
+	// Prefix is the name prefix of driver plugin executables, shared with docker-machine
+	Prefix = "docker-machine-driver-"
+
+	// InfoEnv is set to "1" when a plugin is asked to describe itself: it
+	// should write its Info as JSON to stdout and exit.
+	InfoEnv = "MINIKUBE_DRIVER_PLUGIN_INFO"
+
+	// infoTimeout is how long a plugin has to describe itself
+	infoTimeout = 5 * time.Second
+)
+
+// Info is what a driver plugin advertises about itself
+type Info struct {
+	// Name of the driver, which must match the executable name after Prefix
+	Name string `json:"name"`
+	// Alias contains other names the driver may be selected by
+	Alias []string `json:"alias,omitempty"`
+	// Kind is either "vm" or "baremetal", defaulting to "vm". Containers are only run by the docker and podman drivers.
+	Kind string `json:"kind,omitempty"`
+	// Priority is one of "experimental", "discouraged", "deprecated", "fallback", "default" or "preferred"
+	Priority string `json:"priority"`
+	// Default is whether the driver may be selected when none was asked for
+	Default bool `json:"default"`
+	// Status is the installation status of the driver on this host
+	Status Status `json:"status"`
+}
+
+// Status is the installation status of a driver plugin, see registry.State
+type Status struct {
+	Installed        bool   `json:"installed"`
+	Healthy          bool   `json:"healthy"`
+	Running          bool   `json:"running"`
+	NeedsImprovement bool   `json:"needsImprovement"`
+	Error            string `json:"error,omitempty"`
+	Reason           string `json:"reason,omitempty"`
+	Fix              string `json:"fix,omitempty"`
+	Doc              string `json:"doc,omitempty"`
+}
+
+var priorities = map[string]registry.Priority{
+	"experimental": registry.Experimental,
+	"discouraged":  registry.Discouraged,
+	"deprecated":   registry.Deprecated,
+	"fallback":     registry.Fallback,
+	"default":      registry.Default,
+	"preferred":    registry.Preferred,
+}
+
+func init() {
+	registry.AddDiscoverer(discover)
+}
+
+// Dir is where driver plugins are installed for minikube alone. It is searched before the PATH.
+func Dir() string {
+	return localpath.MakeMiniPath("drivers")
+}
+
+// discover returns the drivers of the plugins found in Dir and on the PATH
+func discover(known func(string) bool) []registry.DriverDef {
+	dirs := append([]string{Dir()}, filepath.SplitList(os.Getenv("PATH"))...)
+	return find(dirs, known)
+}
+
+// find returns the drivers of the plugins found in dirs, earlier dirs taking precedence
+func find(dirs []string, known func(string) bool) []registry.DriverDef {
+	seen := map[string]bool{}
+	defs := []registry.DriverDef{}
+	for _, dir := range dirs {
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, f := range files {
+			name := pluginName(f)
+			if name == "" || seen[name] || known(name) {
+				continue
+			}
+			seen[name] = true
+
+			path := filepath.Join(dir, f.Name())
+			info, err := query(path)
+			if err != nil {
+				klog.Infof("ignoring driver plugin %s: %v", path, err)
+				continue
+			}
+			def, err := definition(path, name, info)
+			if err != nil {
+				klog.Warningf("ignoring driver plugin %s: %v", path, err)
+				continue
+			}
+			klog.Infof("found driver plugin %s: %+v", path, info)
+			defs = append(defs, def)
+		}
+	}
+	return defs
+}
+
+// pluginName returns the driver name of a plugin executable, or "" if f is not one
+func pluginName(f os.FileInfo) string {
+	name := f.Name()
+	if runtime.GOOS == "windows" {
+		if !strings.HasSuffix(name, ".exe") {
+			return ""
+		}
+		name = strings.TrimSuffix(name, ".exe")
+	} else if f.Mode()&0111 == 0 {
+		return ""
+	}
+	if f.IsDir() || !strings.HasPrefix(name, Prefix) {
+		return ""
+	}
+	return strings.TrimPrefix(name, Prefix)
+}
+
+// query asks the plugin at path to describe itself
+func query(path string) (Info, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), infoTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Env = append(os.Environ(), InfoEnv+"=1")
+	out, err := cmd.Output()
+	if err != nil {
+		return Info{}, errors.Wrap(err, "not a minikube driver plugin")
+	}
+	var info Info
+	if err := json.Unmarshal(out, &info); err != nil {
+		return Info{}, errors.Wrap(err, "parsing plugin info")
+	}
+	return info, nil
+}
+
+// definition returns the driver definition for a plugin
+func definition(path string, name string, info Info) (registry.DriverDef, error) {
+	if info.Name != name {
+		return registry.DriverDef{}, fmt.Errorf("plugin advertises driver %q, but is named for %q", info.Name, name)
+	}
+	priority, ok := priorities[info.Priority]
+	if !ok {
+		return registry.DriverDef{}, fmt.Errorf("unknown priority %q", info.Priority)
+	}
+	kind := registry.Kind(info.Kind)
+	switch kind {
+	case "":
+		kind = registry.KindVM
+	case registry.KindVM, registry.KindBareMetal:
+	case "kic":
+		return registry.DriverDef{}, fmt.Errorf("kind %q is only supported by the built in docker and podman drivers", info.Kind)
+	default:
+		return registry.DriverDef{}, fmt.Errorf("unknown kind %q", info.Kind)
+	}
+
+	st := registry.State{
+		Installed:        info.Status.Installed,
+		Healthy:          info.Status.Healthy,
+		Running:          info.Status.Running,
+		NeedsImprovement: info.Status.NeedsImprovement,
+		Reason:           info.Status.Reason,
+		Fix:              info.Status.Fix,
+		Doc:              info.Status.Doc,
+	}
+	if info.Status.Error != "" {
+		st.Error = errors.New(info.Status.Error)
+	}
+
+	return registry.DriverDef{
+		Name:     name,
+		Alias:    info.Alias,
+		Config:   configure,
+		Status:   func() registry.State { return st },
+		Default:  info.Default,
+		Priority: priority,
+		Kind:     kind,
+		Plugin:   path,
+	}, nil
+}
+
+// pluginConfig is passed to plugins as their raw driver config. It carries
+// the same fields as the configs of the built in VM drivers.
+type pluginConfig struct {
+	*drivers.BaseDriver
+
+	Memory            int
+	CPU               int
+	DiskSize          int
+	Boot2DockerURL    string
+	ISO               string
+	KubernetesVersion string
+	ContainerRuntime  string
+}
+
+func configure(cc config.ClusterConfig, n config.Node) (interface{}, error) {
+	name := config.MachineName(cc, n)
+	return pluginConfig{
+		BaseDriver: &drivers.BaseDriver{
+			MachineName: name,
+			StorePath:   localpath.MiniPath(),
+			SSHUser:     "docker",
+		},
+		Memory:            cc.Memory,
+		CPU:               cc.CPUs,
+		DiskSize:          cc.DiskSize,
+		Boot2DockerURL:    download.LocalISOResource(cc.MinikubeISO),
+		ISO:               filepath.Join(localpath.MiniPath(), "machines", name, "boot2docker.iso"),
+		KubernetesVersion: n.KubernetesVersion,
+		ContainerRuntime:  cc.KubernetesConfig.ContainerRuntime,
+	}, nil
+}
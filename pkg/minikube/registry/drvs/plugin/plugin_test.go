@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"k8s.io/minikube/pkg/minikube/registry"
+)
+
+// writePlugin writes a plugin to dir, which prints info when asked to describe itself
+func writePlugin(t *testing.T, dir string, name string, info string, mode os.FileMode) {
+	t.Helper()
+	script := "#!/bin/sh\nif [ \"$" + InfoEnv + "\" = 1 ]; then\n  echo '" + info + "'\n  exit 0\nfi\nexit 1\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, Prefix+name), []byte(script), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFind(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+	first, err := ioutil.TempDir("", "drivers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(first)
+	second, err := ioutil.TempDir("", "path")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(second)
+
+	writePlugin(t, first, "acme", `{"name":"acme","priority":"preferred","default":true,"status":{"installed":true,"healthy":true}}`, 0755)
+	writePlugin(t, second, "acme", `{"name":"acme","priority":"fallback"}`, 0755)
+	writePlugin(t, second, "metal", `{"name":"metal","kind":"baremetal","priority":"experimental","status":{"installed":true,"error":"no metal"}}`, 0755)
+	writePlugin(t, second, "renamed", `{"name":"other","priority":"default"}`, 0755)
+	writePlugin(t, second, "badkind", `{"name":"badkind","kind":"cloud","priority":"default"}`, 0755)
+	writePlugin(t, second, "container", `{"name":"container","kind":"kic","priority":"default"}`, 0755)
+	writePlugin(t, second, "noexec", `{"name":"noexec","priority":"default"}`, 0644)
+	writePlugin(t, second, "kvm2", `{"name":"kvm2","priority":"default"}`, 0755)
+	if err := ioutil.WriteFile(filepath.Join(second, Prefix+"legacy"), []byte("#!/bin/sh\necho not a plugin\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	known := func(name string) bool { return name == "kvm2" }
+	defs := find([]string{first, second, filepath.Join(second, "missing")}, known)
+
+	got := map[string]registry.DriverDef{}
+	for _, d := range defs {
+		got[d.Name] = d
+	}
+	if len(got) != 2 {
+		t.Fatalf("found %v, want acme and metal", defs)
+	}
+
+	acme := got["acme"]
+	if acme.Plugin != filepath.Join(first, Prefix+"acme") {
+		t.Errorf("acme plugin = %s, want the one in %s", acme.Plugin, first)
+	}
+	if acme.Priority != registry.Preferred || !acme.Default || acme.Kind != registry.KindVM {
+		t.Errorf("acme = %+v, want a default preferred vm", acme)
+	}
+	if st := acme.Status(); !st.Installed || !st.Healthy || st.Error != nil {
+		t.Errorf("acme status = %+v, want installed and healthy", st)
+	}
+
+	metal := got["metal"]
+	if metal.Kind != registry.KindBareMetal || metal.Priority != registry.Experimental {
+		t.Errorf("metal = %+v, want experimental baremetal", metal)
+	}
+	if st := metal.Status(); st.Healthy || st.Error == nil || st.Error.Error() != "no metal" {
+		t.Errorf("metal status = %+v, want unhealthy with error", st)
+	}
+}
@@ -53,6 +53,7 @@ func init() {
 		Config:   configure,
 		Init:     func() drivers.Driver { return kic.NewDriver(kic.Config{OCIBinary: oci.Podman}) },
 		Status:   status,
+		Doctor:   func() []registry.Check { return driver.KICChecks(oci.Podman) },
 		Default:  true,
 		Priority: priority,
 	}); err != nil {
@@ -51,20 +51,26 @@ func configure(cc config.ClusterConfig, n config.Node) (interface{}, error) {
 		ContainerRuntime: cc.KubernetesConfig.ContainerRuntime,
 	})
 
-	if cc.SSHIPAddress == "" {
+	ip, user, key, port := cc.SSHIPAddress, cc.SSHUser, cc.SSHKey, cc.SSHPort
+	// nodes added with "minikube node add --ssh-ip-address" run on machines of their own
+	if n.SSHIPAddress != "" {
+		ip, user, key, port = n.SSHIPAddress, n.SSHUser, n.SSHKey, n.SSHPort
+	}
+
+	if ip == "" {
 		return nil, errors.Errorf("please provide an IP address")
 	}
 
 	// We don't want the API server listening on loopback interface,
 	// even if we might use a tunneled VM port for the SSH service
-	if cc.SSHIPAddress == "127.0.0.1" || cc.SSHIPAddress == "localhost" {
+	if ip == "127.0.0.1" || ip == "localhost" {
 		return nil, errors.Errorf("please provide real IP address")
 	}
 
-	d.IPAddress = cc.SSHIPAddress
-	d.SSHUser = cc.SSHUser
-	d.SSHKey = cc.SSHKey
-	d.SSHPort = cc.SSHPort
+	d.IPAddress = ip
+	d.SSHUser = user
+	d.SSHKey = key
+	d.SSHPort = port
 
 	return d, nil
 }
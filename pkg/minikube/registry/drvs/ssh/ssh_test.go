@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package ssh
+
+import (
+	"testing"
+
+	"k8s.io/minikube/pkg/drivers/ssh"
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestConfigure(t *testing.T) {
+	cc := config.ClusterConfig{
+		Name:             "p1",
+		SSHIPAddress:     "192.168.0.10",
+		SSHUser:          "root",
+		SSHKey:           "/keys/cluster",
+		SSHPort:          22,
+		KubernetesConfig: config.KubernetesConfig{ContainerRuntime: "docker"},
+	}
+	tests := []struct {
+		name     string
+		node     config.Node
+		wantIP   string
+		wantUser string
+		wantKey  string
+		wantPort int
+	}{
+		{
+			name:     "primary",
+			node:     config.Node{Name: "", ControlPlane: true},
+			wantIP:   "192.168.0.10",
+			wantUser: "root",
+			wantKey:  "/keys/cluster",
+			wantPort: 22,
+		},
+		{
+			name:     "own machine",
+			node:     config.Node{Name: "m02", SSHIPAddress: "192.168.0.11", SSHUser: "ubuntu", SSHKey: "/keys/m02", SSHPort: 2222},
+			wantIP:   "192.168.0.11",
+			wantUser: "ubuntu",
+			wantKey:  "/keys/m02",
+			wantPort: 2222,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := configure(cc, tc.node)
+			if err != nil {
+				t.Fatalf("configure: %v", err)
+			}
+			d := got.(*ssh.Driver)
+			if d.IPAddress != tc.wantIP || d.SSHUser != tc.wantUser || d.SSHKey != tc.wantKey || d.SSHPort != tc.wantPort {
+				t.Errorf("configure() = %s@%s:%d key %s, want %s@%s:%d key %s", d.SSHUser, d.IPAddress, d.SSHPort, d.SSHKey, tc.wantUser, tc.wantIP, tc.wantPort, tc.wantKey)
+			}
+		})
+	}
+
+	if _, err := configure(config.ClusterConfig{SSHIPAddress: "localhost", KubernetesConfig: cc.KubernetesConfig}, config.Node{}); err == nil {
+		t.Errorf("configure() with a loopback address succeeded, want an error")
+	}
+}
@@ -48,6 +48,9 @@ func IsMock(name string) bool {
 
 // IsVM checks if the driver is a VM
 func IsVM(name string) bool {
+	if k := globalRegistry.Driver(name).Kind; k != "" {
+		return k == KindVM
+	}
 	if IsKIC(name) || IsMock(name) || BareMetal(name) {
 		return false
 	}
@@ -56,7 +59,7 @@ func IsVM(name string) bool {
 
 // BareMetal returns if this driver is unisolated
 func BareMetal(name string) bool {
-	return name == None || name == Mock
+	return name == None || name == Mock || globalRegistry.Driver(name).Kind == KindBareMetal
 }
 
 var (
@@ -92,6 +95,11 @@ func Register(driver DriverDef) error {
 	return globalRegistry.Register(driver)
 }
 
+// AddDiscoverer adds a way of finding drivers which are not built in to the global registry
+func AddDiscoverer(d Discoverer) {
+	globalRegistry.AddDiscoverer(d)
+}
+
 // Driver gets a named driver from the global registry
 func Driver(name string) DriverDef {
 	return globalRegistry.Driver(name)
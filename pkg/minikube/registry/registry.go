@@ -21,6 +21,7 @@ import (
 	"sync"
 
 	"github.com/docker/machine/libmachine/drivers"
+	"k8s.io/klog/v2"
 
 	"k8s.io/minikube/pkg/minikube/config"
 )
@@ -51,6 +52,36 @@ const (
 	HighlyPreferred
 )
 
+var priorityNames = map[Priority]string{
+	Unknown:         "unknown",
+	Obsolete:        "obsolete",
+	Unhealthy:       "unhealthy",
+	Experimental:    "experimental",
+	Discouraged:     "discouraged",
+	Deprecated:      "deprecated",
+	Fallback:        "fallback",
+	Default:         "default",
+	Preferred:       "preferred",
+	HighlyPreferred: "highly-preferred",
+}
+
+func (p Priority) String() string {
+	if n, ok := priorityNames[p]; ok {
+		return n
+	}
+	return fmt.Sprintf("priority(%d)", int(p))
+}
+
+// Kind is how a driver runs the cluster
+type Kind string
+
+const (
+	// KindVM runs the cluster in a virtual machine
+	KindVM Kind = "vm"
+	// KindBareMetal runs the cluster on a machine it does not isolate
+	KindBareMetal Kind = "baremetal"
+)
+
 // Registry contains all the supported driver definitions on the host
 type Registry interface {
 	// Register a driver in registry
@@ -72,6 +103,26 @@ type Loader func() drivers.Driver
 // StatusChecker checks if a driver is available, offering a
 type StatusChecker func() State
 
+// Doctor runs deeper, slower checks of a driver and its host than its StatusChecker
+type Doctor func() []Check
+
+// Check is the outcome of one of the checks run by a Doctor
+type Check struct {
+	// Name describes what was checked
+	Name string
+	// Error is why the check failed, nil if it passed
+	Error error
+	// Warning is set when the check passed, but the driver may not work well
+	Warning bool
+
+	Fix string
+	Doc string
+}
+
+// Discoverer finds drivers which are not built in, such as external plugins.
+// known reports whether a name is already taken by a registered driver.
+type Discoverer func(known func(name string) bool) []DriverDef
+
 // State is the current state of the driver and its dependencies
 type State struct {
 	Installed        bool
@@ -102,11 +153,21 @@ type DriverDef struct {
 	// Status returns the installation status of the driver
 	Status StatusChecker
 
+	// Doctor runs deeper checks of the driver, if it has any
+	Doctor Doctor
+
 	// Default is whether this driver is selected by default or not (opt-in).
 	Default bool
 
 	// Priority returns the prioritization for selecting a driver by default.
 	Priority Priority
+
+	// Kind is how the driver runs the cluster. Only set for drivers which are
+	// not built in, whose kind can not be told from their name.
+	Kind Kind
+
+	// Plugin is the path of the external executable providing the driver, if it is not built in
+	Plugin string
 }
 
 // Empty returns true if the driver is nil
@@ -122,6 +183,9 @@ type driverRegistry struct {
 	drivers        map[string]DriverDef
 	driversByAlias map[string]DriverDef
 	lock           sync.RWMutex
+
+	discoverers []Discoverer
+	discovered  sync.Once
 }
 
 func newRegistry() *driverRegistry {
@@ -151,8 +215,39 @@ func (r *driverRegistry) Register(def DriverDef) error {
 	return nil
 }
 
+// AddDiscoverer adds a way of finding drivers which are not built in. It is
+// only run once a driver is looked up that was not registered.
+func (r *driverRegistry) AddDiscoverer(d Discoverer) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.discoverers = append(r.discoverers, d)
+}
+
+// discover registers the drivers found by the discoverers, once
+func (r *driverRegistry) discover() {
+	r.discovered.Do(func() {
+		r.lock.RLock()
+		discoverers := r.discoverers
+		r.lock.RUnlock()
+
+		known := func(name string) bool {
+			return !r.registered(name).Empty()
+		}
+		for _, d := range discoverers {
+			for _, def := range d(known) {
+				if err := r.Register(def); err != nil {
+					klog.Warningf("skipping driver plugin %s: %v", def.Plugin, err)
+				}
+			}
+		}
+	})
+}
+
 // List returns a list of registered drivers
 func (r *driverRegistry) List() []DriverDef {
+	r.discover()
+
 	r.lock.RLock()
 	defer r.lock.RUnlock()
 
@@ -167,6 +262,15 @@ func (r *driverRegistry) List() []DriverDef {
 
 // Driver returns a driver given a name
 func (r *driverRegistry) Driver(name string) DriverDef {
+	if def := r.registered(name); !def.Empty() {
+		return def
+	}
+	r.discover()
+	return r.registered(name)
+}
+
+// registered returns a registered driver given a name, without looking for plugins
+func (r *driverRegistry) registered(name string) DriverDef {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
 
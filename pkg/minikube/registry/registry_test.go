@@ -91,3 +91,47 @@ func TestDriverAlias(t *testing.T) {
 		t.Errorf("driver.Empty = false, expected true")
 	}
 }
+
+func TestDiscover(t *testing.T) {
+	r := newRegistry()
+	if err := r.Register(DriverDef{Name: "foo"}); err != nil {
+		t.Fatalf("register returned error: %v", err)
+	}
+	calls := 0
+	r.AddDiscoverer(func(known func(string) bool) []DriverDef {
+		calls++
+		if !known("foo") || known("plugin") {
+			t.Errorf("known reports registered drivers wrongly")
+		}
+		return []DriverDef{{Name: "plugin", Plugin: "/bin/plugin"}, {Name: "foo", Plugin: "/bin/foo"}}
+	})
+
+	if d := r.Driver("foo"); d.Plugin != "" {
+		t.Errorf("Driver(foo) = %+v, want the built in driver", d)
+	}
+	if calls != 0 {
+		t.Errorf("discovered plugins for a registered driver")
+	}
+	if d := r.Driver("plugin"); d.Plugin != "/bin/plugin" {
+		t.Errorf("Driver(plugin) = %+v, want the plugin", d)
+	}
+	if n := len(r.List()); n != 2 {
+		t.Errorf("List() has %d drivers, want 2", n)
+	}
+	if calls != 1 {
+		t.Errorf("discoverer called %d times, want 1", calls)
+	}
+}
+
+func TestPriorityString(t *testing.T) {
+	tests := map[Priority]string{
+		Default:         "default",
+		HighlyPreferred: "highly-preferred",
+		Priority(42):    "priority(42)",
+	}
+	for p, want := range tests {
+		if got := p.String(); got != want {
+			t.Errorf("Priority(%d).String() = %q, want %q", int(p), got, want)
+		}
+	}
+}
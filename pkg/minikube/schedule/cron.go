@@ -0,0 +1,213 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronMacros are the nonstandard cron shorthands which are supported
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+var dayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// cronField is the set of values a single field of a cron expression matches
+type cronField struct {
+	// any is true if the field is a plain "*"
+	any    bool
+	values map[int]bool
+}
+
+// Cron is a parsed five field cron expression: minute, hour, day of month, month and day of week
+type Cron struct {
+	minute, hour, dom, month, dow cronField
+}
+
+// ParseCron parses a standard five field cron expression, such as "0 19 * * mon-fri".
+// Fields may be "*", numbers, names of months and weekdays, ranges, steps and comma separated lists of those.
+func ParseCron(expr string) (*Cron, error) {
+	spec := strings.TrimSpace(expr)
+	if m, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = m
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	c := &Cron{}
+	var err error
+	if c.minute, err = parseCronField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid minute in %q: %v", expr, err)
+	}
+	if c.hour, err = parseCronField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid hour in %q: %v", expr, err)
+	}
+	if c.dom, err = parseCronField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid day of month in %q: %v", expr, err)
+	}
+	if c.month, err = parseCronField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid month in %q: %v", expr, err)
+	}
+	if c.dow, err = parseCronField(fields[4], 0, 7, dayNames); err != nil {
+		return nil, fmt.Errorf("invalid day of week in %q: %v", expr, err)
+	}
+	// both 0 and 7 mean Sunday
+	if c.dow.values[7] {
+		delete(c.dow.values, 7)
+		c.dow.values[0] = true
+	}
+	return c, nil
+}
+
+// parseCronField parses a comma separated list of values, ranges and steps between min and max.
+// names, if set, are aliases for the values starting at min.
+func parseCronField(field string, min int, max int, names []string) (cronField, error) {
+	f := cronField{any: field == "*", values: map[int]bool{}}
+	for _, part := range strings.Split(field, ",") {
+		base, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			base = part[:i]
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return f, fmt.Errorf("invalid step in %q", part)
+			}
+			step = n
+		}
+
+		lo, hi := min, max
+		switch {
+		case base == "*":
+		case strings.Contains(base, "-"):
+			bounds := strings.SplitN(base, "-", 2)
+			var err error
+			if lo, err = cronValue(bounds[0], min, max, names); err != nil {
+				return f, err
+			}
+			if hi, err = cronValue(bounds[1], min, max, names); err != nil {
+				return f, err
+			}
+			if lo > hi {
+				return f, fmt.Errorf("invalid range %q", base)
+			}
+		default:
+			v, err := cronValue(base, min, max, names)
+			if err != nil {
+				return f, err
+			}
+			lo = v
+			// "5/15" is shorthand for "5-max/15"
+			if step == 1 {
+				hi = v
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			f.values[v] = true
+		}
+	}
+	return f, nil
+}
+
+// cronValue parses a single number or name between min and max
+func cronValue(s string, min int, max int, names []string) (int, error) {
+	for i, n := range names {
+		if strings.EqualFold(s, n) {
+			return min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < min || v > max {
+		return 0, fmt.Errorf("value %d out of range [%d-%d]", v, min, max)
+	}
+	return v, nil
+}
+
+// dayMatches reports whether the date of t matches. As with cron, if both the
+// day of month and day of week are restricted, matching either one is enough.
+func (c *Cron) dayMatches(t time.Time) bool {
+	dom := c.dom.values[t.Day()]
+	dow := c.dow.values[int(t.Weekday())]
+	if !c.dom.any && !c.dow.any {
+		return dom || dow
+	}
+	return dom && dow
+}
+
+// Matches reports whether the cron expression matches the minute of t
+func (c *Cron) Matches(t time.Time) bool {
+	return c.minute.values[t.Minute()] && c.hour.values[t.Hour()] && c.month.values[int(t.Month())] && c.dayMatches(t)
+}
+
+// Next returns the first minute after t which matches the cron expression,
+// or the zero time if there is none within the next five years
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	end := t.AddDate(5, 0, 0)
+	for t.Before(end) {
+		switch {
+		case !c.month.values[int(t.Month())] || !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !c.hour.values[t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !c.minute.values[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// guestFields returns the fields of the cron expression as "*" or sorted lists of numbers,
+// the format understood by the in-guest scheduler
+func (c *Cron) guestFields() []string {
+	fields := []string{}
+	for _, f := range []cronField{c.minute, c.hour, c.dom, c.month, c.dow} {
+		if f.any {
+			fields = append(fields, "*")
+			continue
+		}
+		vs := []int{}
+		for v := range f.values {
+			vs = append(vs, v)
+		}
+		sort.Ints(vs)
+		ss := []string{}
+		for _, v := range vs {
+			ss = append(ss, strconv.Itoa(v))
+		}
+		fields = append(fields, strings.Join(ss, ","))
+	}
+	return fields
+}
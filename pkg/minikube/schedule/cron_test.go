@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected []string
+	}{
+		{"* * * * *", []string{"*", "*", "*", "*", "*"}},
+		{"0 19 * * mon-fri", []string{"0", "19", "*", "*", "1,2,3,4,5"}},
+		{"*/15 9-17/4 1,15 jan,JUL sun,7", []string{"0,15,30,45", "9,13,17", "1,15", "1,7", "0"}},
+		{"45/5 0 * * *", []string{"45,50,55", "0", "*", "*", "*"}},
+		{"@weekly", []string{"0", "0", "*", "*", "0"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			c, err := ParseCron(tc.expr)
+			if err != nil {
+				t.Fatalf("ParseCron: %v", err)
+			}
+			if got := c.guestFields(); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("fields = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * foo *", "5-1 * * * *", "*/0 * * * *", "@sometimes"} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("expected an error parsing %q", expr)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// a Saturday
+	now := time.Date(2021, time.March, 6, 19, 30, 10, 0, time.UTC)
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2021, time.March, 6, 19, 31, 0, 0, time.UTC)},
+		{"0 19 * * mon-fri", time.Date(2021, time.March, 8, 19, 0, 0, 0, time.UTC)},
+		{"30 19 * * *", time.Date(2021, time.March, 7, 19, 30, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2021, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		// either day field matches when both are restricted
+		{"0 12 10 * sun", time.Date(2021, time.March, 7, 12, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			c, err := ParseCron(tc.expr)
+			if err != nil {
+				t.Fatalf("ParseCron: %v", err)
+			}
+			if got := c.Next(now); !got.Equal(tc.expected) {
+				t.Errorf("Next = %s, want %s", got, tc.expected)
+			}
+			if !tc.expected.IsZero() && !c.Matches(tc.expected) {
+				t.Errorf("expected %s to match", tc.expected)
+			}
+		})
+	}
+}
@@ -22,12 +22,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"strconv"
 	"time"
 
 	"github.com/VividCortex/godaemon"
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/localpath"
 )
 
@@ -42,7 +44,11 @@ func KillExisting(profiles []string) {
 }
 
 func killPIDForProfile(profile string) error {
-	file := localpath.PID(profile)
+	return killPID(localpath.PID(profile))
+}
+
+// killPID kills the process whose PID is saved in file, and deletes the file
+func killPID(file string) error {
 	f, err := ioutil.ReadFile(file)
 	if os.IsNotExist(err) {
 		return nil
@@ -63,7 +69,7 @@ func killPIDForProfile(profile string) error {
 	if err != nil {
 		return errors.Wrap(err, "finding process")
 	}
-	klog.Infof("killing process %v as it is an old scheduled stop", pid)
+	klog.Infof("killing process %v as it is an old scheduled process", pid)
 	if err := p.Kill(); err != nil {
 		return errors.Wrapf(err, "killing %v", pid)
 	}
@@ -89,3 +95,35 @@ func savePIDs(pid int, profiles []string) error {
 	}
 	return nil
 }
+
+// StartSnapshots (re)starts the background process which saves the scheduled snapshots of a profile
+func StartSnapshots(profile string) error {
+	if err := killPID(localpath.SchedulePID(profile)); err != nil {
+		klog.Errorf("error killing scheduled snapshots for profile %s: %v", profile, err)
+	}
+	cc, err := config.Load(profile)
+	if err != nil {
+		return errors.Wrap(err, "load profile")
+	}
+	if !HasSnapshots(cc) {
+		return nil
+	}
+	bin, err := os.Executable()
+	if err != nil {
+		return errors.Wrap(err, "executable")
+	}
+	// the daemon detaches itself, so this returns as soon as it has started
+	return exec.Command(bin, "schedule", "daemon", "--profile", profile).Run()
+}
+
+// DaemonizeSnapshots daemonizes the current process, which then saves the scheduled snapshots of a profile
+func DaemonizeSnapshots(profile string) error {
+	if _, _, err := godaemon.MakeDaemon(&godaemon.DaemonAttr{}); err != nil {
+		return err
+	}
+	file := localpath.SchedulePID(profile)
+	if err := ioutil.WriteFile(file, []byte(fmt.Sprintf("%v", os.Getpid())), 0600); err != nil {
+		return errors.Wrapf(err, "writing %s", file)
+	}
+	return RunSnapshots(profile)
+}
@@ -23,9 +23,12 @@ import (
 	"os/exec"
 	"time"
 
+	"github.com/docker/machine/libmachine/host"
 	"github.com/pkg/errors"
 	"k8s.io/klog/v2"
 	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/config"
 	"k8s.io/minikube/pkg/minikube/constants"
 	"k8s.io/minikube/pkg/minikube/machine"
 	"k8s.io/minikube/pkg/minikube/sysinit"
@@ -59,6 +62,19 @@ func killExisting(profile string) error {
 	if err := sysManger.Stop(constants.ScheduledStopSystemdService); err != nil {
 		return errors.Wrapf(err, "stopping schedule-stop service for profile %s", profile)
 	}
+
+	// the same service runs recurring schedules, so bring it back without the scheduled stop
+	cc, err := config.Load(profile)
+	if err != nil {
+		return errors.Wrapf(err, "loading profile %s", profile)
+	}
+	cc.ScheduledStop = nil
+	if err := config.SaveProfile(profile, cc); err != nil {
+		return errors.Wrap(err, "saving profile")
+	}
+	if len(cc.Schedules) > 0 {
+		return Sync(api, cc)
+	}
 	return nil
 }
 
@@ -95,8 +111,12 @@ func startSystemdService(profile string, duration time.Duration) error {
 	if rr, err := runner.RunCmd(exec.Command("sudo", "mkdir", "-p", "/var/lib/minikube/scheduled-stop")); err != nil {
 		return errors.Wrapf(err, "creating dirs: %v", rr.Output())
 	}
+	cc, err := config.Load(profile)
+	if err != nil {
+		return errors.Wrapf(err, "loading profile %s", profile)
+	}
 	// update environment file to include duration
-	if err := runner.Copy(environmentFile(duration)); err != nil {
+	if err := runner.Copy(environmentFile(h, cc, duration)); err != nil {
 		return errors.Wrap(err, "copying scheduled stop env file")
 	}
 	// restart scheduled stop service in container
@@ -109,8 +129,32 @@ func startSystemdService(profile string, duration time.Duration) error {
 }
 
 // return the contents of the environment file for minikube-scheduled-stop systemd service
-// should be of the format SLEEP=<scheduled stop requested by user in seconds>
-func environmentFile(duration time.Duration) assets.CopyableFile {
-	contents := []byte(fmt.Sprintf("SLEEP=%v", duration.Seconds()))
+// should include SLEEP=<scheduled stop requested by user in seconds>, followed by
+// the settings used by recurring schedules
+func environmentFile(h *host.Host, cc *config.ClusterConfig, duration time.Duration) assets.CopyableFile {
+	contents := []byte(fmt.Sprintf("SLEEP=%v\n", duration.Seconds()))
+	if len(cc.Schedules) > 0 {
+		cp, err := config.PrimaryControlPlane(cc)
+		if err != nil {
+			klog.Warningf("primary control plane: %v", err)
+		}
+		hostIP := ""
+		if ip, err := cluster.HostIP(h, cc.Name); err == nil {
+			hostIP = ip.String()
+		} else {
+			klog.Warningf("host ip: %v", err)
+		}
+		contents = []byte(guestEnvironment(cc, cp, hostIP, time.Now(), duration))
+	}
 	return assets.NewMemoryAssetTarget(contents, constants.ScheduledStopEnvFile, "0644")
 }
+
+// StartSnapshots is not supported, as scheduled snapshots are taken by a daemon
+func StartSnapshots(profile string) error {
+	return errors.New("scheduled snapshots are not supported on Windows")
+}
+
+// DaemonizeSnapshots is not supported, as scheduled snapshots are taken by a daemon
+func DaemonizeSnapshots(profile string) error {
+	return errors.New("scheduled snapshots are not supported on Windows")
+}
@@ -0,0 +1,413 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/docker/machine/libmachine"
+	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/assets"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/command"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/driver"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/sysinit"
+)
+
+const (
+	// Stop powers off the cluster
+	Stop = "stop"
+	// Pause pauses the containers of the minikube namespaces
+	Pause = "pause"
+	// Unpause unpauses the containers of the minikube namespaces
+	Unpause = "unpause"
+	// Snapshot saves a snapshot of the cluster. Unlike the other actions it runs on the host.
+	Snapshot = "snapshot"
+
+	scheduledStopDir   = "/var/lib/minikube/scheduled-stop"
+	schedulerPath      = scheduledStopDir + "/minikube-scheduled-actions"
+	guestSchedulesPath = scheduledStopDir + "/schedules"
+	schedulerDropIn    = "/etc/systemd/system/" + constants.ScheduledStopSystemdService + ".service.d/10-scheduled-actions.conf"
+)
+
+// Actions are the supported scheduled actions
+var Actions = []string{Stop, Pause, Unpause, Snapshot}
+
+var validScheduleName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]{0,62}$`)
+
+// Validate checks that a schedule is valid for a cluster
+func Validate(cc *config.ClusterConfig, s config.ScheduledAction) error {
+	if !validScheduleName.MatchString(s.Name) {
+		return fmt.Errorf("invalid schedule name %q: only letters, digits, '_', '.' and '-' are allowed", s.Name)
+	}
+	for _, existing := range cc.Schedules {
+		if existing.Name == s.Name {
+			return fmt.Errorf("schedule %q already exists", s.Name)
+		}
+	}
+	if driver.BareMetal(cc.Driver) {
+		return fmt.Errorf("scheduled actions are not supported by the %s driver", cc.Driver)
+	}
+
+	if s.Keep < 0 {
+		return fmt.Errorf("the number of snapshots to keep can not be negative, got %d", s.Keep)
+	}
+	switch s.Action {
+	case Stop, Pause, Unpause:
+		if s.Keep != 0 {
+			return fmt.Errorf("only snapshot schedules keep a number of snapshots")
+		}
+	case Snapshot:
+		if !machine.SnapshotSupported(cc.Driver) {
+			return fmt.Errorf("the %s driver does not support snapshots", cc.Driver)
+		}
+		if runtime.GOOS == "windows" {
+			return fmt.Errorf("scheduled snapshots are not supported on Windows")
+		}
+		if s.Cron == "" {
+			return fmt.Errorf("snapshot schedules require a cron expression")
+		}
+	default:
+		return fmt.Errorf("invalid action %q, expected one of: %s", s.Action, strings.Join(Actions, ", "))
+	}
+
+	switch {
+	case s.Cron != "" && s.Idle != 0:
+		return fmt.Errorf("a schedule cannot have both a cron expression and an idle duration")
+	case s.Cron != "":
+		_, err := ParseCron(s.Cron)
+		return err
+	case s.Idle < time.Minute:
+		return fmt.Errorf("the idle duration must be at least 1m, got %s", s.Idle)
+	case len(cc.Nodes) > 1:
+		// API requests are only observed on the primary control plane
+		return fmt.Errorf("idle-triggered schedules are not supported on multi-node clusters")
+	}
+	return nil
+}
+
+// Next returns when a cron schedule next runs after t, or the zero time for idle-triggered schedules
+func Next(s config.ScheduledAction, t time.Time) time.Time {
+	if s.Cron == "" {
+		return time.Time{}
+	}
+	c, err := ParseCron(s.Cron)
+	if err != nil {
+		klog.Warningf("invalid cron expression for schedule %s: %v", s.Name, err)
+		return time.Time{}
+	}
+	return c.Next(t)
+}
+
+// Sync installs the schedules of a cluster into each of its running nodes, where they are run
+// by the scheduled-stop service, so that they survive the host going away
+func Sync(api libmachine.API, cc *config.ClusterConfig) error {
+	cp, err := config.PrimaryControlPlane(cc)
+	if err != nil {
+		return errors.Wrap(err, "primary control plane")
+	}
+	for _, n := range cc.Nodes {
+		machineName := config.MachineName(*cc, n)
+		st, err := machine.Status(api, machineName)
+		if err != nil {
+			return errors.Wrapf(err, "status of %s", machineName)
+		}
+		if st != state.Running.String() {
+			klog.Infof("not installing schedules on %s: %s", machineName, st)
+			continue
+		}
+
+		h, err := api.Load(machineName)
+		if err != nil {
+			return errors.Wrapf(err, "load %s", machineName)
+		}
+		runner, err := machine.CommandRunner(h)
+		if err != nil {
+			return errors.Wrap(err, "command runner")
+		}
+		hostIP, err := cluster.HostIP(h, cc.Name)
+		if err != nil {
+			return errors.Wrap(err, "host ip")
+		}
+		sleep := time.Duration(0)
+		if runtime.GOOS == "windows" && config.IsPrimaryControlPlane(*cc, n) {
+			// the scheduled-stop service also runs "minikube stop --schedule" on Windows, so keep it pending
+			sleep = remainingStop(cc.ScheduledStop, time.Now())
+		}
+		env := guestEnvironment(cc, cp, hostIP.String(), time.Now(), sleep)
+		schedules := guestSchedules(cc, config.IsPrimaryControlPlane(*cc, n))
+		if err := installSchedules(runner, env, schedules); err != nil {
+			return errors.Wrapf(err, "installing schedules on %s", machineName)
+		}
+	}
+	return nil
+}
+
+// installSchedules copies the scheduler, its environment and schedules into a node and restarts the scheduled-stop service
+func installSchedules(runner command.Runner, env string, schedules string) error {
+	if schedules == "" {
+		// the scheduler keeps running, but has nothing left to do
+		if rr, err := runner.RunCmd(exec.Command("sudo", "rm", "-f", guestSchedulesPath)); err != nil {
+			return errors.Wrapf(err, "removing schedules: %s", rr.Output())
+		}
+		return nil
+	}
+
+	if rr, err := runner.RunCmd(exec.Command("sudo", "mkdir", "-p", scheduledStopDir, schedulerDropInDir())); err != nil {
+		return errors.Wrapf(err, "creating dirs: %s", rr.Output())
+	}
+	for _, f := range []assets.CopyableFile{
+		assets.NewMemoryAssetTarget([]byte(schedulerScript), schedulerPath, "0755"),
+		assets.NewMemoryAssetTarget([]byte(schedulerUnit), schedulerDropIn, "0644"),
+		assets.NewMemoryAssetTarget([]byte(env), constants.ScheduledStopEnvFile, "0644"),
+		assets.NewMemoryAssetTarget([]byte(schedules), guestSchedulesPath, "0644"),
+	} {
+		if err := runner.Copy(f); err != nil {
+			return errors.Wrapf(err, "copy %s", f.GetTargetName())
+		}
+	}
+
+	sm := sysinit.New(runner)
+	if err := sm.Enable(constants.ScheduledStopSystemdService); err != nil {
+		return err
+	}
+	return sm.Restart(constants.ScheduledStopSystemdService)
+}
+
+func schedulerDropInDir() string {
+	return strings.TrimSuffix(schedulerDropIn, "/10-scheduled-actions.conf")
+}
+
+// guestEnvironment returns the environment file of the scheduled-stop service.
+// sleep, if non-zero, is how long until a one-shot scheduled stop.
+func guestEnvironment(cc *config.ClusterConfig, cp config.Node, hostIP string, now time.Time, sleep time.Duration) string {
+	var b bytes.Buffer
+	if sleep != 0 {
+		fmt.Fprintf(&b, "SLEEP=%v\n", sleep.Seconds())
+	}
+	fmt.Fprintf(&b, "TZ=%s\n", posixTZ(now))
+	fmt.Fprintf(&b, "CONTAINER_RUNTIME=%s\n", cc.KubernetesConfig.ContainerRuntime)
+	fmt.Fprintf(&b, "PAUSE_NAMESPACES=%q\n", strings.Join(constants.DefaultNamespaces, " "))
+	fmt.Fprintf(&b, "HOST_IP=%s\n", hostIP)
+	fmt.Fprintf(&b, "APISERVER_PORT=%d\n", cp.Port)
+	return b.String()
+}
+
+// remainingStop returns how long until a one-shot scheduled stop, or 0 if none is pending
+func remainingStop(ss *config.ScheduledStopConfig, now time.Time) time.Duration {
+	if ss == nil {
+		return 0
+	}
+	left := time.Unix(ss.InitiationTime, 0).Add(ss.Duration).Sub(now)
+	if left <= 0 {
+		return 0
+	}
+	return left
+}
+
+// posixTZ returns the UTC offset of t as a POSIX TZ value, which needs no zoneinfo in the guest.
+// It does not follow daylight saving time changes, which are picked up by the next sync.
+func posixTZ(t time.Time) string {
+	_, offset := t.Zone()
+	// POSIX offsets are positive west of UTC
+	sign := "-"
+	if offset < 0 {
+		sign = "+"
+		offset = -offset
+	}
+	return fmt.Sprintf("UTC%s%d:%02d", sign, offset/3600, offset%3600/60)
+}
+
+// guestSchedules returns the schedules file read by the in-guest scheduler.
+// Snapshots are taken by the host, and idle-triggered schedules only run on the primary control plane.
+func guestSchedules(cc *config.ClusterConfig, primary bool) string {
+	var b bytes.Buffer
+	for _, s := range cc.Schedules {
+		if s.Action == Snapshot {
+			continue
+		}
+		if s.Cron != "" {
+			c, err := ParseCron(s.Cron)
+			if err != nil {
+				klog.Warningf("skipping schedule %s: %v", s.Name, err)
+				continue
+			}
+			fmt.Fprintf(&b, "cron %s %s %s\n", strings.Join(c.guestFields(), " "), s.Action, s.Name)
+			continue
+		}
+		if primary {
+			fmt.Fprintf(&b, "idle %d %s %s\n", int(s.Idle.Seconds()), s.Action, s.Name)
+		}
+	}
+	return b.String()
+}
+
+// schedulerUnit points the scheduled-stop service at the scheduler installed by minikube
+var schedulerUnit = `[Service]
+ExecStart=
+ExecStart=` + schedulerPath + `
+`
+
+// schedulerScript runs a one-shot scheduled stop, as well as recurring and idle-triggered actions.
+// Every line of the schedules file is either:
+//
+//	cron MINUTES HOURS DAYS_OF_MONTH MONTHS DAYS_OF_WEEK ACTION NAME
+//	idle SECONDS ACTION NAME
+//
+// where each cron field is either "*" or a comma separated list of numbers.
+var schedulerScript = `#!/bin/bash
+
+readonly SCHEDULES=` + guestSchedulesPath + `
+readonly ACTIVITY_CHAIN=MINIKUBE-API-ACTIVITY
+readonly TICK=10
+
+log() {
+  echo "minikube-scheduled-actions: $*"
+}
+
+# field_matches returns whether the number $1 is in the cron field $2
+field_matches() {
+  [[ "$2" == "*" || ",$2," == *",$1,"* ]]
+}
+
+cron_matches() {
+  local now_min now_hour now_dom now_mon now_dow
+  read -r now_min now_hour now_dom now_mon now_dow <<< "$(date '+%-M %-H %-d %-m %w')"
+  field_matches "$now_min" "$1" && field_matches "$now_hour" "$2" && field_matches "$now_mon" "$4" || return 1
+  # as with cron, matching either day field is enough when both are restricted
+  if [[ "$3" != "*" && "$5" != "*" ]]; then
+    field_matches "$now_dom" "$3" || field_matches "$now_dow" "$5"
+  else
+    field_matches "$now_dom" "$3" && field_matches "$now_dow" "$5"
+  fi
+}
+
+# count API server packets from the host, which are requests made by kubectl and minikube
+watch_activity() {
+  [[ -n "$HOST_IP" ]] || return
+  iptables -N "$ACTIVITY_CHAIN" 2>/dev/null
+  iptables -C INPUT -p tcp -s "$HOST_IP" --dport "$APISERVER_PORT" -j "$ACTIVITY_CHAIN" 2>/dev/null ||
+    iptables -I INPUT -p tcp -s "$HOST_IP" --dport "$APISERVER_PORT" -j "$ACTIVITY_CHAIN"
+}
+
+activity() {
+  iptables -nvxL INPUT 2>/dev/null | awk -v chain="$ACTIVITY_CHAIN" '$3 == chain {print $1}'
+}
+
+runc_cmd() {
+  if [[ "$CONTAINER_RUNTIME" == "containerd" ]]; then
+    runc --root /run/containerd/runc/k8s.io "$@"
+  else
+    runc "$@"
+  fi
+}
+
+# pause and unpause mirror "minikube pause" and "minikube unpause"
+pause_cluster() {
+  systemctl disable kubelet
+  systemctl stop kubelet
+  for ns in $PAUSE_NAMESPACES; do
+    if [[ "$CONTAINER_RUNTIME" == "docker" ]]; then
+      docker ps -q --filter status=running --filter "label=io.kubernetes.pod.namespace=$ns" | xargs -r docker pause
+    else
+      for id in $(crictl ps -q --state running --label "io.kubernetes.pod.namespace=$ns"); do
+        runc_cmd pause "$id"
+      done
+    fi
+  done
+}
+
+unpause_cluster() {
+  for ns in $PAUSE_NAMESPACES; do
+    if [[ "$CONTAINER_RUNTIME" == "docker" ]]; then
+      docker ps -q --filter status=paused --filter "label=io.kubernetes.pod.namespace=$ns" | xargs -r docker unpause
+    else
+      for id in $(crictl ps -a -q --label "io.kubernetes.pod.namespace=$ns"); do
+        runc_cmd resume "$id" 2>/dev/null
+      done
+    fi
+  done
+  systemctl start kubelet
+}
+
+run_action() {
+  log "running $1 for schedule $2"
+  case "$1" in
+    stop) systemctl poweroff ;;
+    pause) pause_cluster ;;
+    unpause) unpause_cluster ;;
+    *) log "unsupported action $1" ;;
+  esac
+}
+
+watch_activity
+start=$(date +%s)
+last_activity=$start
+last_count=""
+last_minute=""
+# idle-triggered schedules which already ran since the last API request
+declare -A fired
+
+while true; do
+  now=$(date +%s)
+  if [[ -n "$SLEEP" ]] && (( now - start >= ${SLEEP%.*} )); then
+    run_action stop "stop --schedule"
+  fi
+
+  count=$(activity)
+  if [[ "$count" != "$last_count" ]]; then
+    last_count=$count
+    last_activity=$now
+    fired=()
+  fi
+
+  minute=$(date '+%Y%m%d%H%M')
+  if [[ -f "$SCHEDULES" ]]; then
+    while read -r -u 3 kind args; do
+      case "$kind" in
+        cron)
+          read -r min hour dom mon dow action name <<< "$args"
+          if [[ "$minute" != "$last_minute" ]] && cron_matches "$min" "$hour" "$dom" "$mon" "$dow"; then
+            run_action "$action" "$name"
+          fi
+          ;;
+        idle)
+          read -r seconds action name <<< "$args"
+          if (( now - last_activity >= seconds )) && [[ -z "${fired[$name]}" ]]; then
+            fired[$name]=1
+            run_action "$action" "$name"
+          fi
+          ;;
+      esac
+    done 3< "$SCHEDULES"
+  fi
+  last_minute=$minute
+
+  sleep "$TICK"
+done
+`
@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/minikube/pkg/minikube/config"
+)
+
+func TestValidate(t *testing.T) {
+	cc := &config.ClusterConfig{
+		Driver:    "docker",
+		Nodes:     []config.Node{{ControlPlane: true, Worker: true}},
+		Schedules: []config.ScheduledAction{{Name: "nightly", Action: Stop, Cron: "0 19 * * *"}},
+	}
+	multinode := &config.ClusterConfig{
+		Driver: "docker",
+		Nodes:  []config.Node{{ControlPlane: true, Worker: true}, {Name: "m02", Worker: true}},
+	}
+
+	tests := []struct {
+		description string
+		cc          *config.ClusterConfig
+		s           config.ScheduledAction
+		valid       bool
+	}{
+		{"cron", cc, config.ScheduledAction{Name: "weekdays", Action: Pause, Cron: "0 19 * * mon-fri"}, true},
+		{"idle", cc, config.ScheduledAction{Name: "idle", Action: Pause, Idle: 30 * time.Minute}, true},
+		{"snapshot", cc, config.ScheduledAction{Name: "backup", Action: Snapshot, Cron: "@daily"}, true},
+		{"duplicate", cc, config.ScheduledAction{Name: "nightly", Action: Stop, Cron: "0 19 * * *"}, false},
+		{"invalid name", cc, config.ScheduledAction{Name: "a b", Action: Stop, Cron: "@daily"}, false},
+		{"invalid action", cc, config.ScheduledAction{Name: "x", Action: "delete", Cron: "@daily"}, false},
+		{"invalid cron", cc, config.ScheduledAction{Name: "x", Action: Stop, Cron: "0 25 * * *"}, false},
+		{"both triggers", cc, config.ScheduledAction{Name: "x", Action: Stop, Cron: "@daily", Idle: time.Hour}, false},
+		{"short idle", cc, config.ScheduledAction{Name: "x", Action: Stop, Idle: time.Second}, false},
+		{"snapshot retention", cc, config.ScheduledAction{Name: "backup", Action: Snapshot, Cron: "@daily", Keep: 7}, true},
+		{"negative retention", cc, config.ScheduledAction{Name: "backup", Action: Snapshot, Cron: "@daily", Keep: -1}, false},
+		{"stop retention", cc, config.ScheduledAction{Name: "x", Action: Stop, Cron: "@daily", Keep: 7}, false},
+		{"idle snapshot", cc, config.ScheduledAction{Name: "x", Action: Snapshot, Idle: time.Hour}, false},
+		{"idle multinode", multinode, config.ScheduledAction{Name: "x", Action: Stop, Idle: time.Hour}, false},
+		{"none driver", &config.ClusterConfig{Driver: "none"}, config.ScheduledAction{Name: "x", Action: Stop, Cron: "@daily"}, false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			err := Validate(tc.cc, tc.s)
+			if tc.valid && err != nil {
+				t.Errorf("expected %+v to be valid, got: %v", tc.s, err)
+			}
+			if !tc.valid && err == nil {
+				t.Errorf("expected %+v to be invalid", tc.s)
+			}
+		})
+	}
+}
+
+func TestGuestSchedules(t *testing.T) {
+	cc := &config.ClusterConfig{
+		Schedules: []config.ScheduledAction{
+			{Name: "nightly", Action: Stop, Cron: "0 19 * * mon-fri"},
+			{Name: "idle", Action: Pause, Idle: 30 * time.Minute},
+			{Name: "backup", Action: Snapshot, Cron: "@daily"},
+		},
+	}
+
+	expected := "cron 0 19 * * 1,2,3,4,5 stop nightly\nidle 1800 pause idle\n"
+	if got := guestSchedules(cc, true); got != expected {
+		t.Errorf("primary schedules = %q, want %q", got, expected)
+	}
+	expected = "cron 0 19 * * 1,2,3,4,5 stop nightly\n"
+	if got := guestSchedules(cc, false); got != expected {
+		t.Errorf("secondary schedules = %q, want %q", got, expected)
+	}
+}
+
+func TestRemainingStop(t *testing.T) {
+	now := time.Date(2021, time.March, 6, 19, 30, 0, 0, time.UTC)
+	tests := []struct {
+		description string
+		ss          *config.ScheduledStopConfig
+		expected    time.Duration
+	}{
+		{"none", nil, 0},
+		{"pending", &config.ScheduledStopConfig{InitiationTime: now.Add(-10 * time.Minute).Unix(), Duration: time.Hour}, 50 * time.Minute},
+		{"overdue", &config.ScheduledStopConfig{InitiationTime: now.Add(-2 * time.Hour).Unix(), Duration: time.Hour}, 0},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := remainingStop(tc.ss, now); got != tc.expected {
+				t.Errorf("remainingStop() = %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestPosixTZ(t *testing.T) {
+	tests := []struct {
+		offset   int
+		expected string
+	}{
+		{0, "UTC-0:00"},
+		{2 * 3600, "UTC-2:00"},
+		{-5 * 3600, "UTC+5:00"},
+		{5*3600 + 1800, "UTC-5:30"},
+	}
+	for _, tc := range tests {
+		now := time.Date(2021, time.March, 6, 19, 30, 0, 0, time.FixedZone("test", tc.offset))
+		if got := posixTZ(now); got != tc.expected {
+			t.Errorf("posixTZ(%d) = %q, want %q", tc.offset, got, tc.expected)
+		}
+	}
+}
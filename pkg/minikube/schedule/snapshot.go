@@ -0,0 +1,190 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/machine/libmachine/state"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/cluster"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/constants"
+	"k8s.io/minikube/pkg/minikube/cruntime"
+	"k8s.io/minikube/pkg/minikube/machine"
+	"k8s.io/minikube/pkg/minikube/sysinit"
+)
+
+// snapshotTimeFormat is the time suffix of the names of scheduled snapshots, such as backup-20210307-0000
+const snapshotTimeFormat = "20060102-1504"
+
+// HasSnapshots returns whether a cluster has any snapshot schedules, which are run by the host
+func HasSnapshots(cc *config.ClusterConfig) bool {
+	for _, s := range cc.Schedules {
+		if s.Action == Snapshot {
+			return true
+		}
+	}
+	return false
+}
+
+// RunSnapshots saves a snapshot every time one of the snapshot schedules of a profile is due,
+// until the profile has no snapshot schedules left
+func RunSnapshots(profile string) error {
+	for {
+		cc, err := config.Load(profile)
+		if err != nil {
+			return errors.Wrap(err, "load profile")
+		}
+		s, next := nextSnapshot(cc, time.Now())
+		if s == nil {
+			klog.Infof("no snapshot schedules left for %s", profile)
+			return nil
+		}
+		klog.Infof("next scheduled snapshot %s at %s", s.Name, next)
+		time.Sleep(time.Until(next))
+		if err := takeSnapshot(profile, fmt.Sprintf("%s-%s", s.Name, next.Format(snapshotTimeFormat))); err != nil {
+			klog.Errorf("scheduled snapshot %s: %v", s.Name, err)
+			continue
+		}
+		if s.Keep > 0 {
+			if err := pruneSnapshots(profile, *s); err != nil {
+				klog.Errorf("pruning snapshots of schedule %s: %v", s.Name, err)
+			}
+		}
+	}
+}
+
+// pruneSnapshots deletes the oldest snapshots taken by a schedule, so that only s.Keep of them are left
+func pruneSnapshots(profile string, s config.ScheduledAction) error {
+	cc, err := config.Load(profile)
+	if err != nil {
+		return errors.Wrap(err, "load profile")
+	}
+	snapshots, err := machine.ListSnapshots(profile)
+	if err != nil {
+		return errors.Wrap(err, "list snapshots")
+	}
+	for _, name := range snapshotsToPrune(snapshots, s) {
+		klog.Infof("deleting snapshot %s of schedule %s, which keeps %d", name, s.Name, s.Keep)
+		if err := machine.DeleteSnapshot(cc, name); err != nil {
+			return errors.Wrapf(err, "delete snapshot %s", name)
+		}
+	}
+	return nil
+}
+
+// snapshotsToPrune returns the names of the snapshots taken by a schedule beyond the s.Keep newest ones.
+// snapshots are sorted oldest first, and snapshots saved by hand are never pruned.
+func snapshotsToPrune(snapshots []machine.Snapshot, s config.ScheduledAction) []string {
+	taken := []string{}
+	for _, sn := range snapshots {
+		if isScheduledSnapshot(sn.Name, s.Name) {
+			taken = append(taken, sn.Name)
+		}
+	}
+	if len(taken) <= s.Keep {
+		return nil
+	}
+	return taken[:len(taken)-s.Keep]
+}
+
+// isScheduledSnapshot returns whether a snapshot is named the way schedule names the snapshots it takes
+func isScheduledSnapshot(name string, schedule string) bool {
+	suffix := strings.TrimPrefix(name, schedule+"-")
+	if suffix == name {
+		return false
+	}
+	_, err := time.Parse(snapshotTimeFormat, suffix)
+	return err == nil
+}
+
+// nextSnapshot returns the snapshot schedule which is due first after t
+func nextSnapshot(cc *config.ClusterConfig, t time.Time) (*config.ScheduledAction, time.Time) {
+	var first *config.ScheduledAction
+	var next time.Time
+	for i, s := range cc.Schedules {
+		if s.Action != Snapshot {
+			continue
+		}
+		n := Next(s, t)
+		if n.IsZero() {
+			continue
+		}
+		if first == nil || n.Before(next) {
+			first, next = &cc.Schedules[i], n
+		}
+	}
+	return first, next
+}
+
+// takeSnapshot saves a snapshot, pausing any running nodes while it is taken
+func takeSnapshot(profile string, name string) error {
+	api, err := machine.NewAPIClient()
+	if err != nil {
+		return errors.Wrap(err, "api client")
+	}
+	defer api.Close()
+	cc, err := config.Load(profile)
+	if err != nil {
+		return errors.Wrap(err, "load profile")
+	}
+
+	for _, n := range cc.Nodes {
+		machineName := config.MachineName(*cc, n)
+		st, err := machine.Status(api, machineName)
+		if err != nil {
+			return errors.Wrapf(err, "status of %s", machineName)
+		}
+		if st != state.Running.String() {
+			continue
+		}
+		h, err := api.Load(machineName)
+		if err != nil {
+			return errors.Wrapf(err, "load %s", machineName)
+		}
+		runner, err := machine.CommandRunner(h)
+		if err != nil {
+			return errors.Wrap(err, "command runner")
+		}
+		// a node with no kubelet running is already paused
+		if !sysinit.New(runner).Active("kubelet") {
+			continue
+		}
+		cr, err := cruntime.New(cruntime.Config{Type: cc.KubernetesConfig.ContainerRuntime, Runner: runner})
+		if err != nil {
+			return errors.Wrap(err, "container runtime")
+		}
+		if _, err := cluster.Pause(cr, runner, constants.DefaultNamespaces); err != nil {
+			return errors.Wrapf(err, "pause %s", machineName)
+		}
+		defer func() {
+			if _, err := cluster.Unpause(cr, runner, constants.DefaultNamespaces); err != nil {
+				klog.Errorf("unpause %s: %v", machineName, err)
+			}
+		}()
+	}
+
+	if _, err := machine.SaveSnapshot(api, cc, name); err != nil {
+		return errors.Wrap(err, "save snapshot")
+	}
+	klog.Infof("saved scheduled snapshot %s of %s", name, profile)
+	return nil
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/minikube/machine"
+)
+
+func TestSnapshotsToPrune(t *testing.T) {
+	snapshots := []machine.Snapshot{
+		{Name: "backup-20210305-0000"},
+		{Name: "known-good"},
+		{Name: "backup-daily-20210305-0000"},
+		{Name: "backup-20210306-0000"},
+		{Name: "backup-20210307-0000"},
+	}
+
+	tests := []struct {
+		keep     int
+		expected []string
+	}{
+		{1, []string{"backup-20210305-0000", "backup-20210306-0000"}},
+		{2, []string{"backup-20210305-0000"}},
+		{3, nil},
+		{5, nil},
+	}
+	for _, tc := range tests {
+		got := snapshotsToPrune(snapshots, config.ScheduledAction{Name: "backup", Action: Snapshot, Cron: "@daily", Keep: tc.keep})
+		if diff := cmp.Diff(tc.expected, got); diff != "" {
+			t.Errorf("snapshotsToPrune(keep=%d) mismatch (-want +got):\n%s", tc.keep, diff)
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	storagev1 "k8s.io/client-go/kubernetes/typed/storage/v1"
 	"k8s.io/minikube/pkg/kapi"
@@ -69,6 +70,31 @@ func SetDefaultStorageClass(storage storagev1.StorageV1Interface, name string) e
 	return nil
 }
 
+// DeleteIfBindingModeChanged deletes the storage class if its volume binding mode is not mode.
+// The binding mode can not be updated, so the class has to be recreated by applying its addon again.
+func DeleteIfBindingModeChanged(storage storagev1.StorageV1Interface, name string, mode v1.VolumeBindingMode) (bool, error) {
+	sc, err := storage.StorageClasses().Get(name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "Error getting storage class %s", name)
+	}
+
+	current := v1.VolumeBindingImmediate
+	if sc.VolumeBindingMode != nil {
+		current = *sc.VolumeBindingMode
+	}
+	if current == mode {
+		return false, nil
+	}
+
+	if err := storage.StorageClasses().Delete(name, &metav1.DeleteOptions{}); err != nil {
+		return false, errors.Wrapf(err, "Error deleting storage class %s", name)
+	}
+	return true, nil
+}
+
 // GetStoragev1 return storage v1 interface for client
 func GetStoragev1(context string) (storagev1.StorageV1Interface, error) {
 	client, err := kapi.Client(context)
@@ -195,6 +195,47 @@ func TestSetDefaultStorageClass(t *testing.T) {
 	}
 }
 
+func TestDeleteIfBindingModeChanged(t *testing.T) {
+	immediate := v1.VolumeBindingImmediate
+	wait := v1.VolumeBindingWaitForFirstConsumer
+	var tests = []struct {
+		description string
+		mode        *v1.VolumeBindingMode
+		missing     bool
+		deleted     bool
+	}{
+		{description: "missing class", missing: true},
+		{description: "default binding mode", deleted: true},
+		{description: "immediate", mode: &immediate, deleted: true},
+		{description: "unchanged", mode: &wait},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			client := fake.NewSimpleClientset()
+			if !test.missing {
+				client = fake.NewSimpleClientset(&v1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "standard"}, VolumeBindingMode: test.mode})
+			}
+			sv1 := client.StorageV1()
+
+			deleted, err := DeleteIfBindingModeChanged(sv1, "standard", wait)
+			if err != nil {
+				t.Fatalf("Unexpected err: %v", err)
+			}
+			if deleted != test.deleted {
+				t.Errorf("expected deleted=%v, got %v", test.deleted, deleted)
+			}
+			list, err := sv1.StorageClasses().List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatalf("Unexpected err: %v", err)
+			}
+			if exists := len(list.Items) == 1; exists != (!test.missing && !test.deleted) {
+				t.Errorf("unexpected storage classes after the check: %v", list.Items)
+			}
+		})
+	}
+}
+
 var mockK8sConfig = `apiVersion: v1
 clusters:
 - cluster:
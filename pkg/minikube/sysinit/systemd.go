@@ -120,7 +120,13 @@ func (s *Systemd) GenerateInitShim(svc string, binary string, unit string) ([]as
 	return nil, nil
 }
 
+// usesSystemd returns whether systemd is running as the init system, rather than merely installed,
+// as on hosts booted with OpenRC or sysvinit, and in containers or WSL distributions started without it
 func usesSystemd(r Runner) bool {
-	_, err := r.RunCmd(exec.Command("systemctl", "--version"))
+	if _, err := r.RunCmd(exec.Command("systemctl", "--version")); err != nil {
+		return false
+	}
+	// the check of sd_booted(3)
+	_, err := r.RunCmd(exec.Command("test", "-d", "/run/systemd/system"))
 	return err == nil
 }
@@ -55,3 +55,41 @@ func TestEnable(t *testing.T) {
 		})
 	}
 }
+
+func TestUsesSystemd(t *testing.T) {
+	tests := []struct {
+		name string
+		cmds map[string]string
+		want bool
+	}{
+		{
+			name: "booted",
+			cmds: map[string]string{
+				"systemctl --version":         "systemd 246",
+				"test -d /run/systemd/system": "",
+			},
+			want: true,
+		},
+		{
+			name: "installed but not booted",
+			cmds: map[string]string{
+				"systemctl --version": "systemd 246",
+			},
+		},
+		{
+			name: "not installed",
+			cmds: map[string]string{
+				"service --version": "",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := command.NewFakeCommandRunner()
+			cr.SetCommandToOutput(tc.cmds)
+			if got := usesSystemd(cr); got != tc.want {
+				t.Errorf("usesSystemd() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
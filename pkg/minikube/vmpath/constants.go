@@ -27,10 +27,20 @@ const (
 	GuestPersistentDir = "/var/lib/minikube"
 	// GuestKubernetesCertsDir are where Kubernetes certificates are stored
 	GuestKubernetesCertsDir = GuestPersistentDir + "/certs"
+	// GuestOIDCCACert is the CA of the OIDC issuer, which the API server trusts
+	GuestOIDCCACert = GuestKubernetesCertsDir + "/oidc-ca.crt"
 	// GuestCertAuthDir is where system CA certificates are installed to
 	GuestCertAuthDir = "/usr/share/ca-certificates"
 	// GuestCertStoreDir is where system SSL certificates are installed
 	GuestCertStoreDir = "/etc/ssl/certs"
+	// GuestAuditPolicyDir is where the API server audit policy is stored
+	GuestAuditPolicyDir = "/etc/kubernetes/audit"
+	// GuestAuditPolicy is the API server audit policy
+	GuestAuditPolicy = GuestAuditPolicyDir + "/policy.yaml"
+	// GuestAuditLogDir is where the API server writes its audit log
+	GuestAuditLogDir = "/var/log/kubernetes/audit"
+	// GuestAuditLog is the API server audit log
+	GuestAuditLog = GuestAuditLogDir + "/audit.log"
 	// GuestGvisorDir is where gvisor bootstraps from
 	GuestGvisorDir = "/tmp/gvisor"
 )
@@ -53,7 +53,8 @@ type Parameters struct {
 	CIDR      string // form: CIDR
 	Gateway   string // first IP address (assumed, not checked !)
 	ClientMin string // second IP address
-	ClientMax string // last IP address before broadcastS
+	ClientMax string // last IP address handed out to clients, before the reserved one
+	Reserved  string // last IP address before broadcast, kept out of the client range for static use
 	Broadcast string // last IP address
 	Interface
 }
@@ -66,6 +67,11 @@ type Interface struct {
 	IfaceMAC  string
 }
 
+// Inspect returns the parameters of the network which addr belongs to, see inspect.
+func Inspect(addr string) (*Parameters, error) {
+	return inspect(addr)
+}
+
 // inspect initialises IPv4 network parameters struct from given address.
 // address can be single address (like "192.168.17.42"), network address (like "192.168.17.0"), or in cidr form (like "192.168.17.42/24 or "192.168.17.0/24").
 // If addr is valid existsing interface address, network struct will also contain info about the respective interface.
@@ -140,8 +146,12 @@ func inspect(addr string) (*Parameters, error) {
 	binary.BigEndian.PutUint32(min, gatewayIP+1) // clients-from: first network IP address after gateway
 	n.ClientMin = min.String()
 
+	reserved := make(net.IP, 4)
+	binary.BigEndian.PutUint32(reserved, broadcastIP-1) // last network IP address before broadcast
+	n.Reserved = reserved.String()
+
 	max := make(net.IP, 4)
-	binary.BigEndian.PutUint32(max, broadcastIP-1) // clients-from: last network IP address before broadcast
+	binary.BigEndian.PutUint32(max, broadcastIP-2) // clients-to: last network IP address before the reserved one
 	n.ClientMax = max.String()
 
 	return n, nil
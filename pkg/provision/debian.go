@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provision
+
+import (
+	"github.com/docker/machine/libmachine/drivers"
+	"github.com/docker/machine/libmachine/provision"
+	"github.com/docker/machine/libmachine/provision/pkgaction"
+	"github.com/pkg/errors"
+)
+
+var debian = distro{
+	name:      "debian",
+	ids:       []string{"debian"},
+	installed: "dpkg -s",
+	actions: map[pkgaction.PackageAction]string{
+		pkgaction.Install: "apt-get update -qq && sudo DEBIAN_FRONTEND=noninteractive apt-get install -y",
+		pkgaction.Remove:  "DEBIAN_FRONTEND=noninteractive apt-get remove -y",
+		pkgaction.Upgrade: "apt-get update -qq && sudo DEBIAN_FRONTEND=noninteractive apt-get install --only-upgrade -y",
+		pkgaction.Purge:   "DEBIAN_FRONTEND=noninteractive apt-get purge -y",
+	},
+	packages: debianPackages,
+	prepare:  debianRepositories,
+}
+
+// NewDebianProvisioner creates a new provisioner for Debian and its derivatives, which installs packages with apt
+func NewDebianProvisioner(d drivers.Driver) provision.Provisioner {
+	return newDistroProvisioner(d, debian)
+}
+
+func debianPackages(_ *provision.OsRelease, runtime string) ([]string, error) {
+	pkgs := []string{"conntrack", "socat", "ebtables", "ethtool", "iptables", "curl"}
+	switch runtime {
+	case "", "docker":
+		return append(pkgs, "docker.io"), nil
+	case "containerd":
+		return append(pkgs, "containerd"), nil
+	case "crio", "cri-o":
+		// packaged by the cri-o project rather than Debian, so its repository has to be added beforehand
+		return append(pkgs, "cri-o", "cri-o-runc"), nil
+	default:
+		return nil, errors.Errorf("unsupported container runtime: %s", runtime)
+	}
+}
+
+// debianRepositories checks that the repository of the cri-o project was added, if cri-o is to be installed
+func debianRepositories(p provision.SSHCommander, _ *provision.OsRelease, runtime string, kubernetesVersion string) error {
+	if !isCRIO(runtime) {
+		return nil
+	}
+	if _, err := p.SSHCommand("sudo apt-get update -qq && apt-cache show cri-o"); err != nil {
+		return errCRIORepositoryMissing("Debian", kubernetesVersion)
+	}
+	return nil
+}
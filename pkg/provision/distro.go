@@ -0,0 +1,303 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provision
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/docker/machine/libmachine/auth"
+	"github.com/docker/machine/libmachine/drivers"
+	"github.com/docker/machine/libmachine/engine"
+	"github.com/docker/machine/libmachine/provision"
+	"github.com/docker/machine/libmachine/provision/pkgaction"
+	"github.com/docker/machine/libmachine/swarm"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"k8s.io/klog/v2"
+	"k8s.io/minikube/pkg/minikube/config"
+	"k8s.io/minikube/pkg/util/retry"
+)
+
+// distro describes how to set up the container runtimes on a distribution which the ssh driver may be given,
+// rather than the minikube ISO or the kic base image, which come with all of them
+type distro struct {
+	// name is the name of the provisioner
+	name string
+	// ids are the values of ID and ID_LIKE in /etc/os-release of the distribution
+	ids []string
+	// installed is the command which checks whether a package is installed, such as "dpkg -s"
+	installed string
+	// actions are the commands of the package manager, such as "apt-get install -y", or nil if there is none
+	actions map[pkgaction.PackageAction]string
+	// packages returns the packages which provide the container runtime, and the tools kubeadm requires
+	packages func(osRelease *provision.OsRelease, runtime string) ([]string, error)
+	// prepare runs before missing packages are installed, such as to add the repositories providing them
+	prepare func(p provision.SSHCommander, osRelease *provision.OsRelease, runtime string, kubernetesVersion string) error
+	// configure runs after the packages are installed, to adapt the distribution to Kubernetes
+	configure func(p provision.SSHCommander, runtime string) error
+	// binDir is where crictl is installed, /usr/bin if empty
+	binDir string
+}
+
+// crictlVersion and cniPluginsVersion are the releases installed on the distributions, the same as on the minikube ISO
+const (
+	crictlVersion     = "v1.19.0"
+	cniPluginsVersion = "v0.8.5"
+)
+
+// DistroProvisioner provisions distributions without the container runtimes of the minikube ISO,
+// by installing the container runtime of the cluster with the package manager of the distribution
+type DistroProvisioner struct {
+	UbuntuProvisioner
+	distro distro
+}
+
+func newDistroProvisioner(d drivers.Driver, dist distro) provision.Provisioner {
+	return &DistroProvisioner{
+		UbuntuProvisioner{
+			BuildrootProvisioner{
+				NewSystemdProvisioner(dist.name, d),
+				viper.GetString(config.ProfileName),
+			},
+		},
+		dist,
+	}
+}
+
+func (p *DistroProvisioner) String() string {
+	return p.distro.name
+}
+
+// CompatibleWithHost checks if provisioner is compatible with host
+func (p *DistroProvisioner) CompatibleWithHost() bool {
+	ids := append([]string{p.OsReleaseInfo.ID}, strings.Fields(p.OsReleaseInfo.IDLike)...)
+	for _, id := range ids {
+		for _, want := range p.distro.ids {
+			if id == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GenerateDockerOptions generates the *provision.DockerOptions for this provisioner
+func (p *DistroProvisioner) GenerateDockerOptions(dockerPort int) (*provision.DockerOptions, error) {
+	// a drop-in, as the unit of the package is replaced by upgrades, or read-only
+	return p.generateDockerOptions(dockerPort, p.DaemonOptionsFile)
+}
+
+// Package installs a package
+func (p *DistroProvisioner) Package(name string, action pkgaction.PackageAction) error {
+	cmd, ok := p.distro.actions[action]
+	if !ok {
+		return errors.Errorf("%s can not %s packages", p.distro.name, action)
+	}
+	if _, err := p.SSHCommand(fmt.Sprintf("sudo %s %s", cmd, name)); err != nil {
+		return errors.Wrapf(err, "%s %s", action, name)
+	}
+	return nil
+}
+
+// Provision does the provisioning
+func (p *DistroProvisioner) Provision(swarmOptions swarm.Options, authOptions auth.Options, engineOptions engine.Options) error {
+	p.SwarmOptions = swarmOptions
+	p.AuthOptions = authOptions
+	p.EngineOptions = engineOptions
+
+	klog.Infof("provisioning hostname %q", p.Driver.GetMachineName())
+	if err := p.SetHostname(p.Driver.GetMachineName()); err != nil {
+		return err
+	}
+
+	c, err := config.Load(p.clusterName)
+	if err != nil {
+		return errors.Wrap(err, "getting cluster config")
+	}
+	runtime := c.KubernetesConfig.ContainerRuntime
+	if err := p.installRuntime(runtime, c.KubernetesConfig.KubernetesVersion); err != nil {
+		return errors.Wrapf(err, "install %s", runtime)
+	}
+
+	p.AuthOptions = setRemoteAuthOptions(p)
+	klog.Infof("set auth options %+v", p.AuthOptions)
+
+	klog.Infof("setting up certificates")
+	configAuth := func() error {
+		if err := configureAuth(p); err != nil {
+			klog.Warningf("configureAuth failed: %v", err)
+			return &retry.RetriableError{Err: err}
+		}
+		return nil
+	}
+
+	err = retry.Expo(configAuth, 100*time.Microsecond, 2*time.Minute)
+
+	if err != nil {
+		klog.Infof("Error configuring auth during provisioning %v", err)
+		return err
+	}
+
+	klog.Infof("setting minikube options for container-runtime")
+	if err := setContainerRuntimeOptions(p.clusterName, p); err != nil {
+		klog.Infof("Error setting container-runtime options during provisioning %v", err)
+		return err
+	}
+
+	return nil
+}
+
+// installRuntime installs the packages missing for runtime, along with crictl and the CNI plugins,
+// and configures the distribution for it
+func (p *DistroProvisioner) installRuntime(runtime string, kubernetesVersion string) error {
+	pkgs, err := p.distro.packages(p.OsReleaseInfo, runtime)
+	if err != nil {
+		return err
+	}
+	missing := []string{}
+	for _, pkg := range pkgs {
+		if _, err := p.SSHCommand(fmt.Sprintf("%s %s", p.distro.installed, pkg)); err != nil {
+			missing = append(missing, pkg)
+		}
+	}
+	if len(missing) > 0 {
+		if p.distro.prepare != nil {
+			if err := p.distro.prepare(p, p.OsReleaseInfo, runtime, kubernetesVersion); err != nil {
+				return err
+			}
+		}
+		klog.Infof("installing %v", missing)
+		if err := p.Package(strings.Join(missing, " "), pkgaction.Install); err != nil {
+			return err
+		}
+	}
+	if err := p.installTools(); err != nil {
+		return err
+	}
+
+	if p.distro.configure != nil {
+		if err := p.distro.configure(p, runtime); err != nil {
+			return err
+		}
+	}
+	if isCRIO(runtime) {
+		return updateCrioUnit(p)
+	}
+	return nil
+}
+
+// installTools installs the releases of crictl and the CNI plugins, unless they are already installed:
+// the distributions package neither, or not where kubeadm and the CNI configurations look for them
+func (p *DistroProvisioner) installTools() error {
+	machine, err := p.SSHCommand("uname -m")
+	if err != nil {
+		return errors.Wrap(err, "uname")
+	}
+	arch, err := releaseArch(strings.TrimSpace(machine))
+	if err != nil {
+		return err
+	}
+	binDir := p.distro.binDir
+	if binDir == "" {
+		binDir = "/usr/bin"
+	}
+
+	tools := []struct {
+		installed string
+		url       string
+		dir       string
+	}{
+		{
+			installed: fmt.Sprintf("test -x %s || command -v crictl", path.Join(binDir, "crictl")),
+			url:       fmt.Sprintf("https://github.com/kubernetes-sigs/cri-tools/releases/download/%s/crictl-%s-linux-%s.tar.gz", crictlVersion, crictlVersion, arch),
+			dir:       binDir,
+		},
+		{
+			installed: "test -x /opt/cni/bin/bridge",
+			url:       fmt.Sprintf("https://github.com/containernetworking/plugins/releases/download/%s/cni-plugins-linux-%s-%s.tgz", cniPluginsVersion, arch, cniPluginsVersion),
+			dir:       "/opt/cni/bin",
+		},
+	}
+	for _, t := range tools {
+		if _, err := p.SSHCommand(t.installed); err == nil {
+			continue
+		}
+		klog.Infof("installing %s to %s", t.url, t.dir)
+		if _, err := p.SSHCommand(fmt.Sprintf("sudo mkdir -p %s && curl -fsSL %s | sudo tar -C %s -xz", t.dir, t.url, t.dir)); err != nil {
+			return errors.Wrapf(err, "install %s", t.url)
+		}
+	}
+	return nil
+}
+
+// releaseArch returns the architecture the releases of crictl and the CNI plugins are named after, for the output of uname -m
+func releaseArch(machine string) (string, error) {
+	switch machine {
+	case "x86_64":
+		return "amd64", nil
+	case "aarch64", "arm64":
+		return "arm64", nil
+	case "armv7l":
+		return "arm", nil
+	case "ppc64le", "s390x":
+		return machine, nil
+	default:
+		return "", errors.Errorf("unsupported architecture: %s", machine)
+	}
+}
+
+// kubernetesMinor returns the minor release of a Kubernetes version, such as 1.20 for v1.20.2, which cri-o follows
+func kubernetesMinor(kubernetesVersion string) (string, error) {
+	v, err := semver.ParseTolerant(kubernetesVersion)
+	if err != nil {
+		return "", errors.Wrapf(err, "parse kubernetes version %q", kubernetesVersion)
+	}
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor), nil
+}
+
+// errCRIORepositoryMissing explains how to provide cri-o on a distribution which does not package it
+func errCRIORepositoryMissing(distro string, kubernetesVersion string) error {
+	minor, err := kubernetesMinor(kubernetesVersion)
+	if err != nil {
+		return err
+	}
+	return errors.Errorf("%s does not package cri-o: add the repository of cri-o %s, see https://github.com/cri-o/cri-o/blob/master/install.md, or use --container-runtime=docker or containerd", distro, minor)
+}
+
+func isCRIO(runtime string) bool {
+	return runtime == "crio" || runtime == "cri-o"
+}
+
+// crioDropIn passes the options of setCrioOptions to cri-o, along with the options the packages of the distributions pass.
+// It is written through the shell, so the variables are escaped for systemd to expand them.
+var crioDropIn = `[Service]
+EnvironmentFile=-/etc/sysconfig/crio.minikube
+ExecStart=
+ExecStart=/usr/bin/crio \$CRIO_CONFIG_OPTIONS \$CRIO_RUNTIME_OPTIONS \$CRIO_STORAGE_OPTIONS \$CRIO_NETWORK_OPTIONS \$CRIO_METRICS_OPTIONS \$CRIO_MINIKUBE_OPTIONS
+`
+
+func updateCrioUnit(p provision.SSHCommander) error {
+	dst := "/etc/systemd/system/crio.service.d/10-minikube.conf"
+	if _, err := p.SSHCommand(fmt.Sprintf("sudo mkdir -p %s && printf %%s \"%s\" | sudo tee %s", path.Dir(dst), crioDropIn, dst)); err != nil {
+		return err
+	}
+	_, err := p.SSHCommand("sudo systemctl daemon-reload")
+	return err
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provision
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/docker/machine/libmachine/provision"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompatibleProvisioner(t *testing.T) {
+	tests := []struct {
+		id     string
+		idLike string
+		want   string
+	}{
+		{"buildroot", "", "buildroot"},
+		{"ubuntu", "debian", "ubuntu"},
+		{"debian", "", "debian"},
+		{"raspbian", "debian", "debian"},
+		{"fedora", "", "fedora"},
+		{"rocky", "rhel centos fedora", "fedora"},
+		{"rhel", "fedora", "fedora"},
+		{"flatcar", "coreos", "flatcar"},
+		{"arch", "", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.id, func(t *testing.T) {
+			p := compatibleProvisioner(nil, &provision.OsRelease{ID: tc.id, IDLike: tc.idLike})
+			got := ""
+			if p != nil {
+				got = p.String()
+			}
+			if got != tc.want {
+				t.Errorf("compatibleProvisioner(%s) = %q, want %q", tc.id, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDistroPackages(t *testing.T) {
+	tests := []struct {
+		name     string
+		packages func(*provision.OsRelease, string) ([]string, error)
+		id       string
+		runtime  string
+		want     []string
+		wantErr  bool
+	}{
+		{"debian docker", debianPackages, "debian", "docker", []string{"conntrack", "socat", "ebtables", "ethtool", "iptables", "curl", "docker.io"}, false},
+		{"debian containerd", debianPackages, "debian", "containerd", []string{"conntrack", "socat", "ebtables", "ethtool", "iptables", "curl", "containerd"}, false},
+		{"fedora docker", fedoraPackages, "fedora", "docker", []string{"conntrack-tools", "socat", "ethtool", "iptables", "moby-engine"}, false},
+		{"rocky docker", fedoraPackages, "rocky", "docker", []string{"conntrack-tools", "socat", "ethtool", "iptables", "docker-ce"}, false},
+		{"rocky containerd", fedoraPackages, "rocky", "containerd", []string{"conntrack-tools", "socat", "ethtool", "iptables", "containerd.io"}, false},
+		{"fedora crio", fedoraPackages, "fedora", "crio", []string{"conntrack-tools", "socat", "ethtool", "iptables", "cri-o"}, false},
+		{"flatcar containerd", flatcarPackages, "flatcar", "containerd", nil, false},
+		{"flatcar crio", flatcarPackages, "flatcar", "crio", nil, true},
+		{"debian unknown", debianPackages, "debian", "rkt", nil, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.packages(&provision.OsRelease{ID: tc.id}, tc.runtime)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("packages diff (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+// fakeCommander records the commands it runs, failing those in fail
+type fakeCommander struct {
+	cmds []string
+	fail map[string]bool
+}
+
+func (f *fakeCommander) SSHCommand(args string) (string, error) {
+	f.cmds = append(f.cmds, args)
+	if f.fail[args] {
+		return "", errors.New("exit status 1")
+	}
+	return "", nil
+}
+
+func TestFedoraRepositories(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		runtime string
+		fail    map[string]bool
+		want    []string
+		wantErr bool
+	}{
+		{name: "fedora docker", id: "fedora", runtime: "docker"},
+		{name: "fedora crio", id: "fedora", runtime: "crio", want: []string{"sudo dnf module enable -y cri-o:1.20"}},
+		{name: "rocky docker", id: "rocky", runtime: "docker", want: []string{"sudo dnf install -y dnf-plugins-core && sudo dnf config-manager --add-repo " + dockerCERepo}},
+		{name: "rocky crio", id: "rocky", runtime: "crio", want: []string{"dnf info cri-o"}},
+		{name: "rocky crio without repository", id: "rocky", runtime: "crio", fail: map[string]bool{"dnf info cri-o": true}, want: []string{"dnf info cri-o"}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &fakeCommander{fail: tc.fail}
+			err := fedoraRepositories(f, &provision.OsRelease{ID: tc.id, Name: tc.id}, tc.runtime, "v1.20.2")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, f.cmds); diff != "" {
+				t.Errorf("commands diff (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestReleaseArch(t *testing.T) {
+	tests := map[string]string{"x86_64": "amd64", "aarch64": "arm64", "armv7l": "arm", "s390x": "s390x"}
+	for machine, want := range tests {
+		got, err := releaseArch(machine)
+		if err != nil || got != want {
+			t.Errorf("releaseArch(%q) = %q, %v, want %q", machine, got, err, want)
+		}
+	}
+	if _, err := releaseArch("mips"); err == nil {
+		t.Errorf("releaseArch(mips) succeeded, want an error")
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provision
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/machine/libmachine/drivers"
+	"github.com/docker/machine/libmachine/provision"
+	"github.com/docker/machine/libmachine/provision/pkgaction"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+)
+
+// dockerCERepo provides docker-ce and containerd.io for the distributions compatible with RHEL, which do not package docker
+const dockerCERepo = "https://download.docker.com/linux/centos/docker-ce.repo"
+
+var fedora = distro{
+	name:      "fedora",
+	ids:       []string{"fedora", "rhel", "centos"},
+	installed: "rpm -q",
+	actions: map[pkgaction.PackageAction]string{
+		pkgaction.Install: "dnf install -y",
+		pkgaction.Remove:  "dnf remove -y",
+		pkgaction.Upgrade: "dnf upgrade -y",
+		pkgaction.Purge:   "dnf remove -y",
+	},
+	packages:  fedoraPackages,
+	prepare:   fedoraRepositories,
+	configure: configureSELinux,
+}
+
+// NewFedoraProvisioner creates a new provisioner for Fedora, RHEL and the distributions compatible with them, which installs packages with dnf
+func NewFedoraProvisioner(d drivers.Driver) provision.Provisioner {
+	return newDistroProvisioner(d, fedora)
+}
+
+func fedoraPackages(osRelease *provision.OsRelease, runtime string) ([]string, error) {
+	pkgs := []string{"conntrack-tools", "socat", "ethtool", "iptables"}
+	isFedora := osRelease.ID == "fedora"
+	switch runtime {
+	case "", "docker":
+		if isFedora {
+			return append(pkgs, "moby-engine"), nil
+		}
+		return append(pkgs, "docker-ce"), nil
+	case "containerd":
+		if isFedora {
+			return append(pkgs, "containerd"), nil
+		}
+		return append(pkgs, "containerd.io"), nil
+	case "crio", "cri-o":
+		// Fedora ships cri-o as a module, RHEL does not package it, so its repository has to be added beforehand there
+		return append(pkgs, "cri-o"), nil
+	default:
+		return nil, errors.Errorf("unsupported container runtime: %s", runtime)
+	}
+}
+
+// fedoraRepositories enables the cri-o module stream of the Kubernetes version on Fedora, checks that the
+// cri-o repository was added on RHEL, and adds the docker-ce repository on distributions compatible with RHEL
+func fedoraRepositories(p provision.SSHCommander, osRelease *provision.OsRelease, runtime string, kubernetesVersion string) error {
+	isFedora := osRelease.ID == "fedora"
+	if isCRIO(runtime) {
+		if !isFedora {
+			if _, err := p.SSHCommand("dnf info cri-o"); err != nil {
+				return errCRIORepositoryMissing(osRelease.Name, kubernetesVersion)
+			}
+			return nil
+		}
+		minor, err := kubernetesMinor(kubernetesVersion)
+		if err != nil {
+			return err
+		}
+		klog.Infof("enabling module stream cri-o:%s", minor)
+		if _, err := p.SSHCommand(fmt.Sprintf("sudo dnf module enable -y cri-o:%s", minor)); err != nil {
+			return errors.Wrapf(err, "enable cri-o:%s module stream", minor)
+		}
+		return nil
+	}
+	if isFedora {
+		return nil
+	}
+	klog.Infof("adding repository %s", dockerCERepo)
+	if _, err := p.SSHCommand(fmt.Sprintf("sudo dnf install -y dnf-plugins-core && sudo dnf config-manager --add-repo %s", dockerCERepo)); err != nil {
+		return errors.Wrap(err, "add docker-ce repository")
+	}
+	return nil
+}
+
+// configureSELinux switches SELinux to permissive mode, as kubeadm requires for containers to access the host,
+// and labels the host paths which minikube provides to pods, so that they remain usable if it is enforced again
+func configureSELinux(p provision.SSHCommander, _ string) error {
+	mode, err := p.SSHCommand("getenforce")
+	if err != nil {
+		klog.Infof("SELinux is not installed: %v", err)
+		return nil
+	}
+	if strings.TrimSpace(mode) != "Enforcing" {
+		return nil
+	}
+	klog.Infof("switching SELinux to permissive mode")
+	cmds := []string{
+		"sudo setenforce 0",
+		"sudo sed -i 's/^SELINUX=enforcing$/SELINUX=permissive/' /etc/selinux/config",
+		"sudo mkdir -p /data /tmp/hostpath-provisioner /tmp/hostpath_pv",
+		"sudo chcon -R -t container_file_t /data /tmp/hostpath-provisioner /tmp/hostpath_pv",
+	}
+	for _, c := range cmds {
+		if _, err := p.SSHCommand(c); err != nil {
+			return errors.Wrap(err, "configure SELinux")
+		}
+	}
+	return nil
+}
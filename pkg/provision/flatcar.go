@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package provision
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/docker/machine/libmachine/drivers"
+	"github.com/docker/machine/libmachine/provision"
+	"github.com/pkg/errors"
+)
+
+var flatcar = distro{
+	name: "flatcar",
+	ids:  []string{"flatcar"},
+	// Flatcar has no package manager, but comes with docker and containerd
+	packages:  flatcarPackages,
+	configure: configureFlatcarContainerd,
+	// /usr is read-only
+	binDir: "/opt/bin",
+}
+
+// NewFlatcarProvisioner creates a new provisioner for Flatcar Container Linux, which comes with docker and containerd
+func NewFlatcarProvisioner(d drivers.Driver) provision.Provisioner {
+	return newDistroProvisioner(d, flatcar)
+}
+
+func flatcarPackages(_ *provision.OsRelease, runtime string) ([]string, error) {
+	switch runtime {
+	case "", "docker", "containerd":
+		return nil, nil
+	default:
+		return nil, errors.Errorf("Flatcar does not come with the %s container runtime, use docker or containerd", runtime)
+	}
+}
+
+// flatcarContainerdDropIn makes containerd read the config minikube writes, rather than the one in the read-only /usr
+var flatcarContainerdDropIn = `[Service]
+Environment=CONTAINERD_CONFIG=/etc/containerd/config.toml
+`
+
+func configureFlatcarContainerd(p provision.SSHCommander, runtime string) error {
+	if runtime != "containerd" {
+		return nil
+	}
+	dst := "/etc/systemd/system/containerd.service.d/10-minikube.conf"
+	if _, err := p.SSHCommand(fmt.Sprintf("sudo mkdir -p %s && printf %%s \"%s\" | sudo tee %s && sudo systemctl daemon-reload", path.Dir(dst), flatcarContainerdDropIn, dst)); err != nil {
+		return errors.Wrap(err, "containerd drop-in")
+	}
+	return nil
+}
@@ -56,6 +56,15 @@ type miniProvisioner interface {
 // for escaping systemd template specifiers (e.g. '%i'), which are not supported by minikube
 var systemdSpecifierEscaper = strings.NewReplacer("%", "%%")
 
+// provisioners are the minikube provisioners, in the order DetectProvisioner tries them
+var provisioners = []func(drivers.Driver) provision.Provisioner{
+	NewBuildrootProvisioner,
+	NewUbuntuProvisioner,
+	NewDebianProvisioner,
+	NewFedoraProvisioner,
+	NewFlatcarProvisioner,
+}
+
 func init() {
 	provision.Register("Buildroot", &provision.RegisteredProvisioner{
 		New: NewBuildrootProvisioner,
@@ -63,7 +72,51 @@ func init() {
 	provision.Register("Ubuntu", &provision.RegisteredProvisioner{
 		New: NewUbuntuProvisioner,
 	})
+	// replace the upstream provisioners of the same name, which install docker with a script from the internet
+	provision.Register("Debian", &provision.RegisteredProvisioner{
+		New: NewDebianProvisioner,
+	})
+	provision.Register("Fedora", &provision.RegisteredProvisioner{
+		New: NewFedoraProvisioner,
+	})
+	provision.Register("Flatcar", &provision.RegisteredProvisioner{
+		New: NewFlatcarProvisioner,
+	})
+}
 
+// DetectProvisioner returns the minikube provisioner compatible with the /etc/os-release of the host,
+// falling back to the upstream provisioners for other distributions. Unlike the upstream detection,
+// which tries the registered provisioners in random order, the order of provisioners is kept.
+func DetectProvisioner(d drivers.Driver) (provision.Provisioner, error) {
+	if err := drivers.WaitForSSH(d); err != nil {
+		return nil, err
+	}
+	osRelease, err := drivers.RunSSHCommandFromDriver(d, "cat /etc/os-release")
+	if err != nil {
+		return nil, errors.Wrap(err, "read /etc/os-release")
+	}
+	info, err := provision.NewOsRelease([]byte(osRelease))
+	if err != nil {
+		return nil, errors.Wrap(err, "parse /etc/os-release")
+	}
+	if p := compatibleProvisioner(d, info); p != nil {
+		klog.Infof("detected %s provisioner for %s", p, info.PrettyName)
+		return p, nil
+	}
+	klog.Infof("no minikube provisioner for %s, trying the upstream ones", info.PrettyName)
+	return provision.DetectProvisioner(d)
+}
+
+// compatibleProvisioner returns the first of provisioners which is compatible with info, or nil
+func compatibleProvisioner(d drivers.Driver, info *provision.OsRelease) provision.Provisioner {
+	for _, newProvisioner := range provisioners {
+		p := newProvisioner(d)
+		p.SetOsReleaseInfo(info)
+		if p.CompatibleWithHost() {
+			return p
+		}
+	}
+	return nil
 }
 
 // NewSystemdProvisioner is our fork of the same name in the upstream provision library, without the packages
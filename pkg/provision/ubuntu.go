@@ -60,6 +60,11 @@ func (p *UbuntuProvisioner) CompatibleWithHost() bool {
 
 // GenerateDockerOptions generates the *provision.DockerOptions for this provisioner
 func (p *UbuntuProvisioner) GenerateDockerOptions(dockerPort int) (*provision.DockerOptions, error) {
+	return p.generateDockerOptions(dockerPort, "/lib/systemd/system/docker.service")
+}
+
+// generateDockerOptions writes the docker unit to unitPath, which may also be a drop-in replacing the unit of the distribution
+func (p *UbuntuProvisioner) generateDockerOptions(dockerPort int, unitPath string) (*provision.DockerOptions, error) {
 	var engineCfg bytes.Buffer
 
 	drvLabel := fmt.Sprintf("provider=%s", p.Driver.DriverName())
@@ -150,7 +155,7 @@ WantedBy=multi-user.target
 
 	do := &provision.DockerOptions{
 		EngineOptions:     engineCfg.String(),
-		EngineOptionsPath: "/lib/systemd/system/docker.service",
+		EngineOptionsPath: unitPath,
 	}
 	return do, updateUnit(p, "docker", do.EngineOptions, do.EngineOptionsPath)
 }
@@ -0,0 +1,25 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+// quota limits the amount of data that may be written to a volume directory.
+type quota interface {
+	// Set limits dir, and everything created beneath it, to bytes.
+	Set(dir string, bytes int64) error
+	// Clear removes any limit previously set on dir.
+	Clear(dir string) error
+}
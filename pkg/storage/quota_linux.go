@@ -0,0 +1,283 @@
+// +build linux
+
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// ioctls and flags from linux/fs.h, not yet exported by x/sys/unix
+	fsIOCFSGetXAttr    = 0x801c581f
+	fsIOCFSSetXAttr    = 0x401c5820
+	fsXFlagProjInherit = 0x200
+
+	// quotactl commands and types from linux/quota.h
+	qGetInfo     = 0x800005
+	qGetQuota    = 0x800007
+	qSetQuota    = 0x800008
+	prjQuota     = 2
+	qifBLimits   = 1
+	qifBlockSize = 1024
+
+	// firstProjectID keeps the IDs we hand out clear of those assigned by hand or by the kubelet
+	firstProjectID = 1 << 24
+	maxProjectIDs  = 1 << 16
+)
+
+// fsxattr mirrors struct fsxattr from linux/fs.h
+type fsxattr struct {
+	XFlags     uint32
+	ExtSize    uint32
+	NExtents   uint32
+	ProjID     uint32
+	CowExtSize uint32
+	Pad        [8]byte
+}
+
+// dqblk mirrors struct if_dqblk from linux/quota.h
+type dqblk struct {
+	BHardLimit uint64
+	BSoftLimit uint64
+	CurSpace   uint64
+	IHardLimit uint64
+	ISoftLimit uint64
+	CurInodes  uint64
+	BTime      uint64
+	ITime      uint64
+	Valid      uint32
+	_          uint32
+}
+
+// dqinfo mirrors struct if_dqinfo from linux/quota.h
+type dqinfo struct {
+	BGrace uint64
+	IGrace uint64
+	Flags  uint32
+	Valid  uint32
+}
+
+// projectQuota enforces capacity using xfs or ext4 project quotas
+type projectQuota struct {
+	// device is the block device backing the volume directory
+	device string
+
+	// mu serializes project ID allocation
+	mu sync.Mutex
+}
+
+// newQuota returns a project quota for the filesystem backing dir, or an
+// error explaining why capacity can not be enforced there.
+func newQuota(dir string) (quota, error) {
+	var st unix.Statfs_t
+	if err := unix.Statfs(dir, &st); err != nil {
+		return nil, errors.Wrapf(err, "statfs %s", dir)
+	}
+	switch int64(st.Type) {
+	case unix.EXT4_SUPER_MAGIC, unix.XFS_SUPER_MAGIC:
+	default:
+		return nil, fmt.Errorf("filesystem type %#x of %s does not support project quotas", st.Type, dir)
+	}
+
+	dev, err := blockDevice(dir)
+	if err != nil {
+		return nil, err
+	}
+	q := &projectQuota{device: dev}
+	var info dqinfo
+	if err := q.quotactl(qGetInfo, 0, unsafe.Pointer(&info)); err != nil {
+		return nil, errors.Wrapf(err, "project quotas are not enabled on %s", dev)
+	}
+	return q, nil
+}
+
+// Set limits dir to bytes, assigning it a project ID first if it has none.
+func (q *projectQuota) Set(dir string, bytes int64) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	id, err := projectID(dir)
+	if err != nil {
+		return err
+	}
+	if id == 0 {
+		if id, err = q.freeProjectID(); err != nil {
+			return err
+		}
+		if err := setProjectID(dir, id); err != nil {
+			return err
+		}
+	}
+	return q.setLimit(id, uint64((bytes+qifBlockSize-1)/qifBlockSize))
+}
+
+// Clear removes the limit of the project dir belongs to, freeing its ID.
+func (q *projectQuota) Clear(dir string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	id, err := projectID(dir)
+	if err != nil || id == 0 {
+		return err
+	}
+	return q.setLimit(id, 0)
+}
+
+// freeProjectID returns the first project ID that has neither a limit nor any usage
+func (q *projectQuota) freeProjectID() (uint32, error) {
+	for id := uint32(firstProjectID); id < firstProjectID+maxProjectIDs; id++ {
+		var d dqblk
+		if err := q.quotactl(qGetQuota, id, unsafe.Pointer(&d)); err != nil {
+			return 0, errors.Wrapf(err, "get quota for project %d", id)
+		}
+		if d.BHardLimit == 0 && d.CurInodes == 0 && d.CurSpace == 0 {
+			return id, nil
+		}
+	}
+	return 0, errors.New("no free project ID")
+}
+
+func (q *projectQuota) setLimit(id uint32, blocks uint64) error {
+	d := dqblk{BHardLimit: blocks, BSoftLimit: blocks, Valid: qifBLimits}
+	if err := q.quotactl(qSetQuota, id, unsafe.Pointer(&d)); err != nil {
+		return errors.Wrapf(err, "set quota for project %d", id)
+	}
+	return nil
+}
+
+func (q *projectQuota) quotactl(cmd int, id uint32, addr unsafe.Pointer) error {
+	dev, err := unix.BytePtrFromString(q.device)
+	if err != nil {
+		return err
+	}
+	_, _, errno := unix.Syscall6(unix.SYS_QUOTACTL, uintptr(cmd<<8|prjQuota), uintptr(unsafe.Pointer(dev)), uintptr(id), uintptr(addr), 0, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// projectID returns the project dir belongs to, 0 meaning none
+func projectID(dir string) (uint32, error) {
+	var attr fsxattr
+	if err := fsxattrIoctl(dir, fsIOCFSGetXAttr, &attr); err != nil {
+		return 0, errors.Wrapf(err, "get project of %s", dir)
+	}
+	return attr.ProjID, nil
+}
+
+// setProjectID assigns dir to project id, which anything created beneath it inherits
+func setProjectID(dir string, id uint32) error {
+	var attr fsxattr
+	if err := fsxattrIoctl(dir, fsIOCFSGetXAttr, &attr); err != nil {
+		return errors.Wrapf(err, "get project of %s", dir)
+	}
+	attr.ProjID = id
+	attr.XFlags |= fsXFlagProjInherit
+	if err := fsxattrIoctl(dir, fsIOCFSSetXAttr, &attr); err != nil {
+		return errors.Wrapf(err, "set project of %s", dir)
+	}
+	return nil
+}
+
+func fsxattrIoctl(dir string, req uintptr, attr *fsxattr) error {
+	f, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(), req, uintptr(unsafe.Pointer(attr)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// blockDevice returns a path to the block device backing dir. When the mount
+// source is not visible, as is usual inside a container, a device node is made.
+func blockDevice(dir string) (string, error) {
+	dir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var mountPoint, source string
+	var major, minor uint64
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		// 36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+		fields := strings.Fields(s.Text())
+		sep := -1
+		for i, f := range fields {
+			if f == "-" {
+				sep = i
+				break
+			}
+		}
+		if len(fields) < 5 || sep < 0 || len(fields) < sep+3 {
+			continue
+		}
+		mp := fields[4]
+		if !strings.HasPrefix(dir+"/", strings.TrimSuffix(mp, "/")+"/") || len(mp) < len(mountPoint) {
+			continue
+		}
+		devno := strings.SplitN(fields[2], ":", 2)
+		if len(devno) != 2 {
+			continue
+		}
+		maj, err1 := strconv.ParseUint(devno[0], 10, 32)
+		min, err2 := strconv.ParseUint(devno[1], 10, 32)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		mountPoint, source, major, minor = mp, fields[sep+2], maj, min
+	}
+	if err := s.Err(); err != nil {
+		return "", err
+	}
+	if mountPoint == "" {
+		return "", fmt.Errorf("no mount found for %s", dir)
+	}
+
+	dev := unix.Mkdev(uint32(major), uint32(minor))
+	var st unix.Stat_t
+	if err := unix.Stat(source, &st); err == nil && st.Mode&unix.S_IFMT == unix.S_IFBLK && uint64(st.Rdev) == dev {
+		return source, nil
+	}
+	node := filepath.Join(os.TempDir(), fmt.Sprintf("hostpath-provisioner-%d-%d", major, minor))
+	if err := unix.Mknod(node, unix.S_IFBLK|0600, int(dev)); err != nil && !os.IsExist(err) {
+		return "", errors.Wrapf(err, "mknod %s for %s", node, source)
+	}
+	return node, nil
+}
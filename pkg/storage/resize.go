@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+// annProvisionedBy is set on PVs by the provision controller
+const annProvisionedBy = "pv.kubernetes.io/provisioned-by"
+
+// resyncPeriod is how often claims are looked at again, retrying failed resizes
+const resyncPeriod = time.Minute
+
+// resizer expands PVs whose claims request more storage than they were
+// provisioned with. hostPath volumes need no filesystem resize, so the claim
+// is marked as resized as soon as the PV and its quota are.
+type resizer struct {
+	client kubernetes.Interface
+
+	// owns returns whether a PV belongs to the provisioner, only its own PVs are resized
+	owns func(*core.PersistentVolume) bool
+
+	// quota enforces the new capacity, may be nil
+	quota quota
+}
+
+// Run starts watching claims until stopCh is closed
+func (r *resizer) Run(stopCh <-chan struct{}) {
+	factory := informers.NewSharedInformerFactory(r.client, resyncPeriod)
+	informer := factory.Core().V1().PersistentVolumeClaims().Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    r.handle,
+		UpdateFunc: func(_, obj interface{}) { r.handle(obj) },
+	})
+	factory.Start(stopCh)
+}
+
+func (r *resizer) handle(obj interface{}) {
+	pvc, ok := obj.(*core.PersistentVolumeClaim)
+	if !ok {
+		return
+	}
+	if err := r.resize(pvc); err != nil {
+		klog.Errorf("Failed to resize volume of claim %s/%s: %v", pvc.Namespace, pvc.Name, err)
+	}
+}
+
+// resize expands the PV bound to pvc if it asks for more than its capacity
+func (r *resizer) resize(pvc *core.PersistentVolumeClaim) error {
+	if pvc.Status.Phase != core.ClaimBound || pvc.Spec.VolumeName == "" {
+		return nil
+	}
+	want := pvc.Spec.Resources.Requests[core.ResourceStorage]
+	have := pvc.Status.Capacity[core.ResourceStorage]
+	if want.Cmp(have) <= 0 {
+		return nil
+	}
+
+	pv, err := r.client.CoreV1().PersistentVolumes().Get(pvc.Spec.VolumeName, meta.GetOptions{})
+	if err != nil {
+		return errors.Wrap(err, "get volume")
+	}
+	if pv.Annotations[annProvisionedBy] != provisionerName || !r.owns(pv) || pv.Spec.HostPath == nil {
+		return nil
+	}
+
+	klog.Infof("Expanding volume %s from %s to %s", pv.Name, have.String(), want.String())
+	if r.quota != nil {
+		if err := r.quota.Set(pv.Spec.HostPath.Path, want.Value()); err != nil {
+			return errors.Wrap(err, "setting quota")
+		}
+	}
+
+	if c := pv.Spec.Capacity[core.ResourceStorage]; c.Cmp(want) < 0 {
+		pv = pv.DeepCopy()
+		pv.Spec.Capacity[core.ResourceStorage] = want
+		if _, err := r.client.CoreV1().PersistentVolumes().Update(pv); err != nil {
+			return errors.Wrap(err, "update volume capacity")
+		}
+	}
+
+	pvc = pvc.DeepCopy()
+	if pvc.Status.Capacity == nil {
+		pvc.Status.Capacity = core.ResourceList{}
+	}
+	pvc.Status.Capacity[core.ResourceStorage] = want
+	var conditions []core.PersistentVolumeClaimCondition
+	for _, c := range pvc.Status.Conditions {
+		if c.Type != core.PersistentVolumeClaimResizing && c.Type != core.PersistentVolumeClaimFileSystemResizePending {
+			conditions = append(conditions, c)
+		}
+	}
+	pvc.Status.Conditions = conditions
+	if _, err := r.client.CoreV1().PersistentVolumeClaims(pvc.Namespace).UpdateStatus(pvc); err != nil {
+		return errors.Wrap(err, "update claim capacity")
+	}
+	return nil
+}
@@ -20,9 +20,11 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
 
 	"github.com/pkg/errors"
 	core "k8s.io/api/core/v1"
+	storage "k8s.io/api/storage/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
@@ -35,28 +37,87 @@ import (
 
 const provisionerName = "k8s.io/minikube-hostpath"
 
+// reclaimPolicyParam is the StorageClass parameter overriding its reclaimPolicy,
+// which lets a class ask for Recycle: the reclaimPolicy field only accepts Retain and Delete.
+const reclaimPolicyParam = "reclaimPolicy"
+
+// annSelectedNode is set on claims by the scheduler once a pod using them is placed
+const annSelectedNode = "volume.kubernetes.io/selected-node"
+
+// annIdentity marks which provisioner created a PV, only that one may delete or resize it
+const annIdentity = "hostPathProvisionerIdentity"
+
 type hostPathProvisioner struct {
 	// The directory to create PV-backing directories in
 	pvDir string
 
-	// Identity of this hostPathProvisioner, generated. Used to identify "this"
-	// provisioner's PVs.
+	// Identity of this hostPathProvisioner, the node name when running per
+	// node, generated otherwise. Used to identify "this" provisioner's PVs.
 	identity types.UID
+
+	// The node this provisioner creates volumes on, empty when it is the only
+	// provisioner and its PVs carry no node affinity
+	nodeName string
+
+	// Whether to provision claims which have no selected node, i.e. those of
+	// StorageClasses binding immediately. Only the primary control plane does.
+	immediate bool
+
+	// Enforces the capacity of PVs, nil if the filesystem under pvDir has no project quotas
+	quota quota
 }
 
 // NewHostPathProvisioner creates a new Provisioner using host paths
 func NewHostPathProvisioner(pvDir string) controller.Provisioner {
-	return &hostPathProvisioner{
-		pvDir:    pvDir,
-		identity: uuid.NewUUID(),
+	return newHostPathProvisioner(pvDir, "", true)
+}
+
+func newHostPathProvisioner(pvDir string, nodeName string, immediate bool) *hostPathProvisioner {
+	p := &hostPathProvisioner{
+		pvDir:     pvDir,
+		identity:  uuid.NewUUID(),
+		nodeName:  nodeName,
+		immediate: immediate,
+	}
+	if nodeName != "" {
+		p.identity = types.UID(nodeName)
+	}
+	q, err := newQuota(pvDir)
+	if err != nil {
+		klog.Infof("Volume capacity will not be enforced: %v", err)
+		return p
 	}
+	p.quota = q
+	return p
 }
 
 var _ controller.Provisioner = &hostPathProvisioner{}
+var _ controller.Qualifier = &hostPathProvisioner{}
+
+// ShouldProvision returns whether the volume of claim belongs on this provisioner's node.
+func (p *hostPathProvisioner) ShouldProvision(claim *core.PersistentVolumeClaim) bool {
+	if p.nodeName == "" {
+		return true
+	}
+	node := claim.Annotations[annSelectedNode]
+	if node == "" {
+		return p.immediate
+	}
+	return node == p.nodeName
+}
 
 // Provision creates a storage asset and returns a PV object representing it.
 func (p *hostPathProvisioner) Provision(options controller.ProvisionOptions) (*core.PersistentVolume, error) {
+	policy, err := reclaimPolicy(options.StorageClass)
+	if err != nil {
+		return nil, err
+	}
+
 	path := path.Join(p.pvDir, options.PVC.Namespace, options.PVC.Name)
+	if _, err := os.Stat(path); err == nil {
+		// A retained volume still holds this claim's directory, don't hand its data to the new claim
+		path = filepath.Join(p.pvDir, options.PVC.Namespace, options.PVName)
+	}
 	klog.Infof("Provisioning volume %v to %s", options, path)
 	if err := os.MkdirAll(path, 0777); err != nil {
 		return nil, err
@@ -67,18 +128,28 @@ func (p *hostPathProvisioner) Provision(options controller.ProvisionOptions) (*c
 		return nil, err
 	}
 
+	capacity := options.PVC.Spec.Resources.Requests[core.ResourceStorage]
+	if p.quota != nil {
+		if err := p.quota.Set(path, capacity.Value()); err != nil {
+			if rerr := os.RemoveAll(path); rerr != nil {
+				klog.Warningf("Failed to remove %s: %v", path, rerr)
+			}
+			return nil, errors.Wrap(err, "setting quota")
+		}
+	}
+
 	pv := &core.PersistentVolume{
 		ObjectMeta: meta.ObjectMeta{
 			Name: options.PVName,
 			Annotations: map[string]string{
-				"hostPathProvisionerIdentity": string(p.identity),
+				annIdentity: string(p.identity),
 			},
 		},
 		Spec: core.PersistentVolumeSpec{
-			PersistentVolumeReclaimPolicy: *options.StorageClass.ReclaimPolicy,
+			PersistentVolumeReclaimPolicy: policy,
 			AccessModes:                   options.PVC.Spec.AccessModes,
 			Capacity: core.ResourceList{
-				core.ResourceStorage: options.PVC.Spec.Resources.Requests[core.ResourceStorage],
+				core.ResourceStorage: capacity,
 			},
 			PersistentVolumeSource: core.PersistentVolumeSource{
 				HostPath: &core.HostPathVolumeSource{
@@ -88,6 +159,24 @@ func (p *hostPathProvisioner) Provision(options controller.ProvisionOptions) (*c
 		},
 	}
 
+	if p.nodeName != "" {
+		hostname := p.nodeName
+		if options.SelectedNode != nil && options.SelectedNode.Labels[core.LabelHostname] != "" {
+			hostname = options.SelectedNode.Labels[core.LabelHostname]
+		}
+		pv.Spec.NodeAffinity = &core.VolumeNodeAffinity{
+			Required: &core.NodeSelector{
+				NodeSelectorTerms: []core.NodeSelectorTerm{{
+					MatchExpressions: []core.NodeSelectorRequirement{{
+						Key:      core.LabelHostname,
+						Operator: core.NodeSelectorOpIn,
+						Values:   []string{hostname},
+					}},
+				}},
+			},
+		}
+	}
+
 	return pv, nil
 }
 
@@ -95,23 +184,59 @@ func (p *hostPathProvisioner) Provision(options controller.ProvisionOptions) (*c
 // by the given PV.
 func (p *hostPathProvisioner) Delete(volume *core.PersistentVolume) error {
 	klog.Infof("Deleting volume %v", volume)
-	ann, ok := volume.Annotations["hostPathProvisionerIdentity"]
-	if !ok {
+	if _, ok := volume.Annotations[annIdentity]; !ok {
 		return errors.New("identity annotation not found on PV")
 	}
-	if ann != string(p.identity) {
+	if !p.owns(volume) {
 		return &controller.IgnoredError{Reason: "identity annotation on PV does not match ours"}
 	}
 
-	if err := os.RemoveAll(volume.Spec.PersistentVolumeSource.HostPath.Path); err != nil {
+	path := volume.Spec.PersistentVolumeSource.HostPath.Path
+	if p.quota != nil {
+		if err := p.quota.Clear(path); err != nil {
+			klog.Warningf("Failed to clear quota of %s: %v", path, err)
+		}
+	}
+	if err := os.RemoveAll(path); err != nil {
 		return errors.Wrap(err, "removing hostpath PV")
 	}
 
 	return nil
 }
 
-// StartStorageProvisioner will start storage provisioner server
-func StartStorageProvisioner(pvDir string) error {
+// owns returns whether volume was provisioned by p, or is adopted by it
+func (p *hostPathProvisioner) owns(volume *core.PersistentVolume) bool {
+	return volume.Annotations[annIdentity] == string(p.identity) || p.adopts(volume)
+}
+
+// adopts returns whether p takes over volume from the single provisioner of
+// older versions. Its volumes carry a generated identity and no node affinity,
+// and live on the primary control plane, where that provisioner was started.
+func (p *hostPathProvisioner) adopts(volume *core.PersistentVolume) bool {
+	return p.nodeName != "" && p.immediate && volume.Spec.NodeAffinity == nil
+}
+
+// reclaimPolicy returns the reclaim policy for PVs of the given StorageClass.
+// Recycle is left to the kube-controller-manager, which scrubs hostPath volumes itself.
+func reclaimPolicy(sc *storage.StorageClass) (core.PersistentVolumeReclaimPolicy, error) {
+	policy := core.PersistentVolumeReclaimDelete
+	if sc.ReclaimPolicy != nil {
+		policy = *sc.ReclaimPolicy
+	}
+	if v, ok := sc.Parameters[reclaimPolicyParam]; ok {
+		policy = core.PersistentVolumeReclaimPolicy(v)
+	}
+	switch policy {
+	case core.PersistentVolumeReclaimRetain, core.PersistentVolumeReclaimDelete, core.PersistentVolumeReclaimRecycle:
+		return policy, nil
+	}
+	return "", fmt.Errorf("unsupported reclaim policy %q of storage class %s", policy, sc.Name)
+}
+
+// StartStorageProvisioner will start storage provisioner server. When nodeName
+// is set it runs as one of a set of provisioners, one per node, each creating
+// the volumes of claims whose pods were scheduled onto its node.
+func StartStorageProvisioner(pvDir string, nodeName string) error {
 	klog.Infof("Initializing the minikube storage provisioner...")
 	config, err := rest.InClusterConfig()
 	if err != nil {
@@ -131,13 +256,55 @@ func StartStorageProvisioner(pvDir string) error {
 
 	// Create the provisioner: it implements the Provisioner interface expected by
 	// the controller
-	hostPathProvisioner := NewHostPathProvisioner(pvDir)
+	immediate := true
+	var options []func(*controller.ProvisionController) error
+	if nodeName != "" {
+		nodes, err := clientset.CoreV1().Nodes().List(meta.ListOptions{})
+		if err != nil {
+			return errors.Wrap(err, "list nodes")
+		}
+		immediate = isPrimary(nodeName, nodes.Items)
+		// Every node runs its own provisioner, electing a leader would leave all but one idle
+		options = append(options, controller.LeaderElection(false))
+	}
+	hostPathProvisioner := newHostPathProvisioner(pvDir, nodeName, immediate)
 
 	// Start the provision controller which will dynamically provision hostPath
 	// PVs
-	pc := controller.NewProvisionController(clientset, provisionerName, hostPathProvisioner, serverVersion.GitVersion)
+	pc := controller.NewProvisionController(clientset, provisionerName, hostPathProvisioner, serverVersion.GitVersion, options...)
+
+	// Start the resizer, which grows PVs when their claims ask for more storage
+	r := &resizer{client: clientset, owns: hostPathProvisioner.owns, quota: hostPathProvisioner.quota}
+	r.Run(wait.NeverStop)
 
 	klog.Info("Storage provisioner initialized, now starting service!")
 	pc.Run(wait.NeverStop)
 	return nil
 }
+
+// isPrimary returns whether nodeName is the primary control plane, the oldest
+// of the control plane nodes: the others join the cluster it was created on
+func isPrimary(nodeName string, nodes []core.Node) bool {
+	var primary *core.Node
+	for i := range nodes {
+		n := &nodes[i]
+		if !isControlPlane(n) {
+			continue
+		}
+		if primary == nil || n.CreationTimestamp.Before(&primary.CreationTimestamp) ||
+			(n.CreationTimestamp.Equal(&primary.CreationTimestamp) && n.Name < primary.Name) {
+			primary = n
+		}
+	}
+	return primary != nil && primary.Name == nodeName
+}
+
+// isControlPlane returns whether node runs the control plane
+func isControlPlane(node *core.Node) bool {
+	for _, l := range []string{"node-role.kubernetes.io/master", "node-role.kubernetes.io/control-plane"} {
+		if _, ok := node.Labels[l]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	core "k8s.io/api/core/v1"
+	storage "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/sig-storage-lib-external-provisioner/v5/controller"
+)
+
+type fakeQuota map[string]int64
+
+func (q fakeQuota) Set(dir string, bytes int64) error {
+	q[dir] = bytes
+	return nil
+}
+
+func (q fakeQuota) Clear(dir string) error {
+	delete(q, dir)
+	return nil
+}
+
+func TestReclaimPolicy(t *testing.T) {
+	retain := core.PersistentVolumeReclaimRetain
+	tests := []struct {
+		description string
+		sc          storage.StorageClass
+		want        core.PersistentVolumeReclaimPolicy
+		wantErr     bool
+	}{
+		{description: "default", want: core.PersistentVolumeReclaimDelete},
+		{description: "field", sc: storage.StorageClass{ReclaimPolicy: &retain}, want: retain},
+		{description: "parameter", sc: storage.StorageClass{ReclaimPolicy: &retain, Parameters: map[string]string{reclaimPolicyParam: "Recycle"}}, want: core.PersistentVolumeReclaimRecycle},
+		{description: "invalid", sc: storage.StorageClass{Parameters: map[string]string{reclaimPolicyParam: "Shred"}}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := reclaimPolicy(&tc.sc)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("reclaimPolicy() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("reclaimPolicy() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestProvision(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hostpath")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	q := fakeQuota{}
+	p := &hostPathProvisioner{pvDir: dir, identity: "test", quota: q}
+	retain := core.PersistentVolumeReclaimRetain
+	options := func(pvName string) controller.ProvisionOptions {
+		return controller.ProvisionOptions{
+			StorageClass: &storage.StorageClass{ReclaimPolicy: &retain},
+			PVName:       pvName,
+			PVC: &core.PersistentVolumeClaim{
+				ObjectMeta: meta.ObjectMeta{Name: "claim", Namespace: "default"},
+				Spec: core.PersistentVolumeClaimSpec{
+					Resources: core.ResourceRequirements{
+						Requests: core.ResourceList{core.ResourceStorage: resource.MustParse("1Mi")},
+					},
+				},
+			},
+		}
+	}
+
+	pv, err := p.Provision(options("pvc-1"))
+	if err != nil {
+		t.Fatalf("Provision: %v", err)
+	}
+	first := pv.Spec.HostPath.Path
+	if want := filepath.Join(dir, "default", "claim"); first != want {
+		t.Errorf("path = %s, want %s", first, want)
+	}
+	if pv.Spec.PersistentVolumeReclaimPolicy != retain {
+		t.Errorf("reclaim policy = %s, want %s", pv.Spec.PersistentVolumeReclaimPolicy, retain)
+	}
+	if q[first] != 1<<20 {
+		t.Errorf("quota = %d, want %d", q[first], 1<<20)
+	}
+
+	// The retained volume keeps its directory, a new claim of the same name must not share it
+	pv, err = p.Provision(options("pvc-2"))
+	if err != nil {
+		t.Fatalf("Provision: %v", err)
+	}
+	if want := filepath.Join(dir, "default", "pvc-2"); pv.Spec.HostPath.Path != want {
+		t.Errorf("path = %s, want %s", pv.Spec.HostPath.Path, want)
+	}
+
+	if err := p.Delete(pv); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := q[pv.Spec.HostPath.Path]; ok {
+		t.Errorf("quota of deleted volume was not cleared")
+	}
+	if _, err := os.Stat(pv.Spec.HostPath.Path); !os.IsNotExist(err) {
+		t.Errorf("volume directory was not removed: %v", err)
+	}
+}
+
+func TestShouldProvision(t *testing.T) {
+	claim := func(node string) *core.PersistentVolumeClaim {
+		c := &core.PersistentVolumeClaim{}
+		if node != "" {
+			c.Annotations = map[string]string{annSelectedNode: node}
+		}
+		return c
+	}
+	tests := []struct {
+		description string
+		p           hostPathProvisioner
+		claim       *core.PersistentVolumeClaim
+		want        bool
+	}{
+		{description: "single provisioner", p: hostPathProvisioner{}, claim: claim("m02"), want: true},
+		{description: "own node", p: hostPathProvisioner{nodeName: "m02"}, claim: claim("m02"), want: true},
+		{description: "other node", p: hostPathProvisioner{nodeName: "m02", immediate: true}, claim: claim("m03"), want: false},
+		{description: "immediate", p: hostPathProvisioner{nodeName: "minikube", immediate: true}, claim: claim(""), want: true},
+		{description: "immediate elsewhere", p: hostPathProvisioner{nodeName: "m02"}, claim: claim(""), want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := tc.p.ShouldProvision(tc.claim); got != tc.want {
+				t.Errorf("ShouldProvision() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOwns(t *testing.T) {
+	volume := func(identity string, pinned bool) *core.PersistentVolume {
+		pv := &core.PersistentVolume{ObjectMeta: meta.ObjectMeta{Annotations: map[string]string{annIdentity: identity}}}
+		if pinned {
+			pv.Spec.NodeAffinity = &core.VolumeNodeAffinity{}
+		}
+		return pv
+	}
+	primary := hostPathProvisioner{identity: "minikube", nodeName: "minikube", immediate: true}
+	worker := hostPathProvisioner{identity: "m02", nodeName: "m02"}
+	tests := []struct {
+		description string
+		p           hostPathProvisioner
+		volume      *core.PersistentVolume
+		want        bool
+	}{
+		{description: "own", p: worker, volume: volume("m02", true), want: true},
+		{description: "other node", p: worker, volume: volume("minikube", true), want: false},
+		{description: "legacy on primary", p: primary, volume: volume("0f3b6d1c", false), want: true},
+		{description: "legacy elsewhere", p: worker, volume: volume("0f3b6d1c", false), want: false},
+		{description: "pinned elsewhere", p: primary, volume: volume("m02", true), want: false},
+		{description: "single provisioner", p: hostPathProvisioner{identity: "0f3b6d1c", immediate: true}, volume: volume("6a2e8c4f", false), want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			if got := tc.p.owns(tc.volume); got != tc.want {
+				t.Errorf("owns() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIsPrimary(t *testing.T) {
+	node := func(name string, created int64, controlPlane bool) core.Node {
+		n := core.Node{ObjectMeta: meta.ObjectMeta{Name: name, CreationTimestamp: meta.Unix(created, 0)}}
+		if controlPlane {
+			n.Labels = map[string]string{"node-role.kubernetes.io/master": ""}
+		}
+		return n
+	}
+	nodes := []core.Node{node("minikube-m03", 30, true), node("minikube", 10, true), node("minikube-m02", 20, false)}
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "minikube", want: true},
+		{name: "minikube-m02", want: false},
+		{name: "minikube-m03", want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isPrimary(tc.name, nodes); got != tc.want {
+				t.Errorf("isPrimary(%q) = %v, want %v", tc.name, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestProvisionNodeAffinity(t *testing.T) {
+	dir, err := ioutil.TempDir("", "hostpath")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	p := newHostPathProvisioner(dir, "m02", false)
+	pv, err := p.Provision(controller.ProvisionOptions{
+		StorageClass: &storage.StorageClass{},
+		PVName:       "pvc-1",
+		PVC:          &core.PersistentVolumeClaim{ObjectMeta: meta.ObjectMeta{Name: "claim", Namespace: "default"}},
+		SelectedNode: &core.Node{ObjectMeta: meta.ObjectMeta{Name: "m02", Labels: map[string]string{core.LabelHostname: "minikube-m02"}}},
+	})
+	if err != nil {
+		t.Fatalf("Provision: %v", err)
+	}
+	if pv.Annotations[annIdentity] != "m02" {
+		t.Errorf("identity = %q, want %q", pv.Annotations[annIdentity], "m02")
+	}
+	if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+		t.Fatalf("volume has no node affinity")
+	}
+	e := pv.Spec.NodeAffinity.Required.NodeSelectorTerms[0].MatchExpressions[0]
+	if e.Key != core.LabelHostname || len(e.Values) != 1 || e.Values[0] != "minikube-m02" {
+		t.Errorf("node affinity = %+v, want %s in [minikube-m02]", e, core.LabelHostname)
+	}
+}
+
+func TestResize(t *testing.T) {
+	pv := &core.PersistentVolume{
+		ObjectMeta: meta.ObjectMeta{
+			Name:        "pvc-1",
+			Annotations: map[string]string{annProvisionedBy: provisionerName, annIdentity: "m02"},
+		},
+		Spec: core.PersistentVolumeSpec{
+			Capacity: core.ResourceList{core.ResourceStorage: resource.MustParse("1Gi")},
+			PersistentVolumeSource: core.PersistentVolumeSource{
+				HostPath: &core.HostPathVolumeSource{Path: "/tmp/hostpath-provisioner/default/claim"},
+			},
+		},
+	}
+	pvc := &core.PersistentVolumeClaim{
+		ObjectMeta: meta.ObjectMeta{Name: "claim", Namespace: "default"},
+		Spec: core.PersistentVolumeClaimSpec{
+			VolumeName: "pvc-1",
+			Resources: core.ResourceRequirements{
+				Requests: core.ResourceList{core.ResourceStorage: resource.MustParse("2Gi")},
+			},
+		},
+		Status: core.PersistentVolumeClaimStatus{
+			Phase:      core.ClaimBound,
+			Capacity:   core.ResourceList{core.ResourceStorage: resource.MustParse("1Gi")},
+			Conditions: []core.PersistentVolumeClaimCondition{{Type: core.PersistentVolumeClaimResizing}},
+		},
+	}
+	client := fake.NewSimpleClientset(pv, pvc)
+	q := fakeQuota{}
+	p := &hostPathProvisioner{identity: "m02", nodeName: "m02"}
+	r := &resizer{client: client, owns: p.owns, quota: q}
+	if err := r.resize(pvc); err != nil {
+		t.Fatalf("resize: %v", err)
+	}
+
+	want := resource.MustParse("2Gi")
+	if q[pv.Spec.HostPath.Path] != want.Value() {
+		t.Errorf("quota = %d, want %d", q[pv.Spec.HostPath.Path], want.Value())
+	}
+	gotPV, err := client.CoreV1().PersistentVolumes().Get("pvc-1", meta.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := gotPV.Spec.Capacity[core.ResourceStorage]; c.Cmp(want) != 0 {
+		t.Errorf("volume capacity = %s, want %s", c.String(), want.String())
+	}
+	gotPVC, err := client.CoreV1().PersistentVolumeClaims("default").Get("claim", meta.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := gotPVC.Status.Capacity[core.ResourceStorage]; c.Cmp(want) != 0 {
+		t.Errorf("claim capacity = %s, want %s", c.String(), want.String())
+	}
+	if len(gotPVC.Status.Conditions) != 0 {
+		t.Errorf("claim conditions = %v, want none", gotPVC.Status.Conditions)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/codes"
+	exporttrace "go.opentelemetry.io/otel/sdk/export/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"k8s.io/minikube/pkg/minikube/localpath"
+)
+
+// FileEnvVar is the env variable holding the path of the file the file tracer appends spans to
+const FileEnvVar = "MINIKUBE_TRACE_FILE"
+
+// fileSpan is a span, as written by the file tracer
+type fileSpan struct {
+	TraceID      string                 `json:"traceId"`
+	SpanID       string                 `json:"spanId"`
+	ParentSpanID string                 `json:"parentSpanId,omitempty"`
+	Name         string                 `json:"name"`
+	StartTime    time.Time              `json:"startTime"`
+	EndTime      time.Time              `json:"endTime"`
+	DurationMS   int64                  `json:"durationMs"`
+	Attributes   map[string]interface{} `json:"attributes,omitempty"`
+	Status       string                 `json:"status,omitempty"`
+}
+
+// initFileTracer returns a tracer which appends spans to a file, one JSON object per line
+func initFileTracer() (*spanTracer, error) {
+	path := os.Getenv(FileEnvVar)
+	if path == "" {
+		path = localpath.MakeMiniPath("logs", "trace.jsonl")
+	}
+	e, err := newFileExporter(path)
+	if err != nil {
+		return nil, err
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.AlwaysSample()}),
+		sdktrace.WithSyncer(e),
+		sdktrace.WithResource(serviceResource()),
+	)
+	return newSpanTracer(tp.Tracer(parentSpanName), func() {
+		if err := tp.Shutdown(context.Background()); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write traces to %s: %v\n", path, err)
+		}
+	}), nil
+}
+
+// fileExporter writes spans as JSON lines
+type fileExporter struct {
+	mu  sync.Mutex
+	f   *os.File
+	enc *json.Encoder
+}
+
+func newFileExporter(path string) (*fileExporter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, errors.Wrapf(err, "creating %s", filepath.Dir(path))
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening %s", path)
+	}
+	return &fileExporter{f: f, enc: json.NewEncoder(f)}, nil
+}
+
+// ExportSpans appends spans to the file
+func (e *fileExporter) ExportSpans(ctx context.Context, spans []*exporttrace.SpanSnapshot) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, s := range spans {
+		if err := e.enc.Encode(toFileSpan(s)); err != nil {
+			return errors.Wrap(err, "writing span")
+		}
+	}
+	return nil
+}
+
+// Shutdown closes the file
+func (e *fileExporter) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.f.Close()
+}
+
+func toFileSpan(s *exporttrace.SpanSnapshot) fileSpan {
+	fs := fileSpan{
+		TraceID:    s.SpanContext.TraceID.String(),
+		SpanID:     s.SpanContext.SpanID.String(),
+		Name:       s.Name,
+		StartTime:  s.StartTime,
+		EndTime:    s.EndTime,
+		DurationMS: s.EndTime.Sub(s.StartTime).Milliseconds(),
+	}
+	if s.ParentSpanID.IsValid() {
+		fs.ParentSpanID = s.ParentSpanID.String()
+	}
+	if len(s.Attributes) > 0 {
+		fs.Attributes = map[string]interface{}{}
+		for _, kv := range s.Attributes {
+			fs.Attributes[string(kv.Key)] = kv.Value.AsInterface()
+		}
+	}
+	if s.StatusCode != codes.Unset {
+		fs.Status = s.StatusCode.String()
+	}
+	return fs
+}
@@ -17,14 +17,11 @@ limitations under the License.
 package trace
 
 import (
-	"context"
 	"fmt"
 	"os"
 
 	"go.opentelemetry.io/otel"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	"go.opentelemetry.io/otel/trace"
-	"k8s.io/klog/v2"
 
 	texporter "github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/trace"
 	"github.com/pkg/errors"
@@ -38,41 +35,8 @@ const (
 	parentSpanName = "minikube start"
 )
 
-type gcpTracer struct {
-	projectID string
-	parentCtx context.Context
-	trace.Tracer
-	spans   map[string]trace.Span
-	cleanup func()
-}
-
-// StartSpan starts a span for the next step of
-// `minikube start` via the GCP tracer
-func (t *gcpTracer) StartSpan(name string) {
-	_, span := t.Tracer.Start(t.parentCtx, name)
-	t.spans[name] = span
-}
-
-// EndSpan ends the most recent span, indicating
-// that one step of `minikube start` has completed
-func (t *gcpTracer) EndSpan(name string) {
-	span, ok := t.spans[name]
-	if !ok {
-		klog.Warningf("cannot end span %s as it was never started", name)
-		return
-	}
-	span.End()
-}
-
-func (t *gcpTracer) Cleanup() {
-	span, ok := t.spans[parentSpanName]
-	if ok {
-		span.End()
-	}
-	t.cleanup()
-}
-
-func initGCPTracer() (*gcpTracer, error) {
+// initGCPTracer returns a tracer which exports to Cloud Trace
+func initGCPTracer() (*spanTracer, error) {
 	projectID := os.Getenv(ProjectEnvVar)
 	if projectID == "" {
 		return nil, fmt.Errorf("GCP tracer requires a valid GCP project id set via the %s env variable", ProjectEnvVar)
@@ -90,16 +54,5 @@ func initGCPTracer() (*gcpTracer, error) {
 		return nil, errors.Wrap(err, "installing pipeline")
 	}
 
-	t := otel.Tracer(parentSpanName)
-
-	ctx, span := t.Start(context.Background(), parentSpanName)
-	return &gcpTracer{
-		projectID: projectID,
-		parentCtx: ctx,
-		cleanup:   flush,
-		Tracer:    t,
-		spans: map[string]trace.Span{
-			parentSpanName: span,
-		},
-	}, nil
+	return newSpanTracer(otel.Tracer(parentSpanName), flush), nil
 }
@@ -0,0 +1,145 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlphttp"
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/credentials"
+
+	"k8s.io/minikube/pkg/version"
+)
+
+const (
+	// OTLPEndpointEnvVar is the env variable holding the address of the OTLP collector
+	OTLPEndpointEnvVar = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	// OTLPProtocolEnvVar is the env variable selecting the OTLP transport, either grpc or http/protobuf
+	OTLPProtocolEnvVar = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	// OTLPHeadersEnvVar is the env variable holding extra headers to send, formatted as key1=value1,key2=value2
+	OTLPHeadersEnvVar = "OTEL_EXPORTER_OTLP_HEADERS"
+
+	otlpGRPC            = "grpc"
+	otlpHTTP            = "http/protobuf"
+	defaultOTLPGRPCAddr = "localhost:4317"
+	defaultOTLPHTTPAddr = "localhost:4318"
+	otlpExportTimeout   = 10 * time.Second
+)
+
+// initOTelTracer returns a tracer which exports to an OpenTelemetry collector, such as Jaeger, over OTLP
+func initOTelTracer() (*spanTracer, error) {
+	e, err := newOTLPExporter(os.Getenv(OTLPEndpointEnvVar), os.Getenv(OTLPProtocolEnvVar), os.Getenv(OTLPHeadersEnvVar))
+	if err != nil {
+		return nil, err
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithConfig(sdktrace.Config{DefaultSampler: sdktrace.AlwaysSample()}),
+		sdktrace.WithBatcher(e),
+		sdktrace.WithResource(serviceResource()),
+	)
+	return newSpanTracer(tp.Tracer(parentSpanName), func() {
+		ctx, cancel := context.WithTimeout(context.Background(), otlpExportTimeout)
+		defer cancel()
+		if err := tp.Shutdown(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to export traces: %v\n", err)
+		}
+	}), nil
+}
+
+// serviceResource identifies minikube as the source of the spans
+func serviceResource() *resource.Resource {
+	return resource.NewWithAttributes(
+		label.String("service.name", "minikube"),
+		label.String("service.version", version.GetVersion()),
+	)
+}
+
+// newOTLPExporter returns an exporter sending spans to the collector at endpoint, over either gRPC or HTTP
+func newOTLPExporter(endpoint string, protocol string, headers string) (*otlp.Exporter, error) {
+	h := map[string]string{}
+	for _, kv := range strings.Split(headers, ",") {
+		if kv = strings.TrimSpace(kv); kv == "" {
+			continue
+		}
+		s := strings.SplitN(kv, "=", 2)
+		if len(s) != 2 {
+			return nil, fmt.Errorf("invalid %s header %q, expected key=value", OTLPHeadersEnvVar, kv)
+		}
+		h[strings.TrimSpace(s[0])] = strings.TrimSpace(s[1])
+	}
+
+	driver, err := otlpDriver(endpoint, protocol, h)
+	if err != nil {
+		return nil, err
+	}
+	// the gRPC connection is established in the background, so this does not wait for the collector
+	e, err := otlp.NewExporter(context.Background(), driver)
+	if err != nil {
+		return nil, errors.Wrapf(err, "start %s exporter", protocol)
+	}
+	return e, nil
+}
+
+// otlpDriver returns the driver of protocol, endpoint being an address with an optional
+// http:// or https:// scheme, and for HTTP a path the traces path is appended to
+func otlpDriver(endpoint string, protocol string, headers map[string]string) (otlp.ProtocolDriver, error) {
+	secure := strings.HasPrefix(endpoint, "https://")
+	if i := strings.Index(endpoint, "://"); i >= 0 {
+		endpoint = endpoint[i+len("://"):]
+	}
+	endpoint = strings.TrimSuffix(endpoint, "/")
+
+	switch protocol {
+	case otlpHTTP, "":
+		if endpoint == "" {
+			endpoint = defaultOTLPHTTPAddr
+		}
+		path := otlphttp.DefaultTracesPath
+		if i := strings.Index(endpoint, "/"); i >= 0 {
+			endpoint, path = endpoint[:i], endpoint[i:]+path
+		}
+		opts := []otlphttp.Option{otlphttp.WithEndpoint(endpoint), otlphttp.WithTracesURLPath(path), otlphttp.WithHeaders(headers)}
+		if !secure {
+			opts = append(opts, otlphttp.WithInsecure())
+		}
+		return otlphttp.NewDriver(opts...), nil
+	case otlpGRPC:
+		if endpoint == "" {
+			endpoint = defaultOTLPGRPCAddr
+		}
+		opts := []otlpgrpc.Option{otlpgrpc.WithEndpoint(endpoint), otlpgrpc.WithHeaders(headers)}
+		if secure {
+			opts = append(opts, otlpgrpc.WithTLSCredentials(credentials.NewTLS(&tls.Config{})))
+		} else {
+			opts = append(opts, otlpgrpc.WithInsecure())
+		}
+		return otlpgrpc.NewDriver(opts...), nil
+	default:
+		return nil, fmt.Errorf("invalid %s %q, valid protocols include: [%s, %s]", OTLPProtocolEnvVar, protocol, otlpGRPC, otlpHTTP)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlphttp"
+	"go.opentelemetry.io/otel/label"
+	exporttrace "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// field returns the first field num of a protobuf message
+func field(t *testing.T, b []byte, num protowire.Number) []byte {
+	t.Helper()
+	for len(b) > 0 {
+		n, typ, l := protowire.ConsumeTag(b)
+		if l < 0 {
+			t.Fatalf("invalid tag")
+		}
+		b = b[l:]
+		var v []byte
+		switch typ {
+		case protowire.BytesType:
+			v, l = protowire.ConsumeBytes(b)
+		default:
+			l = protowire.ConsumeFieldValue(n, typ, b)
+			v = b[:l]
+		}
+		if l < 0 {
+			t.Fatalf("invalid field %d", n)
+		}
+		if n == num {
+			return v
+		}
+		b = b[l:]
+	}
+	return nil
+}
+
+func TestOTLPExport(t *testing.T) {
+	start := time.Unix(1614000000, 0)
+	span := &exporttrace.SpanSnapshot{
+		SpanContext: trace.SpanContext{
+			TraceID: trace.TraceID{1, 2, 3},
+			SpanID:  trace.SpanID{4, 5, 6},
+		},
+		ParentSpanID: trace.SpanID{7, 8, 9},
+		Name:         "Starting Node",
+		StartTime:    start,
+		EndTime:      start.Add(time.Second),
+		Attributes:   []label.KeyValue{label.String("minikube.driver", "docker")},
+		Resource:     serviceResource(),
+	}
+
+	var body []byte
+	var headers http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != otlphttp.DefaultTracesPath {
+			t.Errorf("path = %q, want %q", r.URL.Path, otlphttp.DefaultTracesPath)
+		}
+		headers = r.Header
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer ts.Close()
+
+	e, err := newOTLPExporter(ts.URL, "", "x-token=secret")
+	if err != nil {
+		t.Fatalf("newOTLPExporter: %v", err)
+	}
+	if err := e.ExportSpans(context.Background(), []*exporttrace.SpanSnapshot{span}); err != nil {
+		t.Fatalf("ExportSpans: %v", err)
+	}
+	if ct := headers.Get("Content-Type"); ct != "application/x-protobuf" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if tok := headers.Get("x-token"); tok != "secret" {
+		t.Errorf("x-token = %q, want secret", tok)
+	}
+
+	// ExportTraceServiceRequest.resource_spans.instrumentation_library_spans.spans
+	s := field(t, field(t, field(t, body, 1), 2), 2)
+	if got := string(field(t, s, 5)); got != "Starting Node" {
+		t.Errorf("name = %q", got)
+	}
+	if got := field(t, s, 1); string(got) != string(span.SpanContext.TraceID[:]) {
+		t.Errorf("trace id = %x", got)
+	}
+	if got := field(t, s, 4); string(got) != string(span.ParentSpanID[:]) {
+		t.Errorf("parent span id = %x", got)
+	}
+	end, _ := protowire.ConsumeFixed64(field(t, s, 8))
+	if end != uint64(span.EndTime.UnixNano()) {
+		t.Errorf("end time = %d", end)
+	}
+	kv := field(t, s, 9)
+	if k, v := string(field(t, kv, 1)), string(field(t, field(t, kv, 2), 1)); k != "minikube.driver" || v != "docker" {
+		t.Errorf("attribute = %s=%s", k, v)
+	}
+}
+
+// grpcExportMethod is the method the exporter calls on the collector
+const grpcExportMethod = "/opentelemetry.proto.collector.trace.v1.TraceService/Export"
+
+// serverCodec lets a test server receive the raw requests of the exporter
+type serverCodec struct{}
+
+func (serverCodec) Marshal(v interface{}) ([]byte, error) {
+	b, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return b, nil
+}
+
+func (serverCodec) Unmarshal(data []byte, v interface{}) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	*b = data
+	return nil
+}
+
+func (serverCodec) String() string {
+	return "proto"
+}
+
+func TestOTLPExportGRPC(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	received := make(chan string, 1)
+	srv := grpc.NewServer(grpc.CustomCodec(serverCodec{}), grpc.UnknownServiceHandler(func(_ interface{}, stream grpc.ServerStream) error {
+		method, _ := grpc.MethodFromServerStream(stream)
+		var req []byte
+		if err := stream.RecvMsg(&req); err != nil {
+			return err
+		}
+		received <- method
+		return stream.SendMsg([]byte{})
+	}))
+	go func() {
+		_ = srv.Serve(l)
+	}()
+	defer srv.Stop()
+
+	e, err := newOTLPExporter("http://"+l.Addr().String(), otlpGRPC, "")
+	if err != nil {
+		t.Fatalf("newOTLPExporter: %v", err)
+	}
+	defer e.Shutdown(context.Background())
+	span := &exporttrace.SpanSnapshot{Name: "Starting Node", SpanContext: trace.SpanContext{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{2}}}
+	if err := e.ExportSpans(context.Background(), []*exporttrace.SpanSnapshot{span}); err != nil {
+		t.Fatalf("ExportSpans: %v", err)
+	}
+	if method := <-received; method != grpcExportMethod {
+		t.Errorf("method = %q, want %q", method, grpcExportMethod)
+	}
+}
+
+func TestOTLPExporterInvalid(t *testing.T) {
+	if _, err := newOTLPExporter("", "thrift", ""); err == nil {
+		t.Errorf("expected an error for an unknown protocol")
+	}
+	if _, err := newOTLPExporter("", "", "novalue"); err == nil {
+		t.Errorf("expected an error for an invalid header")
+	}
+}
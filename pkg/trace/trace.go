@@ -29,6 +29,9 @@ var (
 type minikubeTracer interface {
 	StartSpan(string)
 	EndSpan(string)
+	StartNodeSpan(string, map[string]string)
+	EndNodeSpan(string)
+	SetAttributes(map[string]string)
 	Cleanup()
 }
 
@@ -46,10 +49,14 @@ func getTracer(t string) (minikubeTracer, error) {
 	switch t {
 	case "gcp":
 		return initGCPTracer()
+	case "otel":
+		return initOTelTracer()
+	case "file":
+		return initFileTracer()
 	case "":
 		return nil, nil
 	}
-	return nil, fmt.Errorf("%s is not a valid tracer, valid tracers include: [gcp]", t)
+	return nil, fmt.Errorf("%s is not a valid tracer, valid tracers include: [gcp, otel, file]", t)
 }
 
 // StartSpan starts a span with the given name
@@ -68,6 +75,31 @@ func EndSpan(name string) {
 	tracer.EndSpan(name)
 }
 
+// StartNodeSpan starts a span for a node, which the spans
+// of all steps until EndNodeSpan are nested under
+func StartNodeSpan(name string, attributes map[string]string) {
+	if tracer == nil {
+		return
+	}
+	tracer.StartNodeSpan(name, attributes)
+}
+
+// EndNodeSpan ends the span of a node, and any steps of it which are still running
+func EndNodeSpan(name string) {
+	if tracer == nil {
+		return
+	}
+	tracer.EndNodeSpan(name)
+}
+
+// SetAttributes sets attributes, such as the driver, on the span of the whole command
+func SetAttributes(attributes map[string]string) {
+	if tracer == nil {
+		return
+	}
+	tracer.SetAttributes(attributes)
+}
+
 // Cleanup is responsible for trace related cleanup,
 // such as flushing all data
 func Cleanup() {
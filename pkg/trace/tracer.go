@@ -0,0 +1,145 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"context"
+	"sort"
+
+	"go.opentelemetry.io/otel/label"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/klog/v2"
+)
+
+// stepSpan is the span of a step, and the node it was started for, if any
+type stepSpan struct {
+	trace.Span
+	node string
+}
+
+// spanTracer records the steps of `minikube start` as spans of an OpenTelemetry tracer.
+// Steps are nested under the span of the node being started, or the span of the whole command.
+type spanTracer struct {
+	trace.Tracer
+	parentCtx context.Context
+	parent    trace.Span
+
+	node     string
+	nodeCtx  context.Context
+	nodeSpan trace.Span
+
+	spans map[string]stepSpan
+	// ended are steps which were ended along with their node
+	ended   map[string]bool
+	cleanup func()
+}
+
+func newSpanTracer(t trace.Tracer, cleanup func()) *spanTracer {
+	ctx, span := t.Start(context.Background(), parentSpanName)
+	return &spanTracer{
+		Tracer:    t,
+		parentCtx: ctx,
+		parent:    span,
+		spans:     map[string]stepSpan{},
+		ended:     map[string]bool{},
+		cleanup:   cleanup,
+	}
+}
+
+// StartSpan starts a span for the next step of `minikube start`
+func (t *spanTracer) StartSpan(name string) {
+	ctx := t.parentCtx
+	if t.nodeSpan != nil {
+		ctx = t.nodeCtx
+	}
+	_, span := t.Tracer.Start(ctx, name)
+	t.spans[name] = stepSpan{Span: span, node: t.node}
+	delete(t.ended, name)
+}
+
+// EndSpan ends the most recent span, indicating
+// that one step of `minikube start` has completed
+func (t *spanTracer) EndSpan(name string) {
+	span, ok := t.spans[name]
+	if !ok {
+		if !t.ended[name] {
+			klog.Warningf("cannot end span %s as it was never started", name)
+		}
+		return
+	}
+	span.End()
+	delete(t.spans, name)
+}
+
+// StartNodeSpan starts a span for a node, ending the span of the previous node
+func (t *spanTracer) StartNodeSpan(name string, attributes map[string]string) {
+	if t.nodeSpan != nil {
+		t.EndNodeSpan(t.node)
+	}
+	attrs := append([]label.KeyValue{label.String("minikube.node", name)}, labels(attributes)...)
+	t.nodeCtx, t.nodeSpan = t.Tracer.Start(t.parentCtx, "node "+name, trace.WithAttributes(attrs...))
+	t.node = name
+}
+
+// EndNodeSpan ends the span of a node, along with the spans of its steps
+func (t *spanTracer) EndNodeSpan(name string) {
+	if t.nodeSpan == nil || t.node != name {
+		return
+	}
+	for n, s := range t.spans {
+		if s.node == name {
+			s.End()
+			delete(t.spans, n)
+			t.ended[n] = true
+		}
+	}
+	t.nodeSpan.End()
+	t.nodeSpan = nil
+	t.node = ""
+}
+
+// SetAttributes sets attributes on the span of the whole command
+func (t *spanTracer) SetAttributes(attributes map[string]string) {
+	t.parent.SetAttributes(labels(attributes)...)
+}
+
+// Cleanup ends all open spans and flushes them
+func (t *spanTracer) Cleanup() {
+	for n, s := range t.spans {
+		s.End()
+		delete(t.spans, n)
+	}
+	if t.nodeSpan != nil {
+		t.EndNodeSpan(t.node)
+	}
+	t.parent.End()
+	t.cleanup()
+}
+
+// labels converts attributes to labels, sorted by key
+func labels(attributes map[string]string) []label.KeyValue {
+	keys := []string{}
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	kvs := []label.KeyValue{}
+	for _, k := range keys {
+		kvs = append(kvs, label.String(k, attributes[k]))
+	}
+	return kvs
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2021 The Kubernetes Authors All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileTracerNesting(t *testing.T) {
+	dir, err := ioutil.TempDir("", "trace")
+	if err != nil {
+		t.Fatalf("tempdir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "trace.jsonl")
+	os.Setenv(FileEnvVar, path)
+	defer os.Unsetenv(FileEnvVar)
+
+	tr, err := initFileTracer()
+	if err != nil {
+		t.Fatalf("initFileTracer: %v", err)
+	}
+	tr.SetAttributes(map[string]string{"minikube.driver": "docker"})
+	tr.StartSpan("Selecting Driver")
+	tr.EndSpan("Selecting Driver")
+	tr.StartNodeSpan("minikube", map[string]string{"minikube.control_plane": "true"})
+	tr.StartSpan("Starting Node")
+	tr.EndSpan("Starting Node")
+	tr.StartSpan("Enabling Addons")
+	// ends the open step along with its node
+	tr.EndNodeSpan("minikube")
+	tr.EndSpan("Enabling Addons")
+	tr.StartNodeSpan("minikube-m02", nil)
+	tr.StartSpan("Starting Node")
+	tr.Cleanup()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+
+	spans := map[string][]fileSpan{}
+	ids := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var s fileSpan
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			t.Fatalf("unmarshal %q: %v", scanner.Text(), err)
+		}
+		spans[s.Name] = append(spans[s.Name], s)
+		ids[s.SpanID] = s.Name
+	}
+
+	parent := func(s fileSpan) string {
+		return ids[s.ParentSpanID]
+	}
+	root := spans[parentSpanName]
+	if len(root) != 1 || root[0].ParentSpanID != "" || root[0].Attributes["minikube.driver"] != "docker" {
+		t.Fatalf("unexpected root span: %+v", root)
+	}
+	if p := parent(spans["Selecting Driver"][0]); p != parentSpanName {
+		t.Errorf("Selecting Driver parent = %q, want %q", p, parentSpanName)
+	}
+	if s := spans["node minikube"]; len(s) != 1 || parent(s[0]) != parentSpanName || s[0].Attributes["minikube.control_plane"] != "true" {
+		t.Errorf("unexpected node span: %+v", s)
+	}
+	nodes := []string{}
+	for _, s := range spans["Starting Node"] {
+		nodes = append(nodes, parent(s))
+	}
+	if len(nodes) != 2 || nodes[0] != "node minikube" || nodes[1] != "node minikube-m02" {
+		t.Errorf("Starting Node parents = %v, want [node minikube node minikube-m02]", nodes)
+	}
+	if s := spans["Enabling Addons"]; len(s) != 1 || parent(s[0]) != "node minikube" {
+		t.Errorf("unexpected Enabling Addons span: %+v", s)
+	}
+}
@@ -18,9 +18,11 @@ limitations under the License.
 package retry
 
 import (
+	"context"
 	"time"
 
 	"github.com/cenkalti/backoff"
+	"github.com/pkg/errors"
 
 	"k8s.io/klog/v2"
 )
@@ -41,6 +43,16 @@ func Local(callback func() error, maxTime time.Duration) error {
 	return backoff.RetryNotify(callback, b, notify)
 }
 
+// LocalContext is Local, which stops retrying once ctx is done
+func LocalContext(ctx context.Context, callback func() error, maxTime time.Duration) error {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = 250 * time.Millisecond
+	b.RandomizationFactor = 0.25
+	b.Multiplier = 1.25
+	b.MaxElapsedTime = maxTime
+	return withContext(ctx, backoff.RetryNotify(callback, backoff.WithContext(b, ctx), notify))
+}
+
 // Expo is exponential backoff retry.
 // initInterval is the initial waiting time to start with.
 // maxTime is the max time allowed to spend on the all the retries.
@@ -60,6 +72,42 @@ func Expo(callback func() error, initInterval time.Duration, maxTime time.Durati
 	return backoff.RetryNotify(callback, bm, notify)
 }
 
+// ExpoContext is Expo, which stops retrying once ctx is done
+func ExpoContext(ctx context.Context, callback func() error, initInterval time.Duration, maxTime time.Duration, maxRetries ...uint64) error {
+	maxRetry := uint64(defaultMaxRetries) // max number of times to retry
+	if maxRetries != nil {
+		maxRetry = maxRetries[0]
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = maxTime
+	b.InitialInterval = initInterval
+	b.RandomizationFactor = 0.5
+	b.Multiplier = 1.5
+	bm := backoff.WithMaxRetries(b, maxRetry)
+	return withContext(ctx, backoff.RetryNotify(callback, backoff.WithContext(bm, ctx), notify))
+}
+
+// Sleep pauses for d, or until ctx is done, in which case it returns the error of ctx
+func Sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// withContext returns the error of ctx if it is done, so that callers can tell a cancellation from a failure
+func withContext(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil {
+		return errors.Wrapf(ctx.Err(), "last error: %v", err)
+	}
+	return err
+}
+
 // RetriableError is an error that can be tried again
 type RetriableError struct {
 	Err error
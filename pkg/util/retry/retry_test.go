@@ -17,8 +17,10 @@ limitations under the License.
 package retry
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 )
 
 // Returns a function that will return n errors, then return successfully forever.
@@ -51,3 +53,43 @@ func TestErrorGenerator(t *testing.T) {
 		t.Fatalf("Error should not have been thrown this call!")
 	}
 }
+
+func TestExpoContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	fail := func() error {
+		calls++
+		if calls == 2 {
+			cancel()
+		}
+		return errors.New("Error")
+	}
+
+	start := time.Now()
+	err := ExpoContext(ctx, fail, 10*time.Millisecond, time.Minute)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ExpoContext() = %v, want context.Canceled", err)
+	}
+	if calls != 2 {
+		t.Errorf("callback was called %d times, want 2", calls)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("ExpoContext() took %s after its context was cancelled", elapsed)
+	}
+
+	if err := ExpoContext(context.Background(), errorGenerator(2, true), time.Millisecond, time.Minute); err != nil {
+		t.Errorf("ExpoContext() = %v, want success after retries", err)
+	}
+}
+
+func TestSleep(t *testing.T) {
+	if err := Sleep(context.Background(), time.Millisecond); err != nil {
+		t.Errorf("Sleep() = %v, want nil", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := Sleep(ctx, time.Hour); !errors.Is(err, context.Canceled) {
+		t.Errorf("Sleep() = %v, want context.Canceled", err)
+	}
+}
@@ -70,6 +70,10 @@ func validatePersistentVolumeClaim(ctx context.Context, t *testing.T, profile st
 		t.Fatalf("kubectl apply pvc.yaml failed: args %q: %v", rr.Command(), err)
 	}
 
+	// create a test pod that will mount the persistent volume: the default storage class
+	// waits for the first consumer before provisioning, so the claim only binds once it exists
+	createPVTestPod(ctx, t, profile)
+
 	// make sure the pvc is Bound
 	checkStoragePhase := func() error {
 		rr, err := Run(t, exec.CommandContext(ctx, "kubectl", "--context", profile, "get", "pvc", "myclaim", "-o=json"))
@@ -91,9 +95,6 @@ func validatePersistentVolumeClaim(ctx context.Context, t *testing.T, profile st
 		t.Fatalf("failed to check storage phase: %v", err)
 	}
 
-	//	create a test pod that will mount the persistent volume
-	createPVTestPod(ctx, t, profile)
-
 	// write to the persistent volume
 	podName := "sp-pod"
 	rr, err = Run(t, exec.CommandContext(ctx, "kubectl", "--context", profile, "exec", podName, "--", "touch", "/tmp/mount/foo"))
@@ -23,9 +23,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	core "k8s.io/api/core/v1"
 	"k8s.io/minikube/pkg/minikube/config"
 )
 
@@ -46,6 +48,7 @@ func TestMultiNode(t *testing.T) {
 		}{
 			{"FreshStart2Nodes", validateMultiNodeStart},
 			{"AddNode", validateAddNodeToMultiNode},
+			{"StatefulSetVolumes", validateStatefulSetVolumes},
 			{"ProfileList", validateProfileListWithMultiNode},
 			{"StopNode", validateStopRunningNode},
 			{"StartAfterStop", validateStartNodeAfterStop},
@@ -114,6 +117,68 @@ func validateAddNodeToMultiNode(ctx context.Context, t *testing.T, profile strin
 	}
 }
 
+// validateStatefulSetVolumes makes sure volumes are provisioned on, and pinned to, the node of the pod using them
+func validateStatefulSetVolumes(ctx context.Context, t *testing.T, profile string) {
+	manifest := filepath.Join(*testdataDir, "storage-provisioner", "statefulset.yaml")
+	rr, err := Run(t, exec.CommandContext(ctx, "kubectl", "--context", profile, "apply", "-f", manifest))
+	if err != nil {
+		t.Fatalf("failed to create statefulset. args %q : %v", rr.Command(), err)
+	}
+	defer func() {
+		if rr, err := Run(t, exec.CommandContext(ctx, "kubectl", "--context", profile, "delete", "-f", manifest)); err != nil {
+			t.Logf("failed to delete statefulset. args %q : %v", rr.Command(), err)
+		}
+	}()
+
+	rr, err = Run(t, exec.CommandContext(ctx, "kubectl", "--context", profile, "rollout", "status", "statefulset/sp-sts", "--timeout=5m"))
+	if err != nil {
+		t.Fatalf("failed waiting for statefulset. args %q : %v", rr.Command(), err)
+	}
+
+	rr, err = Run(t, exec.CommandContext(ctx, "kubectl", "--context", profile, "get", "pods", "-l", "test=sp-sts", "-o", "json"))
+	if err != nil {
+		t.Fatalf("failed to get pods. args %q : %v", rr.Command(), err)
+	}
+	pods := core.PodList{}
+	if err := json.Unmarshal(rr.Stdout.Bytes(), &pods); err != nil {
+		t.Fatalf("failed to decode pods: %v", err)
+	}
+
+	rr, err = Run(t, exec.CommandContext(ctx, "kubectl", "--context", profile, "get", "pv", "-o", "json"))
+	if err != nil {
+		t.Fatalf("failed to get volumes. args %q : %v", rr.Command(), err)
+	}
+	pvs := core.PersistentVolumeList{}
+	if err := json.Unmarshal(rr.Stdout.Bytes(), &pvs); err != nil {
+		t.Fatalf("failed to decode volumes: %v", err)
+	}
+	nodeOf := map[string]string{}
+	for _, pv := range pvs.Items {
+		if pv.Spec.ClaimRef == nil || pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+			continue
+		}
+		for _, term := range pv.Spec.NodeAffinity.Required.NodeSelectorTerms {
+			for _, e := range term.MatchExpressions {
+				if e.Key == core.LabelHostname && len(e.Values) == 1 {
+					nodeOf[pv.Spec.ClaimRef.Name] = e.Values[0]
+				}
+			}
+		}
+	}
+
+	nodes := map[string]bool{}
+	for _, pod := range pods.Items {
+		nodes[pod.Spec.NodeName] = true
+		claim := "data-" + pod.Name
+		if nodeOf[claim] != pod.Spec.NodeName {
+			t.Errorf("volume of claim %s is pinned to node %q, want %q", claim, nodeOf[claim], pod.Spec.NodeName)
+		}
+	}
+	if len(nodes) != 3 {
+		t.Errorf("expected statefulset pods on 3 nodes, got %v", nodes)
+	}
+}
+
 func validateProfileListWithMultiNode(ctx context.Context, t *testing.T, profile string) {
 	rr, err := Run(t, exec.CommandContext(ctx, Target(), "profile", "list", "--output", "json"))
 	if err != nil {